@@ -0,0 +1,100 @@
+// Command replay runs a GenerateRequest captured by debug/capture through the
+// plugin and writes the generated files to a directory, so that plugin bugs
+// can be reproduced and bisected without sqlc and a database:
+//
+//	go run ./cmd/replay -out /tmp/out debug/capture/output/captured_input.json
+//
+// It exits with status 1 when the generation fails, which makes it usable
+// with git bisect run.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+
+	golang "github.com/sqlc-dev/sqlc-gen-go/internal"
+)
+
+const defaultInput = "debug/capture/output/captured_input.json"
+
+func main() {
+	out := flag.String("out", "debug/output", "directory the generated files are written to")
+	options := flag.String("options", "", "JSON file replacing the plugin options of the captured request")
+	clean := flag.Bool("clean", false, "remove the output directory before writing the files")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: replay [flags] [captured_input.json]\n\nThe input defaults to %s.\n\n", defaultInput)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	input := defaultInput
+	if flag.NArg() == 1 {
+		input = flag.Arg(0)
+	}
+
+	if err := run(input, *options, *out, *clean); err != nil {
+		fmt.Fprintf(os.Stderr, "replay: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(input, options, out string, clean bool) error {
+	req, err := readRequest(input, options)
+	if err != nil {
+		return err
+	}
+	resp, err := golang.Generate(context.Background(), req)
+	if err != nil {
+		return fmt.Errorf("generate: %w", err)
+	}
+
+	if clean {
+		if err := os.RemoveAll(out); err != nil {
+			return err
+		}
+	}
+	for _, f := range resp.Files {
+		path := filepath.Join(out, filepath.FromSlash(f.Name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, f.Contents, 0o644); err != nil {
+			return err
+		}
+		fmt.Println(path)
+	}
+	return nil
+}
+
+// readRequest reads a request captured by debug/capture, replacing its plugin
+// options with the contents of the options file if one is given
+func readRequest(input, options string) (*plugin.GenerateRequest, error) {
+	data, err := os.ReadFile(input)
+	if err != nil {
+		return nil, err
+	}
+	var req plugin.GenerateRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, fmt.Errorf("%s: %w", input, err)
+	}
+	if options != "" {
+		data, err := os.ReadFile(options)
+		if err != nil {
+			return nil, err
+		}
+		if !json.Valid(data) {
+			return nil, fmt.Errorf("%s is not valid JSON", options)
+		}
+		req.PluginOptions = data
+	}
+	return &req, nil
+}
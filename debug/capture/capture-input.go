@@ -4,9 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
+	"github.com/pmezard/go-difflib/difflib"
 	"github.com/sqlc-dev/plugin-sdk-go/codegen"
 	"github.com/sqlc-dev/plugin-sdk-go/plugin"
 
@@ -34,8 +38,19 @@ func findProjectRoot(startDir string) string {
 }
 
 // This program captures the actual input that sqlc sends to the plugin
-// and also runs the real generation so sqlc doesn't fail
+// and also runs the real generation so sqlc doesn't fail.
+//
+// Run as "capture-input diff <previous> <current>" it prints a unified diff
+// of two captured responses instead.
 func main() {
+	if len(os.Args) == 4 && os.Args[1] == "diff" {
+		if err := diffCaptures(os.Stderr, os.Args[2], os.Args[3]); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Use the standard codegen.Run approach but intercept the request
 	codegen.Run(func(ctx context.Context, req *plugin.GenerateRequest) (*plugin.GenerateResponse, error) {
 		return captureAndGenerate(ctx, req)
@@ -143,5 +158,127 @@ func captureAndGenerate(ctx context.Context, req *plugin.GenerateRequest) (*plug
 
 	fmt.Fprintf(os.Stderr, "🎯 Generated %d files - ready for debugging!\n", len(resp.Files))
 
+	saveResponse(debugDir, resp)
+
 	return resp, nil
 }
+
+// capturedFile is a generated file of a captured response, its contents are
+// kept as text so that captures can be read and diffed
+type capturedFile struct {
+	Name     string `json:"name"`
+	Contents string `json:"contents"`
+}
+
+// saveResponse saves the generated files to captured_response.json, the
+// previous capture is kept as captured_response.prev.json to diff against
+func saveResponse(debugDir string, resp *plugin.GenerateResponse) {
+	path := filepath.Join(debugDir, "captured_response.json")
+	if _, err := os.Stat(path); err == nil {
+		if err := os.Rename(path, filepath.Join(debugDir, "captured_response.prev.json")); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Failed to keep the previous response: %v\n", err)
+		}
+	}
+
+	files := make([]capturedFile, 0, len(resp.Files))
+	for _, f := range resp.Files {
+		files = append(files, capturedFile{Name: f.Name, Contents: string(f.Contents)})
+	}
+	data, err := json.MarshalIndent(files, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to marshal response: %v\n", err)
+		return
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Failed to save response to %s: %v\n", path, err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "✅ Captured response saved to %s\n", path)
+}
+
+func readCapture(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var files []capturedFile
+	if err := json.Unmarshal(data, &files); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", path, err)
+	}
+	contents := make(map[string]string, len(files))
+	for _, f := range files {
+		contents[f.Name] = f.Contents
+	}
+	return contents, nil
+}
+
+// diffCaptures writes a unified diff per generated file that differs between
+// two captured responses, added and removed files are diffed against nothing
+func diffCaptures(w io.Writer, previousPath, currentPath string) error {
+	previous, err := readCapture(previousPath)
+	if err != nil {
+		return err
+	}
+	current, err := readCapture(currentPath)
+	if err != nil {
+		return err
+	}
+
+	names := map[string]struct{}{}
+	for name := range previous {
+		names[name] = struct{}{}
+	}
+	for name := range current {
+		names[name] = struct{}{}
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	changed := 0
+	for _, name := range sorted {
+		a, inPrevious := previous[name]
+		b, inCurrent := current[name]
+		if inPrevious && inCurrent && a == b {
+			continue
+		}
+		changed++
+		diff := difflib.UnifiedDiff{
+			A:        splitLines(a),
+			B:        splitLines(b),
+			FromFile: "a/" + name,
+			ToFile:   "b/" + name,
+			Context:  3,
+		}
+		if !inPrevious {
+			diff.FromFile = "/dev/null"
+		}
+		if !inCurrent {
+			diff.ToFile = "/dev/null"
+		}
+		if err := difflib.WriteUnifiedDiff(w, diff); err != nil {
+			return err
+		}
+	}
+	if changed == 0 {
+		fmt.Fprintf(w, "✅ No changes in %d generated files\n", len(current))
+	} else {
+		fmt.Fprintf(w, "📊 %d of %d generated files changed\n", changed, len(sorted))
+	}
+	return nil
+}
+
+// splitLines splits s into lines that keep their newline
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := difflib.SplitLines(s)
+	if strings.HasSuffix(s, "\n") {
+		// SplitLines adds an empty last line after the final newline
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
@@ -12,22 +12,31 @@ ORIGINAL_PWD="$(pwd)"
 
 # Show help if requested
 if [[ "$1" == "--help" ]] || [[ "$1" == "-h" ]]; then
-    echo "Usage: $0 [directory]"
+    echo "Usage: $0 [--diff] [directory]"
     echo ""
     echo "Captures real sqlc input data for debugging the plugin."
     echo ""
     echo "Arguments:"
     echo "  directory    Path to directory containing sqlc.yaml (default: example/sqlcin)"
     echo ""
+    echo "Options:"
+    echo "  --diff       Print a unified diff of the generated files against the previous capture"
+    echo ""
     echo "Examples:"
     echo "  $0                                                              # Use default"
     echo "  $0 /path/to/your/sqlc/project                                   # Absolute path"
     echo "  $0 ../../hired-rocks/platform-backend/tools/db-generator/platform  # Relative path"
+    echo "  $0 --diff                                                       # Diff against the last capture"
     echo ""
     exit 0
 fi
 
 # Parse arguments
+DIFF=false
+if [[ "$1" == "--diff" ]]; then
+    DIFF=true
+    shift
+fi
 SQLC_DIR="${1:-example/sqlcin}"
 
 # Convert to absolute path if it's relative and doesn't start with /
@@ -104,6 +113,18 @@ if [ -f debug/capture/output/captured_input.json ]; then
     echo "   go run debug/main.go"
     echo ""
     echo "Or use the 'Debug Plugin with Real Data' configuration in VS Code"
+
+    if [ "$DIFF" = true ]; then
+        if [ -f debug/capture/output/captured_response.prev.json ]; then
+            echo ""
+            echo "🔍 Changes since the previous capture:"
+            debug/capture/output/capture-input diff \
+                debug/capture/output/captured_response.prev.json \
+                debug/capture/output/captured_response.json
+        else
+            echo "⚠️  No previous capture to diff against"
+        fi
+    fi
 else
     echo "❌ Failed to capture input data"
     exit 1
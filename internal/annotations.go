@@ -0,0 +1,233 @@
+package golang
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sqlc-dev/plugin-sdk-go/metadata"
+)
+
+// Query annotations are comment lines such as "-- @route writer" that
+// configure the code generated for a query rather than document it
+const (
+	annotationPrefix = "@"
+
+	annotationRoute    = "route"
+	annotationTimeout  = "timeout"
+	annotationRetry    = "retry"
+	annotationCache    = "cache"
+	annotationPrepare  = "prepare"
+	annotationBulk     = "bulk"
+	annotationUpsert   = "upsert"
+	annotationPaginate = "paginate"
+	annotationDerive   = "derive"
+	annotationFilter   = "filter"
+	annotationOrderBy  = "order_by"
+)
+
+var knownAnnotations = map[string]struct{}{
+	annotationRoute:    {},
+	annotationTimeout:  {},
+	annotationRetry:    {},
+	annotationCache:    {},
+	annotationPrepare:  {},
+	annotationBulk:     {},
+	annotationUpsert:   {},
+	annotationPaginate: {},
+	annotationDerive:   {},
+	annotationFilter:   {},
+	annotationOrderBy:  {},
+}
+
+// splitAnnotations separates the annotations from the other comment lines of
+// a query, which are kept as its doc comment. Lines starting with an unknown
+// @key are documentation too.
+func splitAnnotations(comments []string) ([]string, map[string]string) {
+	var doc []string
+	annotations := map[string]string{}
+	for _, line := range comments {
+		key, value, _ := strings.Cut(strings.TrimSpace(line), " ")
+		if name, ok := strings.CutPrefix(key, annotationPrefix); ok {
+			if _, known := knownAnnotations[name]; known {
+				annotations[name] = strings.TrimSpace(value)
+				continue
+			}
+		}
+		doc = append(doc, line)
+	}
+	return doc, annotations
+}
+
+// Routes of read/write splitting
+const (
+	routeReader = "reader"
+	routeWriter = "writer"
+)
+
+// queryRoute returns the handle a query runs on when reads and writes are
+// split. Queries returning rows read, unless annotated otherwise. :copyfrom
+// queries always write.
+func queryRoute(cmd string, annotations map[string]string) (string, error) {
+	switch route := annotations[annotationRoute]; route {
+	case routeReader:
+		if cmd == metadata.CmdCopyFrom {
+			return "", fmt.Errorf("%s queries cannot be routed to %s", cmd, routeReader)
+		}
+		return route, nil
+	case routeWriter:
+		return route, nil
+	case "":
+	default:
+		return "", fmt.Errorf("unknown route %q, must be %s or %s", route, routeReader, routeWriter)
+	}
+	switch cmd {
+	case metadata.CmdOne, metadata.CmdMany, metadata.CmdBatchOne, metadata.CmdBatchMany:
+		return routeReader, nil
+	default:
+		return routeWriter, nil
+	}
+}
+
+var durationUnits = []struct {
+	Unit time.Duration
+	Name string
+}{
+	{time.Hour, "Hour"},
+	{time.Minute, "Minute"},
+	{time.Second, "Second"},
+	{time.Millisecond, "Millisecond"},
+	{time.Microsecond, "Microsecond"},
+	{time.Nanosecond, "Nanosecond"},
+}
+
+// queryTimeout returns the Go expression of the duration a "-- @timeout 500ms"
+// annotation bounds the query to, e.g. 500 * time.Millisecond. Batch results
+// are read after the method returns, so batch queries can not have one.
+func queryTimeout(cmd string, annotations map[string]string) (string, error) {
+	value, ok := annotations[annotationTimeout]
+	if !ok {
+		return "", nil
+	}
+	switch cmd {
+	case metadata.CmdBatchExec, metadata.CmdBatchMany, metadata.CmdBatchOne:
+		return "", fmt.Errorf("timeout is not supported for %s queries", cmd)
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid timeout: %w", err)
+	}
+	if d <= 0 {
+		return "", fmt.Errorf("timeout %s must be positive", value)
+	}
+	return durationExpr(d), nil
+}
+
+// durationExpr returns the Go expression of d in its largest whole unit
+func durationExpr(d time.Duration) string {
+	for _, u := range durationUnits {
+		if d%u.Unit == 0 {
+			return fmt.Sprintf("%d * time.%s", d/u.Unit, u.Name)
+		}
+	}
+	return fmt.Sprintf("time.Duration(%d)", d)
+}
+
+func usesTimeout(queries []Query) bool {
+	for _, q := range queries {
+		if q.Timeout != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// queryRetries returns the number of times a "-- @retry 3" annotation allows
+// the query to be retried after a transient error. Batch results are read
+// after the method returns, so batch queries can not be retried.
+func queryRetries(cmd string, annotations map[string]string) (int, error) {
+	value, ok := annotations[annotationRetry]
+	if !ok {
+		return 0, nil
+	}
+	switch cmd {
+	case metadata.CmdBatchExec, metadata.CmdBatchMany, metadata.CmdBatchOne:
+		return 0, fmt.Errorf("retry is not supported for %s queries", cmd)
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("retry %s must be a positive number of retries", value)
+	}
+	return n, nil
+}
+
+// retriedQueries returns the queries annotated with retries, which the retry
+// decorator overrides
+func retriedQueries(queries []Query) []Query {
+	var retried []Query
+	for _, q := range queries {
+		if q.Retries > 0 {
+			retried = append(retried, q)
+		}
+	}
+	return retried
+}
+
+// queryCacheTTL returns the Go expression of the time the results of a query
+// annotated with "-- @cache ttl=30s" are cached for. Only the results of
+// queries reading rows can be cached.
+func queryCacheTTL(cmd string, annotations map[string]string) (string, error) {
+	value, ok := annotations[annotationCache]
+	if !ok {
+		return "", nil
+	}
+	if cmd != metadata.CmdOne && cmd != metadata.CmdMany {
+		return "", fmt.Errorf("cache is not supported for %s queries", cmd)
+	}
+	var ttl time.Duration
+	for _, setting := range strings.Fields(strings.ReplaceAll(value, ",", " ")) {
+		key, v, _ := strings.Cut(setting, "=")
+		switch key {
+		case "ttl":
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return "", fmt.Errorf("invalid cache ttl: %w", err)
+			}
+			ttl = d
+		default:
+			return "", fmt.Errorf("unknown cache setting %q", key)
+		}
+	}
+	if ttl <= 0 {
+		return "", fmt.Errorf("cache must set a positive ttl, e.g. ttl=30s")
+	}
+	return durationExpr(ttl), nil
+}
+
+// cachedQueries returns the queries annotated with a cache ttl, which the
+// caching decorator overrides
+func cachedQueries(queries []Query) []Query {
+	var cached []Query
+	for _, q := range queries {
+		if q.CacheTTL != "" {
+			cached = append(cached, q)
+		}
+	}
+	return cached
+}
+
+// queryUnprepared reports whether a "-- @prepare false" annotation opts the
+// query out of emit_prepared_queries, e.g. for statements a connection pooler
+// in transaction mode can not keep prepared
+func queryUnprepared(annotations map[string]string) (bool, error) {
+	value, ok := annotations[annotationPrepare]
+	if !ok {
+		return false, nil
+	}
+	prepare, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("prepare %s must be true or false", value)
+	}
+	return !prepare, nil
+}
@@ -0,0 +1,185 @@
+package golang
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/sqlc-dev/plugin-sdk-go/metadata"
+)
+
+func TestSplitAnnotations(t *testing.T) {
+	doc, annotations := splitAnnotations([]string{
+		" ListAuthors lists the authors.",
+		" @timeout 500ms",
+		" filter: the authors are filtered by name",
+		"\t@paginate keyset(created_at, id)",
+		" @param name is not an annotation",
+		" cache:",
+		" @order_by created_at, name",
+		" @prepare",
+	})
+	wantDoc := []string{
+		" ListAuthors lists the authors.",
+		" filter: the authors are filtered by name",
+		" @param name is not an annotation",
+		" cache:",
+	}
+	if !slices.Equal(doc, wantDoc) {
+		t.Errorf("splitAnnotations() doc = %q, want %q", doc, wantDoc)
+	}
+	wantAnnotations := map[string]string{
+		annotationTimeout:  "500ms",
+		annotationPaginate: "keyset(created_at, id)",
+		annotationOrderBy:  "created_at, name",
+		annotationPrepare:  "",
+	}
+	if !maps.Equal(annotations, wantAnnotations) {
+		t.Errorf("splitAnnotations() annotations = %q, want %q", annotations, wantAnnotations)
+	}
+}
+
+func TestQueryRoute(t *testing.T) {
+	for _, tc := range []struct {
+		cmd   string
+		route string
+		want  string
+		err   string
+	}{
+		{metadata.CmdOne, "", routeReader, ""},
+		{metadata.CmdMany, "", routeReader, ""},
+		{metadata.CmdBatchMany, "", routeReader, ""},
+		{metadata.CmdExec, "", routeWriter, ""},
+		{metadata.CmdCopyFrom, "", routeWriter, ""},
+		{metadata.CmdMany, routeWriter, routeWriter, ""},
+		{metadata.CmdExec, routeReader, routeReader, ""},
+		{metadata.CmdCopyFrom, routeReader, "", ":copyfrom queries cannot be routed to reader"},
+		{metadata.CmdOne, "replica", "", `unknown route "replica"`},
+	} {
+		annotations := map[string]string{}
+		if tc.route != "" {
+			annotations[annotationRoute] = tc.route
+		}
+		got, err := queryRoute(tc.cmd, annotations)
+		if tc.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("queryRoute(%s, %q) error = %v, want %q", tc.cmd, tc.route, err, tc.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("queryRoute(%s, %q): %v", tc.cmd, tc.route, err)
+		} else if got != tc.want {
+			t.Errorf("queryRoute(%s, %q) = %s, want %s", tc.cmd, tc.route, got, tc.want)
+		}
+	}
+}
+
+func TestQueryTimeout(t *testing.T) {
+	for _, tc := range []struct {
+		cmd     string
+		timeout string
+		want    string
+		err     string
+	}{
+		{metadata.CmdOne, "500ms", "500 * time.Millisecond", ""},
+		{metadata.CmdExec, "1m30s", "90 * time.Second", ""},
+		{metadata.CmdMany, "2h", "2 * time.Hour", ""},
+		{metadata.CmdOne, "soon", "", "invalid timeout"},
+		{metadata.CmdOne, "0s", "", "timeout 0s must be positive"},
+		{metadata.CmdBatchExec, "1s", "", "timeout is not supported for :batchexec queries"},
+	} {
+		got, err := queryTimeout(tc.cmd, map[string]string{annotationTimeout: tc.timeout})
+		if tc.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("queryTimeout(%s, %q) error = %v, want %q", tc.cmd, tc.timeout, err, tc.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("queryTimeout(%s, %q): %v", tc.cmd, tc.timeout, err)
+		} else if got != tc.want {
+			t.Errorf("queryTimeout(%s, %q) = %s, want %s", tc.cmd, tc.timeout, got, tc.want)
+		}
+	}
+	if got, err := queryTimeout(metadata.CmdBatchExec, map[string]string{}); got != "" || err != nil {
+		t.Errorf("queryTimeout() without annotation = %q, %v", got, err)
+	}
+}
+
+func TestQueryRetries(t *testing.T) {
+	for _, tc := range []struct {
+		cmd   string
+		retry string
+		want  int
+		err   string
+	}{
+		{metadata.CmdOne, "3", 3, ""},
+		{metadata.CmdExec, "1", 1, ""},
+		{metadata.CmdOne, "0", 0, "retry 0 must be a positive number of retries"},
+		{metadata.CmdOne, "often", 0, "retry often must be a positive number of retries"},
+		{metadata.CmdBatchOne, "3", 0, "retry is not supported for :batchone queries"},
+	} {
+		got, err := queryRetries(tc.cmd, map[string]string{annotationRetry: tc.retry})
+		if tc.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("queryRetries(%s, %q) error = %v, want %q", tc.cmd, tc.retry, err, tc.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("queryRetries(%s, %q): %v", tc.cmd, tc.retry, err)
+		} else if got != tc.want {
+			t.Errorf("queryRetries(%s, %q) = %d, want %d", tc.cmd, tc.retry, got, tc.want)
+		}
+	}
+}
+
+func TestQueryCacheTTL(t *testing.T) {
+	for _, tc := range []struct {
+		cmd   string
+		cache string
+		want  string
+		err   string
+	}{
+		{metadata.CmdOne, "ttl=30s", "30 * time.Second", ""},
+		{metadata.CmdMany, "ttl=1m30s", "90 * time.Second", ""},
+		{metadata.CmdMany, " ttl=2h, ", "2 * time.Hour", ""},
+		{metadata.CmdExec, "ttl=30s", "", "cache is not supported for :exec queries"},
+		{metadata.CmdOne, "ttl=soon", "", "invalid cache ttl"},
+		{metadata.CmdOne, "size=10", "", `unknown cache setting "size"`},
+		{metadata.CmdOne, "", "", "cache must set a positive ttl"},
+		{metadata.CmdOne, "ttl=0s", "", "cache must set a positive ttl"},
+	} {
+		got, err := queryCacheTTL(tc.cmd, map[string]string{annotationCache: tc.cache})
+		if tc.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("queryCacheTTL(%s, %q) error = %v, want %q", tc.cmd, tc.cache, err, tc.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("queryCacheTTL(%s, %q): %v", tc.cmd, tc.cache, err)
+		} else if got != tc.want {
+			t.Errorf("queryCacheTTL(%s, %q) = %s, want %s", tc.cmd, tc.cache, got, tc.want)
+		}
+	}
+}
+
+func TestQueryUnprepared(t *testing.T) {
+	for value, want := range map[string]bool{"false": true, "true": false, "0": true} {
+		got, err := queryUnprepared(map[string]string{annotationPrepare: value})
+		if err != nil {
+			t.Errorf("queryUnprepared(%q): %v", value, err)
+		} else if got != want {
+			t.Errorf("queryUnprepared(%q) = %t, want %t", value, got, want)
+		}
+	}
+	if got, err := queryUnprepared(map[string]string{}); got || err != nil {
+		t.Errorf("queryUnprepared() without annotation = %t, %v", got, err)
+	}
+	if _, err := queryUnprepared(map[string]string{annotationPrepare: "never"}); err == nil {
+		t.Error("queryUnprepared(\"never\") succeeded")
+	}
+}
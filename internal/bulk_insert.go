@@ -0,0 +1,98 @@
+package golang
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sqlc-dev/plugin-sdk-go/metadata"
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+	"github.com/sqlc-dev/plugin-sdk-go/sdk"
+)
+
+// bulkUnnest is the value of the "-- @bulk unnest" annotation, generating a
+// variant of a single-row INSERT inserting the rows of arrays with unnest
+const bulkUnnest = "unnest"
+
+var (
+	valuesKeyword = regexp.MustCompile(`(?i)\bVALUES\s*\(`)
+	paramRef      = regexp.MustCompile(`\$([0-9]+)\b`)
+)
+
+// queryBulkSQL returns the SQL of the bulk variant of a query annotated with
+// "-- @bulk unnest", in which the parameters of the VALUES row are replaced by
+// the columns of unnest($1::type[], ...)
+func queryBulkSQL(query *plugin.Query, annotations map[string]string) (string, error) {
+	value, ok := annotations[annotationBulk]
+	if !ok {
+		return "", nil
+	}
+	if value != bulkUnnest {
+		return "", fmt.Errorf("unknown bulk mode %q, must be %s", value, bulkUnnest)
+	}
+	switch query.Cmd {
+	case metadata.CmdExec, metadata.CmdExecRows, metadata.CmdExecResult:
+	default:
+		return "", fmt.Errorf("bulk is not supported for %s queries", query.Cmd)
+	}
+	if len(query.Params) == 0 {
+		return "", fmt.Errorf("bulk requires a query with parameters")
+	}
+
+	sql := query.Text
+	loc := valuesKeyword.FindStringIndex(sql)
+	if loc == nil {
+		return "", fmt.Errorf("bulk requires an INSERT ... VALUES (...) query")
+	}
+	end := closingParen(sql, loc[1])
+	if end < 0 {
+		return "", fmt.Errorf("bulk requires an INSERT ... VALUES (...) query")
+	}
+	if rest := strings.TrimSpace(sql[end+1:]); strings.HasPrefix(rest, ",") {
+		return "", fmt.Errorf("bulk requires a single VALUES row")
+	}
+	if paramRef.MatchString(sql[:loc[0]]) || paramRef.MatchString(sql[end+1:]) {
+		return "", fmt.Errorf("bulk requires the parameters to only be used in VALUES")
+	}
+
+	params := append([]*plugin.Parameter{}, query.Params...)
+	sort.Slice(params, func(i, j int) bool { return params[i].Number < params[j].Number })
+	arrays := make([]string, len(params))
+	columns := make([]string, len(params))
+	for i, p := range params {
+		if p.Column.IsArray {
+			return "", fmt.Errorf("bulk does not support the array parameter $%d", p.Number)
+		}
+		arrays[i] = fmt.Sprintf("$%d::%s[]", p.Number, sdk.DataType(p.Column.Type))
+		columns[i] = "p" + strconv.Itoa(int(p.Number))
+	}
+	row := paramRef.ReplaceAllString(sql[loc[1]:end], "bulk.p$1")
+	return fmt.Sprintf("%sSELECT %s FROM unnest(%s) AS bulk(%s)%s",
+		sql[:loc[0]], row, strings.Join(arrays, ", "), strings.Join(columns, ", "), sql[end+1:]), nil
+}
+
+// closingParen returns the index of the parenthesis closing the one before
+// start, skipping quoted strings and identifiers, or -1
+func closingParen(sql string, start int) int {
+	depth := 1
+	for i := start; i < len(sql); i++ {
+		switch sql[i] {
+		case '\'', '"':
+			next := strings.IndexByte(sql[i+1:], sql[i])
+			if next < 0 {
+				return -1
+			}
+			i += next + 1
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
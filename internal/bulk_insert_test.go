@@ -0,0 +1,83 @@
+package golang
+
+import (
+	"testing"
+
+	"github.com/sqlc-dev/plugin-sdk-go/metadata"
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+)
+
+func TestQueryBulkSQL(t *testing.T) {
+	param := func(n int32, typ string) *plugin.Parameter {
+		return &plugin.Parameter{Number: n, Column: &plugin.Column{Type: &plugin.Identifier{Name: typ}}}
+	}
+	tests := []struct {
+		name    string
+		sql     string
+		cmd     string
+		params  []*plugin.Parameter
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "values",
+			sql:    "INSERT INTO authors (id, name) VALUES ($1, $2)",
+			cmd:    metadata.CmdExec,
+			params: []*plugin.Parameter{param(1, "uuid"), param(2, "text")},
+			want:   "INSERT INTO authors (id, name) SELECT bulk.p1, bulk.p2 FROM unnest($1::uuid[], $2::text[]) AS bulk(p1, p2)",
+		},
+		{
+			name:   "expressions and conflict clause",
+			sql:    "INSERT INTO authors (id, name, bio) values ($2, lower($1), ')') ON CONFLICT DO NOTHING",
+			cmd:    metadata.CmdExecRows,
+			params: []*plugin.Parameter{param(2, "uuid"), param(1, "text")},
+			want:   "INSERT INTO authors (id, name, bio) SELECT bulk.p2, lower(bulk.p1), ')' FROM unnest($1::text[], $2::uuid[]) AS bulk(p1, p2) ON CONFLICT DO NOTHING",
+		},
+		{
+			name:    "multiple rows",
+			sql:     "INSERT INTO authors (id) VALUES ($1), ($2)",
+			cmd:     metadata.CmdExec,
+			params:  []*plugin.Parameter{param(1, "uuid"), param(2, "uuid")},
+			wantErr: true,
+		},
+		{
+			name:    "parameter outside values",
+			sql:     "INSERT INTO authors (id, name) VALUES ($1, $2) ON CONFLICT (id) DO UPDATE SET name = $2",
+			cmd:     metadata.CmdExec,
+			params:  []*plugin.Parameter{param(1, "uuid"), param(2, "text")},
+			wantErr: true,
+		},
+		{
+			name:    "not an insert",
+			sql:     "UPDATE authors SET name = $1",
+			cmd:     metadata.CmdExec,
+			params:  []*plugin.Parameter{param(1, "text")},
+			wantErr: true,
+		},
+		{
+			name:    "returning rows",
+			sql:     "INSERT INTO authors (id) VALUES ($1) RETURNING *",
+			cmd:     metadata.CmdOne,
+			params:  []*plugin.Parameter{param(1, "uuid")},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			query := &plugin.Query{Text: tc.sql, Cmd: tc.cmd, Params: tc.params}
+			got, err := queryBulkSQL(query, map[string]string{annotationBulk: bulkUnnest})
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("queryBulkSQL() = %q, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("queryBulkSQL() failed: %s", err)
+			}
+			if got != tc.want {
+				t.Errorf("queryBulkSQL() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
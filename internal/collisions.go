@@ -0,0 +1,96 @@
+package golang
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+// nameSources records the database identifiers mapped to the Go names of a
+// scope, such as the types of a package or the fields of a struct
+type nameSources struct {
+	scope   string
+	names   []string
+	sources map[string][]string
+}
+
+func newNameSources(scope string) *nameSources {
+	return &nameSources{scope: scope, sources: map[string][]string{}}
+}
+
+func (n *nameSources) add(name, source string) {
+	if _, ok := n.sources[name]; !ok {
+		n.names = append(n.names, name)
+	}
+	if !slices.Contains(n.sources[name], source) {
+		n.sources[name] = append(n.sources[name], source)
+	}
+}
+
+// collisions describes the names that several identifiers are mapped to
+func (n *nameSources) collisions() []string {
+	var report []string
+	for _, name := range n.names {
+		if sources := n.sources[name]; len(sources) > 1 {
+			report = append(report, fmt.Sprintf("%s: %s are all named %s", n.scope, strings.Join(sources, ", "), name))
+		}
+	}
+	return report
+}
+
+// validateNameCollisions fails when the rename, initialisms and inflection
+// rules map different database identifiers to the same Go name: tables,
+// composite types and enums to the same type, enum values to the same
+// constant or columns to the same field. The error lists the identifiers of
+// every collision, the generated code would not compile.
+func validateNameCollisions(options *opts.Options, enums []Enum, structs []Struct) error {
+	var scopes []*nameSources
+	packages := map[string]*nameSources{}
+	pkgScope := func(pkg string) *nameSources {
+		if pkg == "" {
+			pkg = options.OutputModelsPackage
+		}
+		if _, ok := packages[pkg]; !ok {
+			scope := "package " + options.Package
+			if pkg != "" {
+				scope = "package " + pkg
+			}
+			packages[pkg] = newNameSources(scope)
+			scopes = append(scopes, packages[pkg])
+		}
+		return packages[pkg]
+	}
+
+	for _, s := range structs {
+		kind := "table"
+		if s.Positional {
+			kind = "composite type"
+		}
+		pkgScope(s.Package).add(s.Name, fmt.Sprintf("%s %s.%s", kind, s.Table.Schema, s.Table.Name))
+
+		fields := newNameSources("struct " + s.Name)
+		for _, f := range s.Fields {
+			fields.add(f.Name, "column "+f.Column.Name)
+		}
+		scopes = append(scopes, fields)
+	}
+	for _, e := range enums {
+		types := pkgScope(e.Package)
+		types.add(e.Name, "enum "+e.DBName)
+		types.add("Null"+e.Name, "enum "+e.DBName)
+		for _, c := range e.Constants {
+			types.add(c.Name, fmt.Sprintf("value %q of enum %s", c.Value, e.DBName))
+		}
+	}
+
+	var report []string
+	for _, scope := range scopes {
+		report = append(report, scope.collisions()...)
+	}
+	if len(report) > 0 {
+		return fmt.Errorf("database identifiers are renamed to the same Go name:\n\t%s", strings.Join(report, "\n\t"))
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package golang
+
+import (
+	"testing"
+
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+func TestValidateNameCollisions(t *testing.T) {
+	columns := []*plugin.Column{
+		{Name: "title", NotNull: true, Type: &plugin.Identifier{Name: "text"}},
+		{Name: "label", NotNull: true, Type: &plugin.Identifier{Name: "text"}},
+	}
+	req := &plugin.GenerateRequest{
+		Settings: &plugin.Settings{Engine: "postgresql"},
+		Catalog: &plugin.Catalog{
+			DefaultSchema: "public",
+			Schemas: []*plugin.Schema{{
+				Name: "public",
+				Tables: []*plugin.Table{
+					{Rel: &plugin.Identifier{Schema: "public", Name: "products"}, Columns: columns},
+					{Rel: &plugin.Identifier{Schema: "public", Name: "items"}, Columns: columns},
+				},
+				Enums: []*plugin.Enum{{Name: "mood", Vals: []string{"happy", "sad"}}},
+			}},
+		},
+	}
+	for _, tc := range []struct {
+		rename string
+		want   string
+	}{
+		{`{}`, ""},
+		{
+			`{"product": "Item", "title": "Name", "label": "Name", "mood_sad": "MoodHappy"}`,
+			"database identifiers are renamed to the same Go name:\n" +
+				"\tpackage db: table public.products, table public.items are all named Item\n" +
+				"\tpackage db: value \"happy\" of enum public.mood, value \"sad\" of enum public.mood are all named MoodHappy\n" +
+				"\tstruct Item: column title, column label are all named Name\n" +
+				"\tstruct Item: column title, column label are all named Name",
+		},
+	} {
+		req.PluginOptions = []byte(`{"package": "db", "rename": ` + tc.rename + `}`)
+		options, err := opts.Parse(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		enums, err := buildEnums(req, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = validateNameCollisions(options, enums, buildStructs(req, options))
+		if got := errorString(err); got != tc.want {
+			t.Errorf("validateNameCollisions() with rename %s error =\n%s\nwant\n%s", tc.rename, got, tc.want)
+		}
+	}
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,227 @@
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+// compositeType is a composite type of the catalog declared in
+// composite_types, with the columns its struct fields are generated from
+type compositeType struct {
+	Config  *opts.CompositeTypeConfig
+	Schema  string
+	Name    string
+	Comment string
+	Columns []*plugin.Column
+}
+
+// findCompositeType returns the configured attributes of the composite type
+// schema.name, or nil if the type has not been declared in composite_types
+func findCompositeType(options *opts.Options, defaultSchema, schema, name string) *opts.CompositeTypeConfig {
+	for _, ct := range options.CompositeTypes {
+		rel, err := parseIdentifierString(ct.Name)
+		if err != nil {
+			continue
+		}
+		if rel.Schema == "" {
+			rel.Schema = defaultSchema
+		}
+		if rel.Schema == schema && rel.Name == name {
+			return ct
+		}
+	}
+	return nil
+}
+
+// compositeStructName returns the name of the struct generated for a
+// composite type, following the naming of enums in non-default schemas
+func compositeStructName(options *opts.Options, defaultSchema, schema, name string) string {
+	if schema == defaultSchema || options.SchemaPackages {
+		return StructName(name, options)
+	}
+	return StructName(schema+"_"+name, options)
+}
+
+// compositeGoType returns the Go type of a column of the composite type
+// schema.name, qualified with the package its struct is generated in
+func compositeGoType(options *opts.Options, defaultSchema, schema, name string, notNull bool) string {
+	structName := compositeStructName(options, defaultSchema, schema, name)
+	if pkg := schemaPackage(options, defaultSchema, schema); pkg != "" {
+		structName = pkg + "." + structName
+	} else if options.ModelsPackageImportPath != "" {
+		structName = options.OutputModelsPackage + "." + structName
+	}
+	if notNull {
+		return structName
+	}
+	return "*" + structName
+}
+
+// resolveCompositeTypes matches composite_types against the catalog, in the
+// order they were declared. The row type of a table takes its attributes from
+// the table's columns, and declared fields must match them. sqlc does not pass
+// the attributes of CREATE TYPE ... AS composites to plugins, so those must
+// declare their fields.
+func resolveCompositeTypes(req *plugin.GenerateRequest, options *opts.Options) ([]compositeType, error) {
+	var types []compositeType
+	for _, cfg := range options.CompositeTypes {
+		rel, err := parseIdentifierString(cfg.Name)
+		if err != nil {
+			return nil, fmt.Errorf("composite type %s: %w", cfg.Name, err)
+		}
+		if rel.Schema == "" {
+			rel.Schema = req.Catalog.DefaultSchema
+		}
+		ct, err := resolveCompositeType(req, cfg, rel)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, *ct)
+	}
+	return types, nil
+}
+
+func resolveCompositeType(req *plugin.GenerateRequest, cfg *opts.CompositeTypeConfig, rel *plugin.Identifier) (*compositeType, error) {
+	for _, schema := range req.Catalog.Schemas {
+		if schema.Name != rel.Schema {
+			continue
+		}
+		for _, table := range schema.Tables {
+			if table.Rel.Name != rel.Name {
+				continue
+			}
+			if err := checkCompositeFields(cfg, table.Columns); err != nil {
+				return nil, err
+			}
+			return &compositeType{Config: cfg, Schema: schema.Name, Name: rel.Name, Comment: table.Comment, Columns: table.Columns}, nil
+		}
+		for _, ct := range schema.CompositeTypes {
+			if ct.Name != rel.Name {
+				continue
+			}
+			if len(cfg.Fields) == 0 {
+				return nil, fmt.Errorf("composite type %s must declare its fields, sqlc does not pass them to plugins", cfg.Name)
+			}
+			var columns []*plugin.Column
+			for _, f := range cfg.Fields {
+				columns = append(columns, compositeFieldColumn(f))
+			}
+			return &compositeType{Config: cfg, Schema: schema.Name, Name: rel.Name, Comment: ct.Comment, Columns: columns}, nil
+		}
+	}
+	return nil, fmt.Errorf("composite type %s is neither a composite type nor a table of the catalog", cfg.Name)
+}
+
+// compositeFieldColumn returns the column described by a declared field. An
+// array db_type such as text[] becomes an array column of its element type.
+func compositeFieldColumn(f *opts.CompositeFieldConfig) *plugin.Column {
+	dbType, isArray := strings.CutSuffix(f.DBType, "[]")
+	typ, err := parseIdentifierString(dbType)
+	if err != nil {
+		typ = &plugin.Identifier{Name: dbType}
+	}
+	column := &plugin.Column{Name: f.Name, Type: typ, NotNull: f.NotNull, IsArray: isArray}
+	if isArray {
+		column.ArrayDims = 1
+	}
+	return column
+}
+
+// checkCompositeFields reports declared fields that differ from the columns
+// of a table. Types are compared by name, so they must be spelled the way
+// the catalog reports them, e.g. int4 rather than integer.
+func checkCompositeFields(cfg *opts.CompositeTypeConfig, columns []*plugin.Column) error {
+	if len(cfg.Fields) == 0 {
+		return nil
+	}
+	if len(cfg.Fields) != len(columns) {
+		return fmt.Errorf("composite type %s declares %d fields, the table has %d columns", cfg.Name, len(cfg.Fields), len(columns))
+	}
+	for i, f := range cfg.Fields {
+		want := compositeFieldColumn(f)
+		col := columns[i]
+		switch {
+		case want.Name != col.Name:
+			return fmt.Errorf("composite type %s: field %d is %s, the table column is %s", cfg.Name, i+1, want.Name, col.Name)
+		case !sameTypeName(want.Type, col.Type):
+			return fmt.Errorf("composite type %s: field %s has db_type %s, the table column has %s", cfg.Name, f.Name, f.DBType, col.Type.GetName())
+		case want.IsArray != col.IsArray:
+			return fmt.Errorf("composite type %s: field %s array-ness differs from the table column", cfg.Name, f.Name)
+		case want.NotNull != col.NotNull:
+			return fmt.Errorf("composite type %s: field %s has not_null %t, the table column has %t", cfg.Name, f.Name, f.NotNull, col.NotNull)
+		}
+	}
+	return nil
+}
+
+// sameTypeName reports whether two type identifiers name the same type, the
+// schema being compared only when both carry one
+func sameTypeName(a, b *plugin.Identifier) bool {
+	if a.GetName() != b.GetName() {
+		return false
+	}
+	return a.GetSchema() == "" || b.GetSchema() == "" || a.GetSchema() == b.GetSchema()
+}
+
+// buildCompositeStructs generates a struct for every declared composite type
+// of the catalog. pgx/v5 scans composite values into structs field by field,
+// once the type has been registered on the connection.
+func buildCompositeStructs(req *plugin.GenerateRequest, options *opts.Options) []Struct {
+	if typesDriver(options) != opts.SQLDriverPGXV5 {
+		return nil
+	}
+
+	// Errors have been reported by Generate before the structs are built
+	types, _ := resolveCompositeTypes(req, options)
+	var structs []Struct
+	for _, ct := range types {
+		pkg := schemaPackage(options, req.Catalog.DefaultSchema, ct.Schema)
+		if pkg == "" {
+			pkg = options.OutputModelsPackage
+		}
+		s := Struct{
+			Table:      &plugin.Identifier{Schema: ct.Schema, Name: ct.Name},
+			Name:       compositeStructName(options, req.Catalog.DefaultSchema, ct.Schema, ct.Name),
+			Package:    pkg,
+			Comment:    ct.Comment,
+			Positional: true,
+		}
+		for _, column := range ct.Columns {
+			tags := map[string]string{}
+			if options.EmitDbTags {
+				tags["db"] = column.Name
+			}
+			if options.EmitJsonTags {
+				tags["json"] = JSONTagName(column.Name, options)
+			}
+			addExtraGoStructTags(tags, req, options, column)
+			s.Fields = append(s.Fields, Field{
+				Name:   StructName(column.Name, options),
+				Type:   goType(req, options, column),
+				Tags:   tags,
+				Column: column,
+			})
+		}
+		structs = append(structs, s)
+	}
+	return structs
+}
+
+// compositeTypeNames returns the database names of the composite types that
+// have generated structs, in the order they were declared, for registration
+// on pgx connections
+func compositeTypeNames(req *plugin.GenerateRequest, options *opts.Options) []string {
+	if parseDriver(options.SqlPackage) != opts.SQLDriverPGXV5 {
+		return nil
+	}
+
+	types, _ := resolveCompositeTypes(req, options)
+	var names []string
+	for _, ct := range types {
+		names = append(names, ct.Config.Name)
+	}
+	return names
+}
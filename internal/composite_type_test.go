@@ -0,0 +1,107 @@
+package golang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+func TestResolveCompositeTypes(t *testing.T) {
+	ident := func(name string) *plugin.Identifier { return &plugin.Identifier{Name: name} }
+	req := &plugin.GenerateRequest{Catalog: &plugin.Catalog{
+		DefaultSchema: "public",
+		Schemas: []*plugin.Schema{{
+			Name:           "public",
+			CompositeTypes: []*plugin.CompositeType{{Name: "address"}},
+			Tables: []*plugin.Table{{
+				Rel: &plugin.Identifier{Schema: "public", Name: "books"},
+				Columns: []*plugin.Column{
+					{Name: "id", Type: ident("int8"), NotNull: true},
+					{Name: "tags", Type: ident("text"), IsArray: true, ArrayDims: 1},
+				},
+			}},
+		}},
+	}}
+	address := []*opts.CompositeFieldConfig{{Name: "street", DBType: "text"}, {Name: "lines", DBType: "text[]", NotNull: true}}
+
+	for _, tc := range []struct {
+		name    string
+		config  *opts.CompositeTypeConfig
+		columns []string
+		err     string
+	}{
+		{"declared", &opts.CompositeTypeConfig{Name: "address", Fields: address}, []string{"street text", "lines text[] not null"}, ""},
+		{"qualified", &opts.CompositeTypeConfig{Name: "public.address", Fields: address}, []string{"street text", "lines text[] not null"}, ""},
+		{"table", &opts.CompositeTypeConfig{Name: "books"}, []string{"id int8 not null", "tags text[]"}, ""},
+		{"table fields", &opts.CompositeTypeConfig{Name: "books", Fields: []*opts.CompositeFieldConfig{
+			{Name: "id", DBType: "pg_catalog.int8", NotNull: true},
+			{Name: "tags", DBType: "text[]"},
+		}}, []string{"id int8 not null", "tags text[]"}, ""},
+		{"unknown", &opts.CompositeTypeConfig{Name: "adress", Fields: address}, nil, "neither a composite type nor a table"},
+		{"other schema", &opts.CompositeTypeConfig{Name: "billing.address", Fields: address}, nil, "neither a composite type nor a table"},
+		{"undeclared fields", &opts.CompositeTypeConfig{Name: "address"}, nil, "must declare its fields"},
+		{"field count", &opts.CompositeTypeConfig{Name: "books", Fields: []*opts.CompositeFieldConfig{
+			{Name: "id", DBType: "int8", NotNull: true},
+		}}, nil, "declares 1 fields, the table has 2 columns"},
+		{"field name", &opts.CompositeTypeConfig{Name: "books", Fields: []*opts.CompositeFieldConfig{
+			{Name: "id", DBType: "int8", NotNull: true},
+			{Name: "labels", DBType: "text[]"},
+		}}, nil, "field 2 is labels, the table column is tags"},
+		{"field type", &opts.CompositeTypeConfig{Name: "books", Fields: []*opts.CompositeFieldConfig{
+			{Name: "id", DBType: "int4", NotNull: true},
+			{Name: "tags", DBType: "text[]"},
+		}}, nil, "field id has db_type int4, the table column has int8"},
+		{"field array", &opts.CompositeTypeConfig{Name: "books", Fields: []*opts.CompositeFieldConfig{
+			{Name: "id", DBType: "int8", NotNull: true},
+			{Name: "tags", DBType: "text"},
+		}}, nil, "field tags array-ness differs"},
+		{"field not null", &opts.CompositeTypeConfig{Name: "books", Fields: []*opts.CompositeFieldConfig{
+			{Name: "id", DBType: "int8"},
+			{Name: "tags", DBType: "text[]"},
+		}}, nil, "field id has not_null false, the table column has true"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			options := &opts.Options{SqlPackage: "pgx/v5", CompositeTypes: []*opts.CompositeTypeConfig{tc.config}}
+			types, err := resolveCompositeTypes(req, options)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("resolveCompositeTypes() error = %v, want %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var columns []string
+			for _, c := range types[0].Columns {
+				desc := c.Name + " " + c.Type.Name
+				if c.IsArray {
+					desc += "[]"
+				}
+				if c.NotNull {
+					desc += " not null"
+				}
+				columns = append(columns, desc)
+			}
+			if strings.Join(columns, ", ") != strings.Join(tc.columns, ", ") {
+				t.Errorf("columns = %q, want %q", columns, tc.columns)
+			}
+		})
+	}
+}
+
+func TestCompositeTypesDriver(t *testing.T) {
+	for _, sqlPackage := range []string{"pgx/v4", "database/sql"} {
+		req := &plugin.GenerateRequest{PluginOptions: []byte(`{"package": "db", "sql_package": "` + sqlPackage + `", "composite_types": [{"name": "books"}]}`)}
+		options, err := opts.Parse(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := opts.ValidateOpts(options); err == nil {
+			t.Errorf("composite_types is accepted with sql_package %s", sqlPackage)
+		}
+	}
+}
@@ -1,42 +1,139 @@
 package debug
 
 import (
+	"cmp"
+	"context"
 	"fmt"
-	"log"
+	"io"
+	"log/slog"
 	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
 )
 
+// Debug logging is enabled by the SQLC_DEBUG environment variable, or by the
+// debug_log option. Records are written to SQLC_DEBUG_LOG or debug_log, a file
+// path or "stderr", as text or, with SQLC_DEBUG_FORMAT or debug_log_format set
+// to "json", as JSON lines. sqlc only passes the environment variables listed
+// in the plugin config, and WASM plugins can only write to stderr.
+
+// DefaultDestination is the log file used when no destination is configured
+var DefaultDestination = filepath.Join(os.TempDir(), "sqlc-gen-go-debug.log")
+
+var (
+	debugEnabled bool
+	debugLogger  *slog.Logger
+	debugFile    *os.File
+)
+
+// Logger writes the records of a component of the plugin, which is the
+// component field of JSON records
+type Logger struct {
+	component string
+}
+
 var (
-	debugEnabled = os.Getenv("SQLC_DEBUG") != ""
-	debugLogger  *log.Logger
+	NestedBuilder = Logger{component: "nested-builder"}
+	Importer      = Logger{component: "importer"}
+	Templates     = Logger{component: "templates"}
+	Timings       = Logger{component: "timings"}
 )
 
 func init() {
-	if debugEnabled {
-		// Create debug log file
-		file, err := os.OpenFile("/tmp/sqlc-gen-go-debug.log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
-		if err != nil {
-			// Fallback to stderr if file creation fails
-			debugLogger = log.New(os.Stderr, "[SQLC-DEBUG] ", log.Ldate|log.Ltime|log.Lshortfile)
-		} else {
-			debugLogger = log.New(file, "[SQLC-DEBUG] ", log.Ldate|log.Ltime|log.Lshortfile)
+	if os.Getenv("SQLC_DEBUG") != "" {
+		if err := configure(os.Getenv("SQLC_DEBUG_LOG"), os.Getenv("SQLC_DEBUG_FORMAT")); err != nil {
+			Errorf("%s", err)
+		}
+	}
+}
+
+// Setup applies the debug_log and debug_log_format options, which take
+// precedence over the environment. A format alone only applies when debug
+// logging is enabled by SQLC_DEBUG.
+func Setup(destination, format string) error {
+	if destination == "" && (format == "" || !debugEnabled) {
+		return nil
+	}
+	return configure(cmp.Or(destination, os.Getenv("SQLC_DEBUG_LOG")), cmp.Or(format, os.Getenv("SQLC_DEBUG_FORMAT")))
+}
+
+func configure(destination, format string) error {
+	var w io.Writer = os.Stderr
+	var file *os.File
+	if destination != "stderr" {
+		path := cmp.Or(destination, DefaultDestination)
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+		switch {
+		case err == nil:
+			w, file = f, f
+		case destination != "":
+			return fmt.Errorf("debug log: %w", err)
+		}
+		// The default file falls back to stderr, e.g. in WASM sandboxes
+	}
+
+	handlerOptions := &slog.HandlerOptions{AddSource: true, Level: slog.LevelDebug}
+	var handler slog.Handler
+	switch format {
+	case "", "text":
+		handler = slog.NewTextHandler(w, handlerOptions)
+	case "json":
+		handler = slog.NewJSONHandler(w, handlerOptions)
+	default:
+		if file != nil {
+			file.Close()
 		}
+		return fmt.Errorf("debug log: unknown format %q, expected text or json", format)
+	}
+
+	if debugFile != nil {
+		debugFile.Close()
+	}
+	debugEnabled, debugLogger, debugFile = true, slog.New(handler), file
+	return nil
+}
+
+// Enabled reports whether debug logging is enabled
+func Enabled() bool {
+	return debugEnabled
+}
+
+func (l Logger) log(level slog.Level, format string, args ...interface{}) {
+	if !debugEnabled || debugLogger == nil {
+		return
 	}
+	// Skip runtime.Callers, log and the logging function to report the
+	// caller as the source
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:])
+	r := slog.NewRecord(time.Now(), level, fmt.Sprintf(format, args...), pcs[0])
+	if l.component != "" {
+		r.AddAttrs(slog.String("component", l.component))
+	}
+	_ = debugLogger.Handler().Handle(context.Background(), r)
+}
+
+// Printf writes a debug record of the component
+func (l Logger) Printf(format string, args ...interface{}) {
+	l.log(slog.LevelDebug, format, args...)
+}
+
+// Warnf writes a warning record of the component
+func (l Logger) Warnf(format string, args ...interface{}) {
+	l.log(slog.LevelWarn, format, args...)
 }
 
-// Printf writes debug output to log file or stderr when SQLC_DEBUG env var is set
+// Printf writes debug output to the debug log when debug logging is enabled
 // This is safe to use in protobuf plugins as it never writes to stdout
 func Printf(format string, args ...interface{}) {
-	if debugEnabled && debugLogger != nil {
-		debugLogger.Printf(format, args...)
-	}
+	Logger{}.log(slog.LevelDebug, format, args...)
 }
 
-// Println writes debug output to log file or stderr when SQLC_DEBUG env var is set
+// Println writes debug output to the debug log when debug logging is enabled
 func Println(args ...interface{}) {
-	if debugEnabled && debugLogger != nil {
-		debugLogger.Println(args...)
-	}
+	Logger{}.log(slog.LevelDebug, "%s", strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
 }
 
 // Errorf writes to stderr regardless of debug setting (for important warnings/errors)
@@ -44,9 +141,7 @@ func Errorf(format string, args ...interface{}) {
 	fmt.Fprintf(os.Stderr, "[SQLC-ERROR] "+format+"\n", args...)
 }
 
-// Warnf writes to stderr when debug is enabled
+// Warnf writes a warning to the debug log when debug logging is enabled
 func Warnf(format string, args ...interface{}) {
-	if debugEnabled {
-		fmt.Fprintf(os.Stderr, "[SQLC-WARN] "+format+"\n", args...)
-	}
+	Logger{}.log(slog.LevelWarn, format, args...)
 }
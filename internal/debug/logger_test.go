@@ -0,0 +1,62 @@
+package debug
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSetupJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "debug.log")
+	t.Cleanup(func() {
+		debugEnabled, debugLogger, debugFile = false, nil, nil
+	})
+	if err := Setup(path, "json"); err != nil {
+		t.Fatal(err)
+	}
+	NestedBuilder.Printf("building %s", "AuthorGroup")
+	Printf("no component")
+	debugFile.Close()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var records []map[string]any
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			t.Fatalf("record %q is not JSON: %s", scanner.Text(), err)
+		}
+		records = append(records, r)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+	if records[0]["msg"] != "building AuthorGroup" || records[0]["component"] != "nested-builder" {
+		t.Errorf("unexpected record %v", records[0])
+	}
+	if _, ok := records[1]["component"]; ok {
+		t.Errorf("record without component has one: %v", records[1])
+	}
+	source, _ := records[0]["source"].(map[string]any)
+	if file, _ := source["file"].(string); filepath.Base(file) != "logger_test.go" {
+		t.Errorf("source is %v, want the caller", source)
+	}
+}
+
+func TestSetupDisabled(t *testing.T) {
+	if debugEnabled {
+		t.Skip("SQLC_DEBUG is set")
+	}
+	if err := Setup("", "json"); err != nil {
+		t.Fatal(err)
+	}
+	if Enabled() {
+		t.Error("debug_log_format alone enabled debug logging")
+	}
+}
@@ -0,0 +1,51 @@
+package debug
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// Phases accumulates the time a generation spends in each of its phases.
+// Phases that run once per file, such as template execution, add up.
+type Phases struct {
+	start     time.Time
+	names     []string
+	durations map[string]time.Duration
+}
+
+func NewPhases() *Phases {
+	return &Phases{start: time.Now(), durations: map[string]time.Duration{}}
+}
+
+// Track starts timing phase and returns the function stopping it. A nil
+// Phases tracks nothing.
+func (p *Phases) Track(phase string) func() {
+	if p == nil {
+		return func() {}
+	}
+	start := time.Now()
+	return func() {
+		if _, ok := p.durations[phase]; !ok {
+			p.names = append(p.names, phase)
+		}
+		p.durations[phase] += time.Since(start)
+	}
+}
+
+// Report writes the duration of each phase and the total to w
+func (p *Phases) Report(w io.Writer) {
+	fmt.Fprintf(w, "sqlc-gen-go timings:\n")
+	for _, name := range p.names {
+		fmt.Fprintf(w, "  %-28s %10s\n", name, p.durations[name].Round(time.Microsecond))
+	}
+	fmt.Fprintf(w, "  %-28s %10s\n", "total", time.Since(p.start).Round(time.Microsecond))
+}
+
+// Log writes the duration of each phase and the total to the debug log
+func (p *Phases) Log() {
+	for _, name := range p.names {
+		Timings.Printf("%s took %s", name, p.durations[name])
+	}
+	Timings.Printf("generation took %s", time.Since(p.start))
+}
@@ -0,0 +1,31 @@
+package debug
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPhases(t *testing.T) {
+	p := NewPhases()
+	for i := 0; i < 3; i++ {
+		stop := p.Track("execute templates")
+		stop()
+	}
+	p.Track("format")()
+
+	var b strings.Builder
+	p.Report(&b)
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("got report:\n%s", b.String())
+	}
+	for i, phase := range []string{"execute templates", "format", "total"} {
+		if !strings.HasPrefix(strings.TrimSpace(lines[i+1]), phase) {
+			t.Errorf("line %d is %q, want phase %s", i+1, lines[i+1], phase)
+		}
+	}
+
+	// Generations without timings track nothing
+	var none *Phases
+	none.Track("format")()
+}
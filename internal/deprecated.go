@@ -0,0 +1,53 @@
+package golang
+
+import (
+	"strings"
+)
+
+const deprecatedMarker = "deprecated:"
+
+// deprecationLine rewrites a "deprecated: reason" comment line into the
+// "Deprecated: reason" form recognized by go doc and staticcheck. The second
+// return value is false when the line is not a deprecation marker.
+func deprecationLine(line string) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if len(trimmed) < len(deprecatedMarker) || !strings.EqualFold(trimmed[:len(deprecatedMarker)], deprecatedMarker) {
+		return line, false
+	}
+	reason := strings.TrimSpace(trimmed[len(deprecatedMarker):])
+	return strings.TrimSpace("Deprecated: " + reason), true
+}
+
+// deprecatedQueryComments rewrites deprecation markers in the comment lines
+// of a query. sqlc keeps the space following "--", so rewritten lines are
+// given one too.
+func deprecatedQueryComments(comments []string) []string {
+	return rewriteDeprecations(comments, " ")
+}
+
+// deprecatedComment rewrites deprecation markers in a table column comment
+func deprecatedComment(comment string) string {
+	if comment == "" {
+		return comment
+	}
+	return strings.Join(rewriteDeprecations(strings.Split(comment, "\n"), ""), "\n")
+}
+
+// rewriteDeprecations rewrites the deprecation markers of lines, prefixing
+// them with indent. Deprecation notices must start a paragraph, so a blank
+// line is inserted before markers that follow other text.
+func rewriteDeprecations(lines []string, indent string) []string {
+	var out []string
+	for _, line := range lines {
+		rewritten, ok := deprecationLine(line)
+		if !ok {
+			out = append(out, line)
+			continue
+		}
+		if len(out) > 0 && strings.TrimSpace(out[len(out)-1]) != "" {
+			out = append(out, "")
+		}
+		out = append(out, indent+rewritten)
+	}
+	return out
+}
@@ -0,0 +1,67 @@
+package golang
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDeprecationLine(t *testing.T) {
+	for _, tc := range []struct {
+		line string
+		want string
+		ok   bool
+	}{
+		{"deprecated: use GetUserByID", "Deprecated: use GetUserByID", true},
+		{" DEPRECATED:use GetUserByID ", "Deprecated: use GetUserByID", true},
+		{"Deprecated:", "Deprecated:", true},
+		{" Returns the user", " Returns the user", false},
+		{"not deprecated: yet", "not deprecated: yet", false},
+		{"deprecate", "deprecate", false},
+	} {
+		got, ok := deprecationLine(tc.line)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("deprecationLine(%q) = %q, %t, want %q, %t", tc.line, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestDeprecatedQueryComments(t *testing.T) {
+	for _, tc := range []struct {
+		comments []string
+		want     []string
+	}{
+		{
+			[]string{" Returns a user by email.", " deprecated: use GetUserByID"},
+			[]string{" Returns a user by email.", "", " Deprecated: use GetUserByID"},
+		},
+		{
+			[]string{" Returns a user by email.", "", " deprecated: use GetUserByID"},
+			[]string{" Returns a user by email.", "", " Deprecated: use GetUserByID"},
+		},
+		{
+			[]string{" deprecated: use GetUserByID"},
+			[]string{" Deprecated: use GetUserByID"},
+		},
+		{
+			[]string{" Returns a user by email."},
+			[]string{" Returns a user by email."},
+		},
+	} {
+		if got := deprecatedQueryComments(tc.comments); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("deprecatedQueryComments(%q) = %q, want %q", tc.comments, got, tc.want)
+		}
+	}
+}
+
+func TestDeprecatedComment(t *testing.T) {
+	for comment, want := range map[string]string{
+		"":                                     "",
+		"Login address":                        "Login address",
+		"deprecated: use email":                "Deprecated: use email",
+		"Login address\ndeprecated: use email": "Login address\n\nDeprecated: use email",
+	} {
+		if got := deprecatedComment(comment); got != want {
+			t.Errorf("deprecatedComment(%q) = %q, want %q", comment, got, want)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sqlc-dev/plugin-sdk-go/metadata"
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+)
+
+// Values of the "-- @derive exists, count" annotation, generating methods
+// checking whether a :many query returns rows and counting them
+const (
+	deriveExists = "exists"
+	deriveCount  = "count"
+)
+
+// Derived is the SQL of the methods derived from a :many query by a derive
+// annotation, empty for the methods that are not derived
+type Derived struct {
+	ExistsSQL string
+	CountSQL  string
+}
+
+// queryDerived returns the methods derived from a query annotated with
+// "-- @derive exists, count". They select from the query as a subquery, so
+// they share its parameters and WHERE clause.
+func queryDerived(query *plugin.Query, annotations map[string]string) (*Derived, error) {
+	value, ok := annotations[annotationDerive]
+	if !ok {
+		return nil, nil
+	}
+	if query.Cmd != metadata.CmdMany {
+		return nil, fmt.Errorf("derive is not supported for %s queries", query.Cmd)
+	}
+	sql := strings.TrimRight(query.Text, "; \t\n")
+	var d Derived
+	for _, method := range strings.Split(value, ",") {
+		switch method = strings.TrimSpace(method); method {
+		case deriveExists:
+			d.ExistsSQL = fmt.Sprintf("SELECT EXISTS (\n%s\n)", sql)
+		case deriveCount:
+			d.CountSQL = fmt.Sprintf("SELECT count(*) FROM (\n%s\n) AS derived", sql)
+		default:
+			return nil, fmt.Errorf("unknown derived method %q, must be %s or %s", method, deriveExists, deriveCount)
+		}
+	}
+	return &d, nil
+}
+
+func usesDerived(queries []Query) bool {
+	for _, q := range queries {
+		if q.Derived != nil {
+			return true
+		}
+	}
+	return false
+}
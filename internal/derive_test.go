@@ -0,0 +1,35 @@
+package golang
+
+import (
+	"testing"
+
+	"github.com/sqlc-dev/plugin-sdk-go/metadata"
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+)
+
+func TestQueryDerived(t *testing.T) {
+	query := &plugin.Query{Cmd: metadata.CmdMany, Text: "SELECT * FROM books WHERE author_id = $1;"}
+	got, err := queryDerived(query, map[string]string{annotationDerive: "exists, count"})
+	if err != nil {
+		t.Fatalf("queryDerived() failed: %s", err)
+	}
+	want := Derived{
+		ExistsSQL: "SELECT EXISTS (\nSELECT * FROM books WHERE author_id = $1\n)",
+		CountSQL:  "SELECT count(*) FROM (\nSELECT * FROM books WHERE author_id = $1\n) AS derived",
+	}
+	if *got != want {
+		t.Errorf("queryDerived() = %+v, want %+v", *got, want)
+	}
+
+	got, err = queryDerived(query, map[string]string{annotationDerive: "count"})
+	if err != nil || got.ExistsSQL != "" || got.CountSQL == "" {
+		t.Errorf("queryDerived() of count = %+v, %v", got, err)
+	}
+	if _, err := queryDerived(query, map[string]string{annotationDerive: "sum"}); err == nil {
+		t.Errorf("queryDerived() of an unknown method succeeded")
+	}
+	one := &plugin.Query{Cmd: metadata.CmdOne, Text: query.Text}
+	if _, err := queryDerived(one, map[string]string{annotationDerive: "exists"}); err == nil {
+		t.Errorf("queryDerived() of a :one query succeeded")
+	}
+}
@@ -0,0 +1,48 @@
+package golang
+
+import "sort"
+
+// DocSource summarizes the queries generated from one SQL file for the
+// package comment in doc.go
+type DocSource struct {
+	Name    string // The SQL file, e.g. "authors.sql"
+	Queries int
+}
+
+// DocNested describes a nested composite generated for a query, e.g.
+// GroupListAuthors grouping rows into AuthorGroup
+type DocNested struct {
+	Function string
+	Struct   string
+}
+
+// buildDocSources counts the queries of each SQL file, sorted by file name
+func buildDocSources(queries []Query) []DocSource {
+	counts := map[string]int{}
+	for _, q := range queries {
+		counts[q.SourceName]++
+	}
+	sources := make([]DocSource, 0, len(counts))
+	for name, n := range counts {
+		sources = append(sources, DocSource{Name: name, Queries: n})
+	}
+	sort.Slice(sources, func(i, j int) bool { return sources[i].Name < sources[j].Name })
+	return sources
+}
+
+// buildDocNested lists the group functions generated for nested queries in
+// the order they are emitted
+func buildDocNested(nested []Nested) []DocNested {
+	var docs []DocNested
+	seen := map[string]struct{}{}
+	for _, n := range nested {
+		for _, item := range n.NestedDataItems {
+			if _, ok := seen[item.FunctionName]; ok {
+				continue
+			}
+			seen[item.FunctionName] = struct{}{}
+			docs = append(docs, DocNested{Function: item.FunctionName, Struct: item.RootStructName})
+		}
+	}
+	return docs
+}
@@ -0,0 +1,34 @@
+package golang
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildDocSources(t *testing.T) {
+	queries := []Query{
+		{MethodName: "ListBooks", SourceName: "books.sql"},
+		{MethodName: "GetAuthor", SourceName: "authors.sql"},
+		{MethodName: "GetBook", SourceName: "books.sql"},
+	}
+	want := []DocSource{{Name: "authors.sql", Queries: 1}, {Name: "books.sql", Queries: 2}}
+	if got := buildDocSources(queries); !reflect.DeepEqual(got, want) {
+		t.Errorf("buildDocSources() = %v, want %v", got, want)
+	}
+}
+
+func TestBuildDocNested(t *testing.T) {
+	nested := []Nested{
+		{SourceFileName: "authors.sql", NestedDataItems: []NestedQueryTemplateData{
+			{FunctionName: "GroupListAuthors", RootStructName: "AuthorGroup"},
+			{FunctionName: "GroupListAuthors", RootStructName: "AuthorGroup"},
+		}},
+		{SourceFileName: "books.sql", NestedDataItems: []NestedQueryTemplateData{
+			{FunctionName: "GroupListBooks", RootStructName: "BookGroup"},
+		}},
+	}
+	want := []DocNested{{Function: "GroupListAuthors", Struct: "AuthorGroup"}, {Function: "GroupListBooks", Struct: "BookGroup"}}
+	if got := buildDocNested(nested); !reflect.DeepEqual(got, want) {
+		t.Errorf("buildDocNested() = %v, want %v", got, want)
+	}
+}
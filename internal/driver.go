@@ -12,3 +12,13 @@ func parseDriver(sqlPackage string) opts.SQLDriver {
 		return opts.SQLDriverLibPQ
 	}
 }
+
+// typesDriver returns the driver whose Go types the generated code uses. It
+// is the driver of sql_package, except in the database/sql package of
+// stdlib_package, which keeps the pgx/v5 types of the models it shares.
+func typesDriver(options *opts.Options) opts.SQLDriver {
+	if options.TypesSqlPackage != "" {
+		return parseDriver(options.TypesSqlPackage)
+	}
+	return parseDriver(options.SqlPackage)
+}
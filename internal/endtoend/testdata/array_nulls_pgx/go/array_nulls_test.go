@@ -0,0 +1,32 @@
+package querytest
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestArrayNullsScan(t *testing.T) {
+	m := pgtype.NewMap()
+
+	var post Post
+	if err := m.Scan(pgtype.TextArrayOID, pgtype.TextFormatCode, []byte(`{go,NULL}`), &post.Tags); err != nil {
+		t.Fatal(err)
+	}
+	if len(post.Tags) != 2 || post.Tags[0] != (pgtype.Text{String: "go", Valid: true}) || post.Tags[1].Valid {
+		t.Errorf("Tags = %+v, want go and NULL", post.Tags)
+	}
+
+	if err := m.Scan(pgtype.Int4ArrayOID, pgtype.TextFormatCode, []byte(`{}`), &post.Scores); err != nil {
+		t.Fatal(err)
+	}
+	if post.Scores == nil || len(*post.Scores) != 0 {
+		t.Errorf("Scores = %v, want an empty array", post.Scores)
+	}
+	if err := m.Scan(pgtype.Int4ArrayOID, pgtype.TextFormatCode, nil, &post.Scores); err != nil {
+		t.Fatal(err)
+	}
+	if post.Scores != nil {
+		t.Errorf("Scores = %v after scanning NULL, want nil", *post.Scores)
+	}
+}
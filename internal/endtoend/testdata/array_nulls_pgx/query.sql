@@ -0,0 +1,7 @@
+-- name: GetPost :one
+SELECT * FROM posts
+WHERE id = $1;
+
+-- name: CreatePost :one
+INSERT INTO posts (tags, scores) VALUES ($1, $2)
+RETURNING id;
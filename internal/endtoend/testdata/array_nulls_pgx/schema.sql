@@ -0,0 +1,5 @@
+CREATE TABLE posts (
+  id     BIGSERIAL PRIMARY KEY,
+  tags   text[] NOT NULL,
+  scores int4[]
+);
@@ -0,0 +1,85 @@
+package querytest
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// item is the result of one query of a batch
+type item struct {
+	authors []Author
+	err     error
+}
+
+// batchDB is a DBTX answering batches with its items in turn
+type batchDB struct {
+	DBTX
+	items []item
+}
+
+func (db *batchDB) SendBatch(context.Context, *pgx.Batch) pgx.BatchResults {
+	return &batchResults{items: db.items}
+}
+
+type batchResults struct {
+	items  []item
+	closed bool
+}
+
+func (b *batchResults) next() item {
+	it := b.items[0]
+	b.items = b.items[1:]
+	return it
+}
+
+func (b *batchResults) Exec() (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, b.next().err
+}
+
+func (b *batchResults) Query() (pgx.Rows, error) {
+	it := b.next()
+	if it.err != nil {
+		return nil, it.err
+	}
+	return &authorRows{authors: it.authors, next: -1}, nil
+}
+
+func (b *batchResults) QueryRow() pgx.Row {
+	it := b.next()
+	if it.err != nil {
+		return errRow{it.err}
+	}
+	return &authorRows{authors: it.authors}
+}
+
+func (b *batchResults) Close() error {
+	b.closed = true
+	return nil
+}
+
+type errRow struct{ err error }
+
+func (r errRow) Scan(...any) error { return r.err }
+
+// authorRows are the rows of authors, from the one after next
+type authorRows struct {
+	pgx.Rows
+	authors []Author
+	next    int
+}
+
+func (r *authorRows) Next() bool {
+	r.next++
+	return r.next < len(r.authors)
+}
+
+func (r *authorRows) Scan(dest ...any) error {
+	a := r.authors[r.next]
+	*dest[0].(*int64), *dest[1].(*string) = a.ID, a.Name
+	return nil
+}
+
+func (r *authorRows) Close()     {}
+func (r *authorRows) Err() error { return nil }
@@ -0,0 +1,42 @@
+package querytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestBatchErrors(t *testing.T) {
+	failure := errors.New("foreign key violation")
+	db := &batchDB{items: []item{{}, {err: failure}, {}, {err: failure}}}
+	results := New(db).DeleteAuthors(context.Background(), []int64{1, 2, 3, 4})
+	var calls int
+	results.Exec(func(int, error) { calls++ })
+	if calls != 4 {
+		t.Errorf("Exec() called f %d times, want 4", calls)
+	}
+
+	err := results.Close()
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("Close() error = %v, want a *BatchError", err)
+	}
+	if len(batchErr.Items) != 2 || batchErr.Items[0].Index != 1 || batchErr.Items[1].Index != 3 {
+		t.Errorf("BatchError.Items = %v, want items 1 and 3", batchErr.Items)
+	}
+	if !errors.Is(err, failure) {
+		t.Errorf("errors.Is(%v, failure) = false", err)
+	}
+	if want := "2 batch items failed, first DeleteAuthors: item 1: foreign key violation"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err, want)
+	}
+}
+
+func TestBatchNoErrors(t *testing.T) {
+	db := &batchDB{items: []item{{authors: []Author{{ID: 1, Name: "Ann"}}}}}
+	results := New(db).GetAuthors(context.Background(), []int64{1})
+	results.QueryRow(nil)
+	if err := results.Close(); err != nil {
+		t.Errorf("Close() = %v, want nil", err)
+	}
+}
@@ -0,0 +1,42 @@
+package querytest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBatchErrorsSlice(t *testing.T) {
+	ann := Author{ID: 1, Name: "Ann"}
+	bob := Author{ID: 3, Name: "Bob"}
+	failure := errors.New("no author")
+	db := &batchDB{items: []item{{authors: []Author{ann}}, {err: failure}, {authors: []Author{bob}}}}
+
+	got, err := New(db).GetAuthors(context.Background(), []int64{1, 2, 3})
+	if want := []Author{ann, {}, bob}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetAuthors() = %v, want the results of the other items %v", got, want)
+	}
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("GetAuthors() error = %v, want a *BatchError", err)
+	}
+	if len(batchErr.Items) != 1 || batchErr.Items[0].Index != 1 || batchErr.Items[0].Query != "GetAuthors" {
+		t.Errorf("BatchError.Items = %v, want item 1 of GetAuthors", batchErr.Items)
+	}
+	if want := "GetAuthors: item 1: no author"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err, want)
+	}
+}
+
+func TestBatchErrorsManySlice(t *testing.T) {
+	failure := errors.New("timeout")
+	db := &batchDB{items: []item{{err: failure}, {authors: []Author{{ID: 1, Name: "Ann"}}}}}
+	got, err := New(db).ListAuthorsByName(context.Background(), []string{"B", "A"})
+	if len(got) != 2 || got[0] != nil || len(got[1]) != 1 {
+		t.Errorf("ListAuthorsByName() = %v, want no authors for B and Ann for A", got)
+	}
+	if !errors.Is(err, failure) {
+		t.Errorf("ListAuthorsByName() error = %v, want %v", err, failure)
+	}
+}
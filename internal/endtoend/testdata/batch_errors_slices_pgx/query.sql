@@ -0,0 +1,10 @@
+-- name: GetAuthors :batchone
+SELECT * FROM authors
+WHERE id = $1;
+
+-- name: ListAuthorsByName :batchmany
+SELECT * FROM authors
+WHERE name = $1;
+
+-- name: DeleteAuthors :batchexec
+DELETE FROM authors WHERE id = $1;
@@ -0,0 +1,41 @@
+package querytest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+var (
+	ann = Author{ID: 1, Name: "Ann"}
+	bob = Author{ID: 2, Name: "Bob"}
+)
+
+func TestBatchOneSlice(t *testing.T) {
+	db := &batchDB{items: []item{{authors: []Author{ann}}, {authors: []Author{bob}}}}
+	got, err := New(db).GetAuthors(context.Background(), []int64{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []Author{ann, bob}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetAuthors() = %v, want %v", got, want)
+	}
+
+	failure := errors.New("no author")
+	db = &batchDB{items: []item{{authors: []Author{ann}}, {err: failure}}}
+	if got, err := New(db).GetAuthors(context.Background(), []int64{1, 2}); got != nil || err != failure {
+		t.Errorf("GetAuthors() = %v, %v, want nil, %v", got, err, failure)
+	}
+}
+
+func TestBatchManySlice(t *testing.T) {
+	db := &batchDB{items: []item{{authors: []Author{ann, bob}}, {}}}
+	got, err := New(db).ListAuthorsByName(context.Background(), []string{"A", "Z"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := [][]Author{{ann, bob}, nil}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ListAuthorsByName() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,174 @@
+package querytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// authorsDB is a DBTX serving the authors and counting the queries it runs
+type authorsDB struct {
+	authors []Author
+	queries int
+}
+
+func (db *authorsDB) Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error) {
+	db.queries++
+	return pgconn.CommandTag{}, nil
+}
+
+func (db *authorsDB) Query(context.Context, string, ...interface{}) (pgx.Rows, error) {
+	db.queries++
+	return &authorRows{authors: db.authors, next: -1}, nil
+}
+
+func (db *authorsDB) QueryRow(_ context.Context, _ string, args ...interface{}) pgx.Row {
+	db.queries++
+	rows := &authorRows{authors: db.authors, next: int(args[0].(int64)) - 1}
+	if rows.next >= len(db.authors) {
+		return errRow{pgx.ErrNoRows}
+	}
+	return rows
+}
+
+type errRow struct{ err error }
+
+func (r errRow) Scan(...any) error { return r.err }
+
+// authorRows are the rows of authors, from the one after next
+type authorRows struct {
+	pgx.Rows
+	authors []Author
+	next    int
+}
+
+func (r *authorRows) Next() bool {
+	r.next++
+	return r.next < len(r.authors)
+}
+
+func (r *authorRows) Scan(dest ...any) error {
+	a := r.authors[r.next]
+	*dest[0].(*int64), *dest[1].(*string) = a.ID, a.Name
+	return nil
+}
+
+func (r *authorRows) Close()     {}
+func (r *authorRows) Err() error { return nil }
+
+// txDB is a transaction running its queries on authorsDB
+type txDB struct {
+	pgx.Tx
+	db *authorsDB
+}
+
+func (tx txDB) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return tx.db.Exec(ctx, sql, args...)
+}
+
+func (tx txDB) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return tx.db.Query(ctx, sql, args...)
+}
+
+func (tx txDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return tx.db.QueryRow(ctx, sql, args...)
+}
+
+// mapCache is a Cache recording the ttl of its entries
+type mapCache struct {
+	values map[string]interface{}
+	ttls   map[string]time.Duration
+}
+
+func newMapCache() *mapCache {
+	return &mapCache{values: map[string]interface{}{}, ttls: map[string]time.Duration{}}
+}
+
+func (c *mapCache) Get(_ context.Context, key string) (interface{}, bool) {
+	v, ok := c.values[key]
+	return v, ok
+}
+
+func (c *mapCache) Set(_ context.Context, key string, value interface{}, ttl time.Duration) {
+	c.values[key], c.ttls[key] = value, ttl
+}
+
+var authors = []Author{{ID: 1, Name: "Ann"}, {ID: 2, Name: "Bob"}}
+
+func TestCachedGetAuthor(t *testing.T) {
+	ctx := context.Background()
+	db := &authorsDB{authors: authors}
+	cache := newMapCache()
+	q := NewCached(New(db), cache)
+
+	for _, id := range []int64{1, 1, 2, 1} {
+		a, err := q.GetAuthor(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a.ID != id {
+			t.Errorf("GetAuthor(%d) = %v", id, a)
+		}
+	}
+	if db.queries != 2 {
+		t.Errorf("GetAuthor() ran %d queries, want 2", db.queries)
+	}
+	for key, ttl := range cache.ttls {
+		if ttl != 30*time.Second {
+			t.Errorf("%s is cached for %s, want 30s", key, ttl)
+		}
+	}
+
+	if _, err := q.GetAuthor(ctx, 3); err != pgx.ErrNoRows {
+		t.Errorf("GetAuthor(3) error = %v, want %v", err, pgx.ErrNoRows)
+	}
+	if len(cache.values) != 2 {
+		t.Errorf("%d results are cached, want 2, errors are not cached", len(cache.values))
+	}
+}
+
+func TestCachedListAuthors(t *testing.T) {
+	ctx := context.Background()
+	db := &authorsDB{authors: authors}
+	q := NewCached(New(db), newMapCache())
+
+	first, err := q.ListAuthors(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first[0].Name = "changed"
+	second, err := q.ListAuthors(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db.queries != 1 {
+		t.Errorf("ListAuthors() ran %d queries, want 1", db.queries)
+	}
+	if len(second) != 2 || second[0].Name != "Ann" {
+		t.Errorf("ListAuthors() = %v, the cached result was modified by the caller", second)
+	}
+	second[1].Name = "changed"
+	if third, _ := q.ListAuthors(ctx); third[1].Name != "Bob" {
+		t.Errorf("ListAuthors() = %v, the cached result was modified by the caller", third)
+	}
+}
+
+func TestCachedInTx(t *testing.T) {
+	ctx := context.Background()
+	db := &authorsDB{authors: authors}
+	cache := newMapCache()
+	q := NewCached(New(db), cache)
+	tx := NewCached(q.WithTx(txDB{db: db}), cache)
+
+	for i := 0; i < 2; i++ {
+		if _, err := tx.GetAuthor(ctx, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if db.queries != 2 || len(cache.values) != 0 {
+		t.Errorf("GetAuthor() in a transaction ran %d queries and cached %d results, want 2 and none", db.queries, len(cache.values))
+	}
+}
@@ -0,0 +1,17 @@
+-- name: GetAuthor :one
+-- @cache ttl=30s
+SELECT * FROM authors
+WHERE id = $1 LIMIT 1;
+
+-- name: ListAuthors :many
+-- ListAuthors reads every author.
+-- @cache ttl=1m
+SELECT * FROM authors
+ORDER BY name;
+
+-- name: DeleteAuthor :exec
+DELETE FROM authors
+WHERE id = $1;
+
+-- name: CountAuthors :one
+SELECT count(*) FROM authors;
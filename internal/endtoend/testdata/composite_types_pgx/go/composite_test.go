@@ -0,0 +1,61 @@
+package querytest
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// registerTypes registers the composite types the way conn.LoadType would
+// find them in the database
+func registerTypes(m *pgtype.Map) {
+	text, _ := m.TypeForName("text")
+	int8, _ := m.TypeForName("int8")
+	m.RegisterType(&pgtype.Type{Name: "address", OID: 90001, Codec: &pgtype.CompositeCodec{
+		Fields: []pgtype.CompositeCodecField{{Name: "street", Type: text}, {Name: "city", Type: text}},
+	}})
+	m.RegisterType(&pgtype.Type{Name: "books", OID: 90002, Codec: &pgtype.CompositeCodec{
+		Fields: []pgtype.CompositeCodecField{{Name: "id", Type: int8}, {Name: "author_id", Type: int8}, {Name: "title", Type: text}},
+	}})
+}
+
+func TestCompositeTypesScan(t *testing.T) {
+	m := pgtype.NewMap()
+	registerTypes(m)
+
+	var author Author
+	if err := m.Scan(90001, pgtype.TextFormatCode, []byte(`("1 Main St",Springfield)`), &author.Home); err != nil {
+		t.Fatal(err)
+	}
+	if author.Home == nil || author.Home.Street.String != "1 Main St" || author.Home.City.String != "Springfield" {
+		t.Errorf("Home = %+v, want 1 Main St, Springfield", author.Home)
+	}
+	if err := m.Scan(90001, pgtype.TextFormatCode, nil, &author.Home); err != nil {
+		t.Fatal(err)
+	}
+	if author.Home != nil {
+		t.Errorf("Home = %+v after scanning NULL, want nil", author.Home)
+	}
+
+	var row ListAuthorBooksRow
+	if err := m.Scan(90002, pgtype.TextFormatCode, []byte(`(7,3,Dune)`), &row.B); err != nil {
+		t.Fatal(err)
+	}
+	if want := (Books{ID: 7, AuthorID: 3, Title: "Dune"}); row.B != want {
+		t.Errorf("B = %+v, want %+v", row.B, want)
+	}
+}
+
+func TestCompositeTypesEncode(t *testing.T) {
+	m := pgtype.NewMap()
+	registerTypes(m)
+
+	home := Address{Street: pgtype.Text{String: "1 Main St", Valid: true}}
+	buf, err := m.Encode(90001, pgtype.TextFormatCode, home, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(buf), `(1 Main St,)`; got != want {
+		t.Errorf("Encode() = %s, want %s", got, want)
+	}
+}
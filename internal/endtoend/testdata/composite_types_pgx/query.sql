@@ -0,0 +1,8 @@
+-- name: GetAuthor :one
+SELECT * FROM authors
+WHERE id = $1;
+
+-- name: ListAuthorBooks :many
+SELECT a.name, b
+FROM authors a
+JOIN books b ON b.author_id = a.id;
@@ -0,0 +1,16 @@
+CREATE TYPE address AS (
+  street text,
+  city   text
+);
+
+CREATE TABLE authors (
+  id   BIGSERIAL PRIMARY KEY,
+  name text NOT NULL,
+  home address
+);
+
+CREATE TABLE books (
+  id        BIGSERIAL PRIMARY KEY,
+  author_id bigint NOT NULL REFERENCES authors (id),
+  title     text NOT NULL
+);
@@ -0,0 +1,72 @@
+package querytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var errContext = errors.New("context recorded")
+
+// contextDB is a DBTX recording the contexts the queries ran with
+type contextDB struct {
+	ctxs *[]context.Context
+}
+
+func (db contextDB) Exec(ctx context.Context, _ string, _ ...interface{}) (pgconn.CommandTag, error) {
+	*db.ctxs = append(*db.ctxs, ctx)
+	return pgconn.CommandTag{}, errContext
+}
+
+func (db contextDB) Query(ctx context.Context, _ string, _ ...interface{}) (pgx.Rows, error) {
+	*db.ctxs = append(*db.ctxs, ctx)
+	return nil, errContext
+}
+
+func (db contextDB) QueryRow(ctx context.Context, _ string, _ ...interface{}) pgx.Row {
+	*db.ctxs = append(*db.ctxs, ctx)
+	return errRow{}
+}
+
+func (db contextDB) CopyFrom(ctx context.Context, _ pgx.Identifier, _ []string, _ pgx.CopyFromSource) (int64, error) {
+	*db.ctxs = append(*db.ctxs, ctx)
+	return 0, errContext
+}
+
+func (db contextDB) SendBatch(ctx context.Context, _ *pgx.Batch) pgx.BatchResults {
+	*db.ctxs = append(*db.ctxs, ctx)
+	return nil
+}
+
+type errRow struct{}
+
+func (errRow) Scan(...any) error { return errContext }
+
+func TestBackground(t *testing.T) {
+	var ctxs []context.Context
+	b := New(contextDB{&ctxs}).Background()
+
+	if _, err := b.GetAuthor(1); !errors.Is(err, errContext) {
+		t.Errorf("GetAuthor() error = %v, want %v", err, errContext)
+	}
+	if _, err := b.ListAuthors(); !errors.Is(err, errContext) {
+		t.Errorf("ListAuthors() error = %v, want %v", err, errContext)
+	}
+	if err := b.CreateAuthor("Ann"); !errors.Is(err, errContext) {
+		t.Errorf("CreateAuthor() error = %v, want %v", err, errContext)
+	}
+	b.CopyAuthors([]string{"Ann"})
+	b.DeleteAuthor([]int64{1})
+
+	if len(ctxs) != 5 {
+		t.Fatalf("%d queries ran, want 5", len(ctxs))
+	}
+	for i, ctx := range ctxs {
+		if ctx != context.Background() {
+			t.Errorf("query %d ran with %v, want context.Background()", i, ctx)
+		}
+	}
+}
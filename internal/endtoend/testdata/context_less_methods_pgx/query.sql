@@ -0,0 +1,23 @@
+-- name: GetAuthor :one
+-- GetAuthor returns the author with the given id
+SELECT * FROM authors
+WHERE id = $1;
+
+-- name: ListAuthors :many
+SELECT * FROM authors
+ORDER BY name;
+
+-- name: CreateAuthor :exec
+INSERT INTO authors (name) VALUES ($1);
+
+-- name: DeleteAuthors :execrows
+DELETE FROM authors WHERE name = $1;
+
+-- name: RenameAuthor :execresult
+UPDATE authors SET name = $2 WHERE id = $1;
+
+-- name: CopyAuthors :copyfrom
+INSERT INTO authors (name) VALUES ($1);
+
+-- name: DeleteAuthor :batchexec
+DELETE FROM authors WHERE id = $1;
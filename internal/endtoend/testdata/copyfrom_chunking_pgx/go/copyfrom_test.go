@@ -0,0 +1,88 @@
+package querytest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// copyDB is a DBTX recording the number of rows of each copy. The copy
+// numbered failAt copies one row and fails.
+type copyDB struct {
+	DBTX
+	copies []int
+	failAt int
+}
+
+var errCopy = errors.New("copy failed")
+
+func (db *copyDB) CopyFrom(_ context.Context, _ pgx.Identifier, _ []string, src pgx.CopyFromSource) (int64, error) {
+	var n int
+	for src.Next() {
+		if _, err := src.Values(); err != nil {
+			return 0, err
+		}
+		n++
+	}
+	db.copies = append(db.copies, n)
+	if len(db.copies) == db.failAt {
+		return 1, errCopy
+	}
+	return int64(n), nil
+}
+
+var rows = make([]CopyAuthorsParams, 5)
+
+func TestCopyAuthorsChunked(t *testing.T) {
+	db := &copyDB{}
+	var progress []int64
+	n, err := New(db).CopyAuthorsChunked(context.Background(), rows, CopyFromOptions{
+		ChunkSize: 2,
+		Progress:  func(copied int64) { progress = append(progress, copied) },
+	})
+	if err != nil || n != 5 {
+		t.Fatalf("CopyAuthorsChunked() = %d, %v, want 5", n, err)
+	}
+	if want := []int{2, 2, 1}; !slices.Equal(db.copies, want) {
+		t.Errorf("CopyAuthorsChunked() copied %v rows at once, want %v", db.copies, want)
+	}
+	if want := []int64{2, 4, 5}; !slices.Equal(progress, want) {
+		t.Errorf("Progress was called with %v, want %v", progress, want)
+	}
+}
+
+func TestCopyAuthorsChunkedAll(t *testing.T) {
+	db := &copyDB{}
+	if _, err := New(db).CopyAuthorsChunked(context.Background(), rows, CopyFromOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{5}; !slices.Equal(db.copies, want) {
+		t.Errorf("CopyAuthorsChunked() without ChunkSize copied %v rows at once, want %v", db.copies, want)
+	}
+}
+
+func TestCopyAuthorsChunkedFailure(t *testing.T) {
+	db := &copyDB{failAt: 2}
+	n, err := New(db).CopyAuthorsChunked(context.Background(), rows, CopyFromOptions{ChunkSize: 2})
+	if err != errCopy || n != 3 {
+		t.Errorf("CopyAuthorsChunked() = %d, %v, want the 3 rows copied before %v", n, err, errCopy)
+	}
+}
+
+func TestCopyAuthorsChunkedCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	db := &copyDB{}
+	n, err := New(db).CopyAuthorsChunked(ctx, rows, CopyFromOptions{
+		ChunkSize: 2,
+		Progress:  func(int64) { cancel() },
+	})
+	if err != context.Canceled || n != 2 {
+		t.Errorf("CopyAuthorsChunked() = %d, %v, want the 2 rows copied before the cancellation", n, err)
+	}
+	if len(db.copies) != 1 {
+		t.Errorf("CopyAuthorsChunked() ran %d copies after the cancellation, want none", len(db.copies)-1)
+	}
+}
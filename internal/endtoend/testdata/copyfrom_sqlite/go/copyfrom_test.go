@@ -0,0 +1,121 @@
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"slices"
+	"testing"
+)
+
+// recordingDriver records the statements run on its connections and the
+// transactions begun, committed and rolled back. The exec numbered failAt
+// fails.
+type recordingDriver struct {
+	queries []string
+	args    [][]driver.Value
+	events  []string
+	failAt  int
+}
+
+func (d *recordingDriver) Connect(context.Context) (driver.Conn, error) { return recordingConn{d}, nil }
+func (d *recordingDriver) Driver() driver.Driver                        { return nil }
+
+type recordingConn struct{ d *recordingDriver }
+
+func (c recordingConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c recordingConn) Close() error                        { return nil }
+
+func (c recordingConn) Begin() (driver.Tx, error) {
+	c.d.events = append(c.d.events, "begin")
+	return recordingTx{c.d}, nil
+}
+
+func (c recordingConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.d.queries = append(c.d.queries, query)
+	if len(c.d.queries) == c.d.failAt {
+		return nil, errors.New("constraint failed")
+	}
+	values := make([]driver.Value, len(args))
+	for i, a := range args {
+		values[i] = a.Value
+	}
+	c.d.args = append(c.d.args, values)
+	return driver.RowsAffected(len(args) / 2), nil
+}
+
+type recordingTx struct{ d *recordingDriver }
+
+func (tx recordingTx) Commit() error {
+	tx.d.events = append(tx.d.events, "commit")
+	return nil
+}
+
+func (tx recordingTx) Rollback() error {
+	tx.d.events = append(tx.d.events, "rollback")
+	return nil
+}
+
+var books = []CopyBooksParams{{"A", 1}, {"B", 1}, {"C", 2}, {"D", 2}, {"E", 3}}
+
+func TestCopyBooks(t *testing.T) {
+	d := &recordingDriver{}
+	db := sql.OpenDB(d)
+	defer db.Close()
+
+	n, err := New(db).CopyBooks(context.Background(), books)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 5 {
+		t.Errorf("CopyBooks() = %d, want 5", n)
+	}
+	const insert = `INSERT INTO "books" ("title", "author_id") VALUES `
+	wantQueries := []string{insert + "(?, ?), (?, ?)", insert + "(?, ?), (?, ?)", insert + "(?, ?)"}
+	if !slices.Equal(d.queries, wantQueries) {
+		t.Errorf("CopyBooks() ran %q, want %q", d.queries, wantQueries)
+	}
+	if last := d.args[2]; len(last) != 2 || last[0] != "E" || last[1] != int64(3) {
+		t.Errorf("the last chunk inserts %v, want [E 3]", last)
+	}
+	if want := []string{"begin", "commit"}; !slices.Equal(d.events, want) {
+		t.Errorf("CopyBooks() ran in %q, want %q", d.events, want)
+	}
+}
+
+func TestCopyBooksFailure(t *testing.T) {
+	d := &recordingDriver{failAt: 2}
+	db := sql.OpenDB(d)
+	defer db.Close()
+
+	if _, err := New(db).CopyBooks(context.Background(), books); err == nil {
+		t.Fatal("CopyBooks() succeeded")
+	}
+	if want := []string{"begin", "rollback"}; !slices.Equal(d.events, want) {
+		t.Errorf("CopyBooks() ran in %q, want %q", d.events, want)
+	}
+}
+
+func TestCopyBooksInTx(t *testing.T) {
+	d := &recordingDriver{}
+	db := sql.OpenDB(d)
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New(db).WithTx(tx).CopyBooks(context.Background(), books[:3]); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"begin", "commit"}; !slices.Equal(d.events, want) {
+		t.Errorf("CopyBooks() in a transaction ran in %q, want %q", d.events, want)
+	}
+	if len(d.queries) != 2 {
+		t.Errorf("CopyBooks() ran %d statements, want 2", len(d.queries))
+	}
+}
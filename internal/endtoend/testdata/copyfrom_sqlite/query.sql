@@ -0,0 +1,2 @@
+-- name: CopyBooks :copyfrom
+INSERT INTO books (title, author_id) VALUES (?, ?);
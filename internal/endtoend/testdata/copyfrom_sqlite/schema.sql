@@ -0,0 +1,5 @@
+CREATE TABLE books (
+  id        INTEGER PRIMARY KEY,
+  title     text NOT NULL,
+  author_id integer NOT NULL
+);
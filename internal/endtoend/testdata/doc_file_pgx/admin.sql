@@ -0,0 +1,3 @@
+-- name: DeleteAuthor :exec
+DELETE FROM authors
+WHERE id = $1;
@@ -0,0 +1,7 @@
+-- name: GetAuthor :one
+SELECT * FROM authors
+WHERE id = $1;
+
+-- name: ListAuthors :many
+SELECT * FROM authors
+ORDER BY name;
@@ -0,0 +1,7 @@
+-- name: ListAuthorsWithBooks :many
+SELECT sqlc.embed(authors), sqlc.embed(books) FROM authors
+LEFT JOIN books ON books.author_id = authors.id;
+
+-- name: ListBooksWithAuthors :many
+SELECT sqlc.embed(books), sqlc.embed(authors) FROM books
+JOIN authors ON authors.id = books.author_id;
@@ -0,0 +1,10 @@
+CREATE TABLE authors (
+  id   BIGSERIAL PRIMARY KEY,
+  name text NOT NULL
+);
+
+CREATE TABLE books (
+  id        BIGSERIAL PRIMARY KEY,
+  author_id bigint NOT NULL,
+  title     text NOT NULL
+);
@@ -0,0 +1,24 @@
+package querytest
+
+import (
+	"testing"
+)
+
+func TestParseMood(t *testing.T) {
+	all := AllMoodValues()
+	if len(MoodValues) != len(all) {
+		t.Errorf("MoodValues has %d values, AllMoodValues %d", len(MoodValues), len(all))
+	}
+	for _, want := range all {
+		got, err := ParseMood(string(want))
+		if err != nil || got != want {
+			t.Errorf("ParseMood(%q) = %q, %v", want, got, err)
+		}
+	}
+	if got, err := ParseMood("so-so"); err != nil || got != MoodSoSo {
+		t.Errorf("ParseMood(so-so) = %q, %v, want MoodSoSo", got, err)
+	}
+	if got, err := ParseMood("Happy"); err == nil {
+		t.Errorf("ParseMood(Happy) = %q, want an error", got)
+	}
+}
@@ -0,0 +1,3 @@
+-- name: ListPeopleByMood :many
+SELECT * FROM people
+WHERE mood = $1;
@@ -0,0 +1,6 @@
+CREATE TYPE mood AS ENUM ('happy', 'sad', 'so-so');
+
+CREATE TABLE people (
+  id   BIGSERIAL PRIMARY KEY,
+  mood mood NOT NULL
+);
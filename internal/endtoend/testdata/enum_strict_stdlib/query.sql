@@ -0,0 +1,12 @@
+-- name: GetUser :one
+SELECT * FROM users
+WHERE id = $1 LIMIT 1;
+
+-- name: ListUsersByStatus :many
+SELECT * FROM users
+WHERE status = $1
+ORDER BY email;
+
+-- name: SetStatus :exec
+UPDATE users SET status = $2, previous_status = status
+WHERE id = $1;
@@ -0,0 +1,8 @@
+CREATE TYPE user_status AS ENUM ('active', 'suspended', 'deleted');
+
+CREATE TABLE users (
+  id              BIGSERIAL   PRIMARY KEY,
+  email           text        NOT NULL,
+  status          user_status NOT NULL,
+  previous_status user_status
+);
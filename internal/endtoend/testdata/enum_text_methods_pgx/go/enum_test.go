@@ -0,0 +1,44 @@
+package querytest
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestEnumTextRoundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		person Person
+		json   string
+	}{
+		{Person{ID: 1, Mood: MoodSoSo, Last: NullMood{Mood: MoodSad, Valid: true}}, `{"id":1,"mood":"so-so","last":"sad"}`},
+		{Person{ID: 2, Mood: MoodHappy}, `{"id":2,"mood":"happy","last":""}`},
+	} {
+		data, err := json.Marshal(tc.person)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != tc.json {
+			t.Errorf("json.Marshal() = %s, want %s", data, tc.json)
+		}
+		var got Person
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.person {
+			t.Errorf("json.Unmarshal(%s) = %+v, want %+v", data, got, tc.person)
+		}
+	}
+}
+
+func TestEnumString(t *testing.T) {
+	if got := fmt.Sprint(MoodSoSo); got != "so-so" {
+		t.Errorf("fmt.Sprint(MoodSoSo) = %q, want so-so", got)
+	}
+	if got := fmt.Sprint(NullMood{}); got != "" {
+		t.Errorf("fmt.Sprint(NullMood{}) = %q, want an empty string", got)
+	}
+	if got := fmt.Sprint(NullMood{Mood: MoodSad, Valid: true}); got != "sad" {
+		t.Errorf("fmt.Sprint() of a valid NullMood = %q, want sad", got)
+	}
+}
@@ -0,0 +1,7 @@
+CREATE TYPE mood AS ENUM ('happy', 'sad', 'so-so');
+
+CREATE TABLE people (
+  id   BIGSERIAL PRIMARY KEY,
+  mood mood NOT NULL,
+  last mood
+);
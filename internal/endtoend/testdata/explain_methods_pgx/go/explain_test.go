@@ -0,0 +1,43 @@
+package querytest
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// planRow is a row holding a plan
+type planRow []byte
+
+func (r planRow) Scan(dest ...interface{}) error {
+	*dest[0].(*[]byte) = r
+	return nil
+}
+
+// explainDB is a DBTX recording the last query and its arguments
+type explainDB struct {
+	DBTX
+	query string
+	args  []interface{}
+}
+
+func (db *explainDB) QueryRow(_ context.Context, query string, args ...interface{}) pgx.Row {
+	db.query, db.args = query, args
+	return planRow(`[{"Plan": {}}]`)
+}
+
+func TestExplainRenameAuthor(t *testing.T) {
+	db := &explainDB{}
+	plan, err := New(db).ExplainRenameAuthor(context.Background(), RenameAuthorParams{ID: 1, Name: "Ann"})
+	if err != nil || string(plan) != `[{"Plan": {}}]` {
+		t.Fatalf("ExplainRenameAuthor() = %s, %v", plan, err)
+	}
+	if want := "EXPLAIN (ANALYZE, FORMAT JSON) " + renameAuthor; db.query != want {
+		t.Errorf("ExplainRenameAuthor() ran %q, want %q", db.query, want)
+	}
+	if want := []interface{}{int64(1), "Ann"}; !reflect.DeepEqual(db.args, want) {
+		t.Errorf("ExplainRenameAuthor() args = %v, want %v", db.args, want)
+	}
+}
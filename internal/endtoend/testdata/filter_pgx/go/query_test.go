@@ -0,0 +1,67 @@
+package querytest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var errQuery = errors.New("query recorded")
+
+// queryDB is a DBTX recording the last query and its arguments
+type queryDB struct {
+	DBTX
+	query string
+	args  []interface{}
+}
+
+func (db *queryDB) Query(_ context.Context, query string, args ...interface{}) (pgx.Rows, error) {
+	db.query, db.args = query, args
+	return nil, errQuery
+}
+
+func TestListAuthorsFiltered(t *testing.T) {
+	after := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	filter := (&ListAuthorsFilter{}).
+		WhereNameILike("a%").
+		WhereCreatedAtAfter(after).
+		OrderBy(ListAuthorsOrderByCreatedAt, true).
+		OrderBy(ListAuthorsOrderByName, false)
+
+	db := &queryDB{}
+	if _, err := New(db).ListAuthorsFiltered(context.Background(), "NZ", filter); err != errQuery {
+		t.Fatalf("ListAuthorsFiltered() error = %v", err)
+	}
+	want := "SELECT * FROM (\n" + listAuthors + "\n) AS filtered\nWHERE name ILIKE $2 AND created_at > $3\nORDER BY created_at DESC, name"
+	if db.query != want {
+		t.Errorf("ListAuthorsFiltered() ran\n%s\nwant\n%s", db.query, want)
+	}
+	if want := []interface{}{"NZ", "a%", after}; !reflect.DeepEqual(db.args, want) {
+		t.Errorf("ListAuthorsFiltered() args = %v, want %v", db.args, want)
+	}
+}
+
+func TestListAuthorsUnfiltered(t *testing.T) {
+	for _, filter := range []*ListAuthorsFilter{nil, {}} {
+		db := &queryDB{}
+		New(db).ListAuthorsFiltered(context.Background(), "NZ", filter)
+		if db.query != listAuthors {
+			t.Errorf("ListAuthorsFiltered(%v) ran %q, want the query itself", filter, db.query)
+		}
+	}
+}
+
+func TestListAuthorsOrderInvalid(t *testing.T) {
+	db := &queryDB{}
+	filter := (&ListAuthorsFilter{}).OrderBy("name; DROP TABLE authors", false)
+	if _, err := New(db).ListAuthorsFiltered(context.Background(), "NZ", filter); err == nil || err == errQuery {
+		t.Errorf("ListAuthorsFiltered() error = %v, want the invalid column", err)
+	}
+	if db.query != "" {
+		t.Errorf("ListAuthorsFiltered() ran %q with an invalid order column", db.query)
+	}
+}
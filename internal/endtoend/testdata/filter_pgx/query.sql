@@ -0,0 +1,6 @@
+-- name: ListAuthors :many
+-- ListAuthors lists the authors of a country.
+-- @filter name ilike, created_at >
+-- @order_by created_at, name
+SELECT * FROM authors
+WHERE country = $1;
@@ -0,0 +1,6 @@
+CREATE TABLE authors (
+  id         BIGSERIAL PRIMARY KEY,
+  name       text NOT NULL,
+  country    text NOT NULL,
+  created_at timestamptz NOT NULL
+);
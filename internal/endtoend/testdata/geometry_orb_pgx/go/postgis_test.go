@@ -0,0 +1,35 @@
+package querytest
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/paulmach/orb"
+)
+
+func TestOrbCodecRoundTrip(t *testing.T) {
+	const oid = 90001
+	m := pgtype.NewMap()
+	m.RegisterType(&pgtype.Type{Name: "geometry", OID: oid, Codec: OrbCodec{SRID: 4326}})
+
+	want := orb.Point{13.4, 52.5}
+	buf, err := m.Encode(oid, pgtype.BinaryFormatCode, want, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var place Place
+	if err := m.Scan(oid, pgtype.BinaryFormatCode, buf, &place.Location); err != nil {
+		t.Fatal(err)
+	}
+	if !orb.Equal(place.Location, want) {
+		t.Errorf("Location = %v, want %v", place.Location, want)
+	}
+
+	place.Area = orb.Point{}
+	if err := m.Scan(oid, pgtype.BinaryFormatCode, nil, &place.Area); err != nil {
+		t.Fatal(err)
+	}
+	if place.Area != nil {
+		t.Errorf("Area = %v after scanning NULL, want nil", place.Area)
+	}
+}
@@ -0,0 +1,7 @@
+-- name: GetPlace :one
+SELECT * FROM places
+WHERE id = $1;
+
+-- name: CreatePlace :one
+INSERT INTO places (name, location, area) VALUES ($1, $2, $3)
+RETURNING id;
@@ -0,0 +1,8 @@
+CREATE EXTENSION IF NOT EXISTS postgis;
+
+CREATE TABLE places (
+  id       BIGSERIAL PRIMARY KEY,
+  name     text NOT NULL,
+  location geometry(Point, 4326) NOT NULL,
+  area     geography
+);
@@ -0,0 +1,45 @@
+package querytest
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/endtoend/testdata/grouper_interface_pgx/go/entity"
+)
+
+// countingGrouper is a Grouper wrapping another one, as a test would mock it
+type countingGrouper struct {
+	Grouper
+	calls int
+}
+
+func (g *countingGrouper) GroupListAuthorsWithBooks(rows []ListAuthorsWithBooksRow) []AuthorGroup {
+	g.calls++
+	return g.Grouper.GroupListAuthorsWithBooks(rows)
+}
+
+func id(b byte) pgtype.UUID {
+	return pgtype.UUID{Bytes: [16]byte{b}, Valid: true}
+}
+
+func TestDefaultGrouper(t *testing.T) {
+	rows := []ListAuthorsWithBooksRow{
+		{ID: id(1), Name: "Ann", Book: entity.Book{ID: id(10), AuthorID: id(1), Title: "First"}},
+		{ID: id(1), Name: "Ann", Book: entity.Book{ID: id(11), AuthorID: id(1), Title: "Second"}},
+	}
+	var grouper Grouper = &countingGrouper{Grouper: DefaultGrouper{}}
+	groups := grouper.GroupListAuthorsWithBooks(rows)
+	if calls := grouper.(*countingGrouper).calls; calls != 1 {
+		t.Errorf("the grouper was called %d times", calls)
+	}
+	if len(groups) != 1 || groups[0].Name != "Ann" || len(groups[0].Books) != 2 {
+		t.Fatalf("GroupListAuthorsWithBooks() = %+v, want Ann with 2 books", groups)
+	}
+	want := GroupListAuthorsWithBooks(rows)
+	for i, book := range groups[0].Books {
+		if *book != *want[0].Books[i] {
+			t.Errorf("book %d = %+v, want %+v", i, *book, *want[0].Books[i])
+		}
+	}
+}
@@ -0,0 +1,4 @@
+-- name: ListAuthorsWithBooks :many
+SELECT sqlc.embed(authors), sqlc.embed(books) FROM authors
+JOIN books ON books.author_id = authors.id
+ORDER BY authors.id;
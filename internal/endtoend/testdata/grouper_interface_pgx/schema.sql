@@ -0,0 +1,10 @@
+CREATE TABLE authors (
+  id   uuid PRIMARY KEY,
+  name text NOT NULL
+);
+
+CREATE TABLE books (
+  id        uuid PRIMARY KEY,
+  author_id uuid NOT NULL,
+  title     text NOT NULL
+);
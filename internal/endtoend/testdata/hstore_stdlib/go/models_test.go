@@ -0,0 +1,44 @@
+package querytest
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHstoreScan(t *testing.T) {
+	var h Hstore
+	if err := h.Scan([]byte(`"color"=>"red", "note"=>NULL, "quote"=>"say \"hi\"\\"`)); err != nil {
+		t.Fatal(err)
+	}
+	red, quote := "red", `say "hi"\`
+	if want := (Hstore{"color": &red, "note": nil, "quote": &quote}); !reflect.DeepEqual(h, want) {
+		t.Errorf("Scan() = %v, want %v", h, want)
+	}
+
+	if err := h.Scan(nil); err != nil || h != nil {
+		t.Errorf("Scan(nil) = %v, %v, want a nil Hstore", h, err)
+	}
+	if err := h.Scan(`"color"=red`); err == nil {
+		t.Error("Scan() accepts an invalid hstore")
+	}
+}
+
+func TestHstoreValueRoundTrip(t *testing.T) {
+	value := `say "hi"\`
+	h := Hstore{"quote": &value, "note": nil}
+	v, err := h.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var scanned Hstore
+	if err := scanned.Scan(v); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(scanned, h) {
+		t.Errorf("Scan(Value()) = %v, want %v", scanned, h)
+	}
+
+	if v, err := Hstore(nil).Value(); v != nil || err != nil {
+		t.Errorf("Value() of a nil Hstore = %v, %v, want NULL", v, err)
+	}
+}
@@ -0,0 +1,15 @@
+-- name: GetProduct :one
+SELECT * FROM products
+WHERE id = $1 LIMIT 1;
+
+-- name: CreateProduct :one
+INSERT INTO products (
+  name, attributes, labels
+) VALUES (
+  $1, $2, $3
+)
+RETURNING *;
+
+-- name: SetLabels :exec
+UPDATE products SET labels = $2
+WHERE id = $1;
@@ -0,0 +1,8 @@
+CREATE EXTENSION IF NOT EXISTS hstore;
+
+CREATE TABLE products (
+  id         BIGSERIAL PRIMARY KEY,
+  name       text      NOT NULL,
+  attributes hstore    NOT NULL,
+  labels     hstore
+);
@@ -0,0 +1,4 @@
+-- name: ListBooksByAuthor :many
+SELECT * FROM books
+WHERE author_id = $1
+ORDER BY title;
@@ -0,0 +1,49 @@
+package querytest
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNullConversionsRoundTrip(t *testing.T) {
+	account := Account{
+		Name:      PtrToText(ptr("Ada")),
+		Age:       PtrToInt4(ptr(int32(36))),
+		Active:    PtrToBool(ptr(true)),
+		BornOn:    PtrToDate(ptr(time.Date(1815, 12, 10, 0, 0, 0, 0, time.UTC))),
+		UpdatedAt: PtrToTimestamptz(ptr(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))),
+		Ref:       PtrToUUID(ptr([16]byte{1, 2, 3})),
+	}
+	if got := *TextToPtr(account.Name); got != "Ada" {
+		t.Errorf("TextToPtr() = %q", got)
+	}
+	if got := *Int4ToPtr(account.Age); got != 36 {
+		t.Errorf("Int4ToPtr() = %d", got)
+	}
+	if got := *BoolToPtr(account.Active); !got {
+		t.Errorf("BoolToPtr() = %t", got)
+	}
+	if got := *DateToPtr(account.BornOn); got.Year() != 1815 {
+		t.Errorf("DateToPtr() = %s", got)
+	}
+	if got := *TimestamptzToPtr(account.UpdatedAt); !got.Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("TimestamptzToPtr() = %s", got)
+	}
+	if got := *UUIDToPtr(account.Ref); got != [16]byte{1, 2, 3} {
+		t.Errorf("UUIDToPtr() = %v", got)
+	}
+
+	// nil pointers are NULL wrappers, and NULL wrappers nil pointers
+	var null Account
+	if PtrToText(nil).Valid || PtrToUUID(nil).Valid || PtrToTimestamptz(nil).Valid {
+		t.Errorf("nil pointers convert to valid values")
+	}
+	if TextToPtr(null.Name) != nil || Int4ToPtr(null.Age) != nil || BoolToPtr(null.Active) != nil ||
+		DateToPtr(null.BornOn) != nil || TimestamptzToPtr(null.UpdatedAt) != nil || UUIDToPtr(null.Ref) != nil {
+		t.Errorf("NULL values convert to non-nil pointers")
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,9 @@
+CREATE TABLE accounts (
+  id         uuid PRIMARY KEY,
+  name       text,
+  age        integer,
+  active     boolean,
+  born_on    date,
+  updated_at timestamptz,
+  ref        uuid
+);
@@ -0,0 +1,70 @@
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// scanRows is a pgx.Rows returning fixed rows through the sql.Scanner of the
+// scan destinations
+type scanRows struct {
+	pgx.Rows
+	rows [][]interface{}
+	row  []interface{}
+}
+
+func (r *scanRows) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	r.row, r.rows = r.rows[0], r.rows[1:]
+	return true
+}
+
+func (r *scanRows) Scan(dest ...interface{}) error {
+	for i, d := range dest {
+		if err := d.(sql.Scanner).Scan(r.row[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *scanRows) Close()     {}
+func (r *scanRows) Err() error { return nil }
+
+type rowsDB struct {
+	DBTX
+	rows [][]interface{}
+}
+
+func (db *rowsDB) Query(context.Context, string, ...interface{}) (pgx.Rows, error) {
+	return &scanRows{rows: db.rows}, nil
+}
+
+func TestListAuthorsWithBooks(t *testing.T) {
+	db := &rowsDB{rows: [][]interface{}{
+		{int64(1), "Ursula", int64(10), int64(1), "The Dispossessed"},
+		{int64(2), "Italo", nil, nil, nil},
+	}}
+	items, err := New(db).ListAuthorsWithBooks(context.Background())
+	if err != nil {
+		t.Fatalf("ListAuthorsWithBooks() error = %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("ListAuthorsWithBooks() returned %d rows, want 2", len(items))
+	}
+	want := Book{ID: 10, AuthorID: 1, Title: "The Dispossessed"}
+	if items[0].Book == nil || *items[0].Book != want {
+		t.Errorf("Book = %v, want %v", items[0].Book, want)
+	}
+	if items[1].Book != nil {
+		t.Errorf("Book = %v for NULL columns, want nil", items[1].Book)
+	}
+	if want := (Author{ID: 2, Name: "Italo"}); items[1].Author != want {
+		t.Errorf("Author = %v, want %v", items[1].Author, want)
+	}
+}
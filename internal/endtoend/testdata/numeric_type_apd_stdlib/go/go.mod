@@ -0,0 +1,5 @@
+module querytest
+
+go 1.24
+
+require github.com/cockroachdb/apd/v3 v3.2.1
@@ -0,0 +1,32 @@
+package querytest
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+)
+
+func TestDecimalScanValue(t *testing.T) {
+	var invoice Invoice
+	for _, dst := range []sql.Scanner{&invoice.Total, &invoice.Tax} {
+		if err := dst.Scan("12.5"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, v := range []driver.Valuer{invoice.Total, invoice.Tax} {
+		got, err := v.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != "12.5" {
+			t.Errorf("Value() = %v, want 12.5", got)
+		}
+	}
+
+	if err := invoice.Tax.Scan(nil); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := invoice.Tax.Value(); got != nil || err != nil {
+		t.Errorf("Value() of NULL = %v, %v", got, err)
+	}
+}
@@ -0,0 +1,7 @@
+-- name: GetInvoice :one
+SELECT * FROM invoices
+WHERE id = $1;
+
+-- name: CreateInvoice :one
+INSERT INTO invoices (total, tax) VALUES ($1, $2)
+RETURNING id;
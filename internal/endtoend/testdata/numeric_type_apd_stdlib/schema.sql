@@ -0,0 +1,6 @@
+CREATE TABLE invoices (
+  id      BIGSERIAL PRIMARY KEY,
+  total   numeric(12, 2) NOT NULL,
+  tax     numeric(12, 2),
+  balance money
+);
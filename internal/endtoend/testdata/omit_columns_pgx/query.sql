@@ -0,0 +1,7 @@
+-- name: GetUser :one
+SELECT id, email FROM users
+WHERE id = $1;
+
+-- name: GetUserCredentials :one
+SELECT * FROM users
+WHERE email = $1;
@@ -0,0 +1,5 @@
+CREATE TABLE users (
+  id            BIGSERIAL PRIMARY KEY,
+  email         text NOT NULL,
+  password_hash text NOT NULL
+);
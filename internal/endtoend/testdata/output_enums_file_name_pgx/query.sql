@@ -0,0 +1,3 @@
+-- name: GetPerson :one
+SELECT * FROM people
+WHERE id = $1;
@@ -0,0 +1,6 @@
+CREATE TYPE mood AS ENUM ('happy', 'sad');
+
+CREATE TABLE people (
+  id   BIGSERIAL PRIMARY KEY,
+  mood mood NOT NULL
+);
@@ -0,0 +1,6 @@
+// Package types declares the override types of the billing package
+package types
+
+type Money struct {
+	Cents int64
+}
@@ -0,0 +1,11 @@
+-- name: GetOrder :one
+SELECT * FROM orders
+WHERE id = $1 LIMIT 1;
+
+-- name: CreateOrder :one
+INSERT INTO orders (
+  price, weight, note
+) VALUES (
+  $1, $2, $3
+)
+RETURNING *;
@@ -0,0 +1,6 @@
+CREATE TABLE orders (
+  id     BIGSERIAL PRIMARY KEY,
+  price  numeric   NOT NULL,
+  weight numeric   NOT NULL,
+  note   text
+);
@@ -0,0 +1,6 @@
+// Package types declares the override types of the shipping package
+package types
+
+type Weight struct {
+	Grams int64
+}
@@ -0,0 +1,6 @@
+-- name: ListOrders :many
+SELECT * FROM orders;
+
+-- name: ListShipments :many
+SELECT * FROM shipments
+WHERE shipped_at > $1;
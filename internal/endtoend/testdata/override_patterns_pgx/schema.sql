@@ -0,0 +1,14 @@
+CREATE TABLE orders (
+  id         BIGSERIAL PRIMARY KEY,
+  quantity   int4 NOT NULL,
+  discount   int2 NOT NULL,
+  created_at timestamptz NOT NULL,
+  expires_on date NOT NULL
+);
+
+CREATE TABLE shipments (
+  id         BIGSERIAL PRIMARY KEY,
+  order_id   bigint NOT NULL,
+  boxes      int4 NOT NULL,
+  shipped_at timestamptz NOT NULL
+);
@@ -0,0 +1,6 @@
+CREATE TABLE authors (
+  id    BIGSERIAL PRIMARY KEY,
+  name  text NOT NULL,
+  email text NOT NULL,
+  bio   text
+);
@@ -0,0 +1,3 @@
+-- name: ListInvoices :many
+SELECT * FROM invoices
+WHERE user_id = $1;
@@ -0,0 +1,10 @@
+CREATE TABLE users (
+  id    BIGSERIAL PRIMARY KEY,
+  email text NOT NULL
+);
+
+CREATE TABLE invoices (
+  id      BIGSERIAL PRIMARY KEY,
+  user_id bigint NOT NULL,
+  total   bigint NOT NULL
+);
@@ -0,0 +1,11 @@
+CREATE TABLE users (
+  id    BIGSERIAL PRIMARY KEY,
+  email text NOT NULL
+);
+
+CREATE TABLE invoices (
+  id      BIGSERIAL PRIMARY KEY,
+  user_id bigint NOT NULL,
+  total   bigint NOT NULL,
+  note    text
+);
@@ -0,0 +1,33 @@
+package querytest
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestCreateUserParamsBuilder(t *testing.T) {
+	b := NewCreateUserParamsBuilder().
+		WithName("Ada").
+		WithEmail("ada@example.com").
+		WithBio(pgtype.Text{String: "Analyst", Valid: true}).
+		WithAge(36).
+		WithActive(true)
+	got := b.Build()
+	want := CreateUserParams{
+		Name:   "Ada",
+		Email:  "ada@example.com",
+		Bio:    pgtype.Text{String: "Analyst", Valid: true},
+		Age:    36,
+		Active: true,
+	}
+	if got != want {
+		t.Errorf("Build() = %+v, want %+v", got, want)
+	}
+
+	// Build returns a copy, the builder can go on without changing it
+	b.WithName("Grace")
+	if got.Name != "Ada" || b.Build().Name != "Grace" {
+		t.Errorf("Build() shares its params with the builder")
+	}
+}
@@ -0,0 +1,10 @@
+-- name: CreateUser :exec
+INSERT INTO users (
+  name, email, bio, age, active
+) VALUES (
+  $1, $2, $3, $4, $5
+);
+
+-- name: RenameUser :exec
+UPDATE users SET name = $2
+WHERE id = $1;
@@ -0,0 +1,8 @@
+CREATE TABLE users (
+  id     BIGSERIAL PRIMARY KEY,
+  name   text      NOT NULL,
+  email  text      NOT NULL,
+  bio    text,
+  age    integer   NOT NULL,
+  active boolean   NOT NULL
+);
@@ -0,0 +1,11 @@
+-- name: GetEvent :one
+SELECT * FROM events
+WHERE id = $1;
+
+-- name: ListEventTimes :many
+SELECT id, ends_at, state FROM events
+WHERE starts_at > $1;
+
+-- name: UpdateEvent :exec
+UPDATE events SET ends_at = $2, price = $3, ref = $4
+WHERE id = $1;
@@ -0,0 +1,12 @@
+CREATE TYPE status AS ENUM ('open', 'closed');
+
+CREATE TABLE events (
+  id        uuid        PRIMARY KEY,
+  name      text,
+  starts_at timestamptz NOT NULL,
+  ends_at   timestamptz,
+  on_day    date,
+  price     numeric,
+  state     status,
+  ref       uuid
+);
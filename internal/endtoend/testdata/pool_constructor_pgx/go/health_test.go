@@ -0,0 +1,47 @@
+package querytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// execDB is a DBTX that cannot ping, it records the statements it runs
+type execDB struct {
+	DBTX
+	statements []string
+}
+
+func (db *execDB) Exec(_ context.Context, sql string, _ ...interface{}) (pgconn.CommandTag, error) {
+	db.statements = append(db.statements, sql)
+	return pgconn.CommandTag{}, nil
+}
+
+// pingDB is a DBTX that pings like *pgxpool.Pool and *pgx.Conn
+type pingDB struct {
+	execDB
+	err error
+}
+
+func (db *pingDB) Ping(context.Context) error { return db.err }
+
+func TestHealthy(t *testing.T) {
+	exec := &execDB{}
+	if err := New(exec).Healthy(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(exec.statements) != 1 || exec.statements[0] != "SELECT 1" {
+		t.Errorf("Healthy runs %q, want SELECT 1", exec.statements)
+	}
+
+	down := errors.New("connection refused")
+	ping := &pingDB{err: down}
+	if err := New(ping).Healthy(context.Background()); !errors.Is(err, down) {
+		t.Errorf("Healthy() = %v, want the error of Ping", err)
+	}
+	if len(ping.statements) != 0 {
+		t.Errorf("Healthy runs %q although the DBTX can ping", ping.statements)
+	}
+}
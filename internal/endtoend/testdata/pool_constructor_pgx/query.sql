@@ -0,0 +1,3 @@
+-- name: GetAuthor :one
+SELECT * FROM authors
+WHERE id = $1;
@@ -0,0 +1,145 @@
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+)
+
+// fakeDriver counts the statements prepared and closed on its connections.
+// Statements of queries in failing can not be prepared.
+type fakeDriver struct {
+	mu       sync.Mutex
+	failing  map[string]bool
+	prepared map[string]int
+	closed   int
+	direct   int
+}
+
+func (d *fakeDriver) Connect(context.Context) (driver.Conn, error) { return fakeConn{d}, nil }
+func (d *fakeDriver) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct{ d *fakeDriver }
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	if c.d.failing[query] {
+		return nil, errors.New("cannot prepare")
+	}
+	c.d.prepared[query]++
+	return fakeStmt{c.d}, nil
+}
+
+func (c fakeConn) Close() error              { return nil }
+func (c fakeConn) Begin() (driver.Tx, error) { return nil, errors.New("no transactions") }
+
+func (c fakeConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	c.d.direct++
+	return driver.RowsAffected(1), nil
+}
+
+func (c fakeConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	c.d.direct++
+	return fakeRows{}, nil
+}
+
+type fakeStmt struct{ d *fakeDriver }
+
+func (s fakeStmt) Close() error {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.closed++
+	return nil
+}
+
+func (s fakeStmt) NumInput() int                              { return -1 }
+func (s fakeStmt) Exec([]driver.Value) (driver.Result, error) { return driver.RowsAffected(1), nil }
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error)  { return fakeRows{}, nil }
+
+type fakeRows struct{}
+
+func (fakeRows) Columns() []string         { return []string{"id", "name"} }
+func (fakeRows) Close() error              { return nil }
+func (fakeRows) Next([]driver.Value) error { return io.EOF }
+
+func open(t *testing.T, failing ...string) (*Queries, *fakeDriver) {
+	d := &fakeDriver{failing: map[string]bool{}, prepared: map[string]int{}}
+	for _, query := range failing {
+		d.failing[query] = true
+	}
+	db := sql.OpenDB(d)
+	t.Cleanup(func() { db.Close() })
+	q, err := Prepare(context.Background(), db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return q, d
+}
+
+func TestPrepareLazily(t *testing.T) {
+	ctx := context.Background()
+	q, d := open(t)
+	if len(d.prepared) != 0 {
+		t.Fatalf("Prepare() prepared %v, want no statement before first use", d.prepared)
+	}
+	for i := 0; i < 3; i++ {
+		if err := q.DeleteAuthor(ctx, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := q.ListAuthors(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if d.prepared[deleteAuthor] != 1 || len(d.prepared) != 1 {
+		t.Errorf("prepared %v, want deleteAuthor once", d.prepared)
+	}
+	if d.direct != 1 {
+		t.Errorf("%d queries ran unprepared, want ListAuthors", d.direct)
+	}
+
+	if err := q.Reprepare(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if d.prepared[deleteAuthor] != 2 || d.closed != 1 {
+		t.Errorf("Reprepare() prepared %v and closed %d statements, want deleteAuthor again and the previous one closed", d.prepared, d.closed)
+	}
+	if err := q.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if d.closed != 2 {
+		t.Errorf("%d statements were closed, want 2", d.closed)
+	}
+}
+
+func TestPrepareLazilyFailure(t *testing.T) {
+	ctx := context.Background()
+	q, d := open(t, deleteAuthor)
+	if err := q.DeleteAuthor(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if d.direct != 1 {
+		t.Errorf("DeleteAuthor() ran %d queries unprepared, want 1", d.direct)
+	}
+	if err := q.Reprepare(ctx); err == nil {
+		t.Error("Reprepare() succeeded preparing deleteAuthor")
+	}
+	delete(d.failing, deleteAuthor)
+	if err := q.Reprepare(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.DeleteAuthor(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if d.prepared[deleteAuthor] != 1 || d.direct != 1 {
+		t.Errorf("DeleteAuthor() ran unprepared after Reprepare")
+	}
+}
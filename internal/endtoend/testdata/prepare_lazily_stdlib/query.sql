@@ -0,0 +1,12 @@
+-- name: GetAuthor :one
+SELECT * FROM authors
+WHERE id = $1;
+
+-- name: ListAuthors :many
+-- ListAuthors runs unprepared, its plan depends on the table size.
+-- @prepare false
+SELECT * FROM authors
+ORDER BY name;
+
+-- name: DeleteAuthor :exec
+DELETE FROM authors WHERE id = $1;
@@ -0,0 +1,81 @@
+package querytest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var errSent = errors.New("sent")
+
+// sentDB is a DBTX recording the SQL, or statement names, it is sent
+type sentDB struct {
+	pgx.Tx
+	sent *[]string
+}
+
+func (db sentDB) Exec(_ context.Context, sql string, _ ...interface{}) (pgconn.CommandTag, error) {
+	*db.sent = append(*db.sent, sql)
+	return pgconn.CommandTag{}, errSent
+}
+
+func (db sentDB) Query(_ context.Context, sql string, _ ...interface{}) (pgx.Rows, error) {
+	*db.sent = append(*db.sent, sql)
+	return nil, errSent
+}
+
+func (db sentDB) QueryRow(_ context.Context, sql string, _ ...interface{}) pgx.Row {
+	*db.sent = append(*db.sent, sql)
+	return errRow{}
+}
+
+func (db sentDB) CopyFrom(context.Context, pgx.Identifier, []string, pgx.CopyFromSource) (int64, error) {
+	return 0, errSent
+}
+
+func (db sentDB) SendBatch(_ context.Context, b *pgx.Batch) pgx.BatchResults {
+	for _, q := range b.QueuedQueries {
+		*db.sent = append(*db.sent, q.SQL)
+	}
+	return nil
+}
+
+type errRow struct{}
+
+func (errRow) Scan(...any) error { return errSent }
+
+func run(q *Queries) {
+	ctx := context.Background()
+	q.GetAuthor(ctx, 1)
+	q.ListAuthors(ctx)
+	q.DeleteAuthor(ctx, 1)
+	q.DeleteAuthors(ctx, []int64{1})
+}
+
+func TestNewPrepared(t *testing.T) {
+	var sent []string
+	run(NewPrepared(sentDB{sent: &sent}))
+	want := []string{"getAuthor", listAuthors, "deleteAuthor", "deleteAuthors"}
+	if !slices.Equal(sent, want) {
+		t.Errorf("NewPrepared() sent %q, want %q", sent, want)
+	}
+
+	sent = nil
+	run(NewPrepared(nil).WithTx(sentDB{sent: &sent}))
+	if !slices.Equal(sent, want) {
+		t.Errorf("WithTx() sent %q, want %q", sent, want)
+	}
+}
+
+func TestNew(t *testing.T) {
+	var sent []string
+	run(New(sentDB{sent: &sent}))
+	want := []string{getAuthor, listAuthors, deleteAuthor, deleteAuthors}
+	if !slices.Equal(sent, want) {
+		t.Errorf("New() sent %q, want %q", sent, want)
+	}
+}
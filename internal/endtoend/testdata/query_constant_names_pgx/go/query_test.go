@@ -0,0 +1,32 @@
+package querytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var errQuery = errors.New("query recorded")
+
+// queryDB is a DBTX recording the last query
+type queryDB struct {
+	DBTX
+	query string
+}
+
+func (db *queryDB) Query(_ context.Context, query string, _ ...interface{}) (pgx.Rows, error) {
+	db.query = query
+	return nil, errQuery
+}
+
+func TestListAuthorsSQL(t *testing.T) {
+	db := &queryDB{}
+	if _, err := New(db).ListAuthors(context.Background()); err != errQuery {
+		t.Fatalf("ListAuthors() error = %v", err)
+	}
+	if db.query != ListAuthorsSQL {
+		t.Errorf("ListAuthors() ran %q, want ListAuthorsSQL", db.query)
+	}
+}
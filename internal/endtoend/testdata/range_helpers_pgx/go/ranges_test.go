@@ -0,0 +1,83 @@
+package querytest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestInt4RangeHelpers(t *testing.T) {
+	// [2,5)
+	seats := pgtype.Range[pgtype.Int4]{
+		Lower:     pgtype.Int4{Int32: 2, Valid: true},
+		Upper:     pgtype.Int4{Int32: 5, Valid: true},
+		LowerType: pgtype.Inclusive,
+		UpperType: pgtype.Exclusive,
+		Valid:     true,
+	}
+	if lower := Int4RangeLower(seats); lower == nil || *lower != 2 {
+		t.Errorf("Int4RangeLower() = %v, want 2", lower)
+	}
+	if upper := Int4RangeUpper(seats); upper == nil || *upper != 5 {
+		t.Errorf("Int4RangeUpper() = %v, want 5", upper)
+	}
+	for v, want := range map[int32]bool{1: false, 2: true, 4: true, 5: false} {
+		if got := Int4RangeContains(seats, v); got != want {
+			t.Errorf("Int4RangeContains(%d) = %t, want %t", v, got, want)
+		}
+	}
+
+	// (2,)
+	seats.LowerType, seats.UpperType = pgtype.Exclusive, pgtype.Unbounded
+	seats.Upper = pgtype.Int4{}
+	if upper := Int4RangeUpper(seats); upper != nil {
+		t.Errorf("Int4RangeUpper() of an unbounded range = %d, want nil", *upper)
+	}
+	if Int4RangeContains(seats, 2) || !Int4RangeContains(seats, 1000) {
+		t.Error("Int4RangeContains() of (2,) does not hold exactly the values above 2")
+	}
+
+	empty := pgtype.Range[pgtype.Int4]{LowerType: pgtype.Empty, UpperType: pgtype.Empty, Valid: true}
+	if Int4RangeLower(empty) != nil || Int4RangeContains(empty, 0) {
+		t.Error("an empty range has a lower bound or contains 0")
+	}
+	var null pgtype.Range[pgtype.Int4]
+	if Int4RangeLower(null) != nil || Int4RangeUpper(null) != nil || Int4RangeContains(null, 0) {
+		t.Error("a NULL range has bounds or contains 0")
+	}
+}
+
+func TestTstzRangeHelpers(t *testing.T) {
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	// [09:00,10:00]
+	slot := pgtype.Range[pgtype.Timestamptz]{
+		Lower:     pgtype.Timestamptz{Time: start, Valid: true},
+		Upper:     pgtype.Timestamptz{Time: end, Valid: true},
+		LowerType: pgtype.Inclusive,
+		UpperType: pgtype.Inclusive,
+		Valid:     true,
+	}
+	if lower := TstzRangeLower(slot); lower == nil || !lower.Equal(start) {
+		t.Errorf("TstzRangeLower() = %v, want %v", lower, start)
+	}
+	for _, tc := range []struct {
+		v    time.Time
+		want bool
+	}{
+		{start.Add(-time.Second), false},
+		{start, true},
+		{end, true},
+		{end.Add(time.Second), false},
+	} {
+		if got := TstzRangeContains(slot, tc.v); got != tc.want {
+			t.Errorf("TstzRangeContains(%v) = %t, want %t", tc.v, got, tc.want)
+		}
+	}
+
+	slot.UpperType = pgtype.Exclusive
+	if TstzRangeContains(slot, end) {
+		t.Errorf("TstzRangeContains() of a range excluding %v holds it", end)
+	}
+}
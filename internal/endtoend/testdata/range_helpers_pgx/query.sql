@@ -0,0 +1,7 @@
+-- name: GetReservation :one
+SELECT * FROM reservations
+WHERE id = $1;
+
+-- name: ListReservationsDuring :many
+SELECT * FROM reservations
+WHERE slot && $1;
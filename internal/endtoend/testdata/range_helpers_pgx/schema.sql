@@ -0,0 +1,7 @@
+CREATE TABLE reservations (
+  id     BIGSERIAL PRIMARY KEY,
+  seats  int4range NOT NULL,
+  budget int8range,
+  nights daterange NOT NULL,
+  slot   tstzrange NOT NULL
+);
@@ -0,0 +1,63 @@
+package querytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var errHandle = errors.New("handle called")
+
+// handle is a DBTX recording which handle the queries ran on
+type handle struct {
+	name  string
+	calls *[]string
+}
+
+func (h handle) Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error) {
+	*h.calls = append(*h.calls, h.name)
+	return pgconn.CommandTag{}, errHandle
+}
+
+func (h handle) Query(context.Context, string, ...interface{}) (pgx.Rows, error) {
+	*h.calls = append(*h.calls, h.name)
+	return nil, errHandle
+}
+
+func (h handle) QueryRow(context.Context, string, ...interface{}) pgx.Row {
+	*h.calls = append(*h.calls, h.name)
+	return errRow{}
+}
+
+func (h handle) CopyFrom(context.Context, pgx.Identifier, []string, pgx.CopyFromSource) (int64, error) {
+	*h.calls = append(*h.calls, h.name)
+	return 0, errHandle
+}
+
+type errRow struct{}
+
+func (errRow) Scan(...any) error { return errHandle }
+
+func TestReadWriteRouting(t *testing.T) {
+	var calls []string
+	q := NewReadWrite(handle{"reader", &calls}, handle{"writer", &calls})
+	ctx := context.Background()
+
+	q.GetAuthor(ctx, 1)
+	q.ListAuthors(ctx)
+	q.CreateAuthor(ctx, "Ann")
+	q.CopyAuthors(ctx, []string{"Ann"})
+
+	want := []string{"reader", "writer", "writer", "writer"}
+	if len(calls) != len(want) {
+		t.Fatalf("queries ran on %q, want %q", calls, want)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Errorf("query %d ran on %s, want %s", i, calls[i], want[i])
+		}
+	}
+}
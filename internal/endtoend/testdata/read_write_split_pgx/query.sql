@@ -0,0 +1,14 @@
+-- name: GetAuthor :one
+SELECT * FROM authors
+WHERE id = $1;
+
+-- name: ListAuthors :many
+-- @route writer
+SELECT * FROM authors
+ORDER BY name;
+
+-- name: CreateAuthor :exec
+INSERT INTO authors (name) VALUES ($1);
+
+-- name: CopyAuthors :copyfrom
+INSERT INTO authors (name) VALUES ($1);
@@ -0,0 +1,14 @@
+-- name: GetAuthor :one
+SELECT * FROM authors
+WHERE id = ?;
+
+-- name: ListAuthors :many
+-- @route writer
+SELECT * FROM authors
+ORDER BY name;
+
+-- name: CreateAuthor :exec
+INSERT INTO authors (name) VALUES (?);
+
+-- name: CopyAuthors :copyfrom
+INSERT INTO authors (name) VALUES (?);
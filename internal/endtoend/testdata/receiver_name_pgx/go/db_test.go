@@ -0,0 +1,41 @@
+package querytest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// execTx is a transaction counting the statements run on it
+type execTx struct {
+	pgx.Tx
+	execs int
+}
+
+func (tx *execTx) Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error) {
+	tx.execs++
+	return pgconn.NewCommandTag("DELETE 1"), nil
+}
+
+// renamer is implemented by the Queries value, its methods having value
+// receivers
+type renamer interface {
+	RenameAuthor(ctx context.Context, arg RenameAuthorParams) error
+	WithTx(tx pgx.Tx) *Queries
+}
+
+func TestValueReceivers(t *testing.T) {
+	db, tx := &execTx{}, &execTx{}
+	var q renamer = *New(db)
+	if err := q.RenameAuthor(context.Background(), RenameAuthorParams{ID: 1, Name: "Ann"}); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := q.WithTx(tx).DeleteAuthor(context.Background(), 1); err != nil || n != 1 {
+		t.Fatalf("DeleteAuthor() = %d, %v", n, err)
+	}
+	if db.execs != 1 || tx.execs != 1 {
+		t.Errorf("%d statements ran on the db and %d on the transaction, want 1 and 1", db.execs, tx.execs)
+	}
+}
@@ -0,0 +1,7 @@
+-- name: GetUserByEmail :one
+SELECT * FROM users
+WHERE email = $1;
+
+-- name: ListUsers :many
+SELECT * FROM users
+ORDER BY id;
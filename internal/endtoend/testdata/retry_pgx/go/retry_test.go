@@ -0,0 +1,89 @@
+package querytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// failingDB is a DBTX whose Exec fails with the errors in turn, then succeeds
+type failingDB struct {
+	errs  []error
+	calls int
+}
+
+func (db *failingDB) Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error) {
+	db.calls++
+	if len(db.errs) == 0 {
+		return pgconn.CommandTag{}, nil
+	}
+	err := db.errs[0]
+	db.errs = db.errs[1:]
+	return pgconn.CommandTag{}, err
+}
+
+func (db *failingDB) Query(context.Context, string, ...interface{}) (pgx.Rows, error) {
+	panic("unexpected query")
+}
+
+func (db *failingDB) QueryRow(context.Context, string, ...interface{}) pgx.Row {
+	panic("unexpected query")
+}
+
+func TestRetry(t *testing.T) {
+	serialization := &pgconn.PgError{Code: "40001"}
+	deadlock := &pgconn.PgError{Code: "40P01"}
+	unique := &pgconn.PgError{Code: "23505"}
+	for _, tc := range []struct {
+		name  string
+		errs  []error
+		calls int
+		err   error
+	}{
+		{"success", nil, 1, nil},
+		{"transient", []error{serialization, deadlock}, 3, nil},
+		{"exhausted", []error{serialization, serialization, deadlock}, 3, deadlock},
+		{"permanent", []error{unique}, 1, unique},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			db := &failingDB{errs: tc.errs}
+			q := NewRetry(New(db))
+			q.Backoff = func(int) time.Duration { return 0 }
+			if err := q.DeleteAuthor(context.Background(), 1); err != tc.err {
+				t.Errorf("DeleteAuthor() error = %v, want %v", err, tc.err)
+			}
+			if db.calls != tc.calls {
+				t.Errorf("DeleteAuthor() ran %d times, want %d", db.calls, tc.calls)
+			}
+		})
+	}
+}
+
+func TestRetryCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	db := &failingDB{errs: []error{&pgconn.PgError{Code: "40001"}}}
+	q := NewRetry(New(db))
+	q.Backoff = func(int) time.Duration {
+		cancel()
+		return time.Hour
+	}
+	if err := q.DeleteAuthor(ctx, 1); err == nil {
+		t.Fatal("DeleteAuthor() succeeded after the context was canceled")
+	}
+	if db.calls != 1 {
+		t.Errorf("DeleteAuthor() ran %d times, want 1", db.calls)
+	}
+}
+
+func TestIsTransientError(t *testing.T) {
+	if !IsTransientError(&pgconn.PgError{Code: "40001"}) {
+		t.Error("serialization failures are not transient")
+	}
+	if IsTransientError(errors.New("syntax error")) {
+		t.Error("other errors are transient")
+	}
+}
@@ -0,0 +1,17 @@
+-- name: GetAuthor :one
+-- @retry 3
+SELECT * FROM authors
+WHERE id = $1 LIMIT 1;
+
+-- name: ListAuthors :many
+-- ListAuthors reads every author.
+SELECT * FROM authors
+ORDER BY name;
+
+-- name: DeleteAuthor :exec
+-- @retry 2
+DELETE FROM authors
+WHERE id = $1;
+
+-- name: CountAuthors :one
+SELECT count(*) FROM authors;
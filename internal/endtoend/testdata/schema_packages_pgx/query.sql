@@ -0,0 +1,7 @@
+-- name: GetUser :one
+SELECT * FROM users
+WHERE id = $1;
+
+-- name: ListInvoices :many
+SELECT * FROM billing.invoices
+WHERE user_id = $1 AND status = $2;
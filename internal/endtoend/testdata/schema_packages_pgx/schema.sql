@@ -0,0 +1,14 @@
+CREATE TABLE users (
+  id    BIGSERIAL PRIMARY KEY,
+  email text NOT NULL
+);
+
+CREATE SCHEMA billing;
+
+CREATE TYPE billing.invoice_status AS ENUM ('open', 'paid');
+
+CREATE TABLE billing.invoices (
+  id      BIGSERIAL PRIMARY KEY,
+  user_id bigint NOT NULL REFERENCES users (id),
+  status  billing.invoice_status NOT NULL
+);
@@ -0,0 +1,39 @@
+package querytest
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestRedaction(t *testing.T) {
+	user := User{ID: 7, Email: "ann@example.com", PasswordHash: "$2a$10$secret", Name: "Ann"}
+	params := CreateUserParams{Email: "ann@example.com", PasswordHash: "$2a$10$secret", Name: "Ann"}
+
+	if got, want := fmt.Sprint(user), "User{ID:7 Email:[REDACTED] PasswordHash:[REDACTED] Name:Ann}"; got != want {
+		t.Errorf("fmt.Sprint(user) = %s, want %s", got, want)
+	}
+	if got, want := fmt.Sprintf("%v", params), "CreateUserParams{Email:[REDACTED] PasswordHash:[REDACTED] Name:Ann}"; got != want {
+		t.Errorf("fmt.Sprintf(%%v, params) = %s, want %s", got, want)
+	}
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+		if a.Key == slog.TimeKey {
+			return slog.Attr{}
+		}
+		return a
+	}}))
+	logger.Info("created", "user", user, "params", params)
+	got := buf.String()
+	if strings.Contains(got, "ann@example.com") || strings.Contains(got, "secret") {
+		t.Errorf("the log leaks a sensitive field: %s", got)
+	}
+	for _, want := range []string{"user.ID=7", "user.Email=[REDACTED]", "user.Name=Ann", "params.PasswordHash=[REDACTED]"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("the log does not contain %s: %s", want, got)
+		}
+	}
+}
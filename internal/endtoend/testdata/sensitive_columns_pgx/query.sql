@@ -0,0 +1,7 @@
+-- name: GetUser :one
+SELECT * FROM users
+WHERE id = $1;
+
+-- name: CreateUser :exec
+INSERT INTO users (email, password_hash, name)
+VALUES ($1, $2, $3);
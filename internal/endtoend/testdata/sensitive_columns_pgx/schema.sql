@@ -0,0 +1,8 @@
+CREATE TABLE users (
+  id            BIGSERIAL PRIMARY KEY,
+  email         text NOT NULL,
+  password_hash text NOT NULL,
+  name          text NOT NULL
+);
+
+COMMENT ON COLUMN users.password_hash IS 'sensitive: hashed';
@@ -0,0 +1,30 @@
+package querytest
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSensitiveStructTag(t *testing.T) {
+	data, err := json.Marshal(User{ID: 7, Email: "ann@example.com", PasswordHash: "secret", Name: "Ann"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), `{"id":7,"name":"Ann"}`; got != want {
+		t.Errorf("json.Marshal(user) = %s, want %s", got, want)
+	}
+
+	for _, typ := range []reflect.Type{reflect.TypeOf(User{}), reflect.TypeOf(CreateUserParams{})} {
+		for _, name := range []string{"Email", "PasswordHash", "Name"} {
+			field, _ := typ.FieldByName(name)
+			want := "-"
+			if name == "Name" {
+				want = ""
+			}
+			if got := field.Tag.Get("log"); got != want {
+				t.Errorf("%s.%s has log tag %q, want %q", typ.Name(), name, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package querytest
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func methods(iface interface{}) []string {
+	typ := reflect.TypeOf(iface).Elem()
+	var names []string
+	for i := 0; i < typ.NumMethod(); i++ {
+		names = append(names, typ.Method(i).Name)
+	}
+	return names
+}
+
+func TestSplitQuerier(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		iface interface{}
+		want  []string
+	}{
+		{"QuerierReader", (*QuerierReader)(nil), []string{"GetAuthor", "ListAuthors"}},
+		{"QuerierWriter", (*QuerierWriter)(nil), []string{"DeleteAuthor", "RenameAuthor"}},
+		{"Querier", (*Querier)(nil), []string{"DeleteAuthor", "GetAuthor", "ListAuthors", "RenameAuthor"}},
+	} {
+		if got := methods(test.iface); !slices.Equal(got, test.want) {
+			t.Errorf("%s has methods %v, want %v", test.name, got, test.want)
+		}
+	}
+}
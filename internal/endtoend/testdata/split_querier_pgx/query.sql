@@ -0,0 +1,14 @@
+-- name: GetAuthor :one
+SELECT * FROM authors
+WHERE id = $1;
+
+-- name: ListAuthors :many
+SELECT * FROM authors;
+
+-- name: RenameAuthor :exec
+UPDATE authors SET name = $2
+WHERE id = $1;
+
+-- name: DeleteAuthor :execrows
+DELETE FROM authors
+WHERE id = $1;
@@ -0,0 +1,60 @@
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+)
+
+// rowidDriver answers every query with the rowid 42, and fails the statements
+// run without reading their result
+type rowidDriver struct{ queries []string }
+
+func (d *rowidDriver) Connect(context.Context) (driver.Conn, error) { return rowidConn{d}, nil }
+func (d *rowidDriver) Driver() driver.Driver                        { return nil }
+
+type rowidConn struct{ d *rowidDriver }
+
+func (c rowidConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not prepared") }
+func (c rowidConn) Close() error                        { return nil }
+func (c rowidConn) Begin() (driver.Tx, error)           { return nil, errors.New("no transactions") }
+
+func (c rowidConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	return nil, errors.New("the rowid is not read from the statement")
+}
+
+func (c rowidConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	c.d.queries = append(c.d.queries, query)
+	return &rowidRows{}, nil
+}
+
+type rowidRows struct{ done bool }
+
+func (r *rowidRows) Columns() []string { return []string{"rowid"} }
+func (r *rowidRows) Close() error      { return nil }
+
+func (r *rowidRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = int64(42)
+	return nil
+}
+
+func TestCreateAuthorReturning(t *testing.T) {
+	d := &rowidDriver{}
+	db := sql.OpenDB(d)
+	defer db.Close()
+
+	id, err := New(db).CreateAuthor(context.Background(), "Ann")
+	if err != nil || id != 42 {
+		t.Fatalf("CreateAuthor() = %d, %v, want 42", id, err)
+	}
+	if len(d.queries) != 1 || d.queries[0] != createAuthor {
+		t.Errorf("CreateAuthor() ran %q, want createAuthor", d.queries)
+	}
+}
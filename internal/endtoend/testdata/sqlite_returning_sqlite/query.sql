@@ -0,0 +1,6 @@
+-- name: CreateAuthor :execlastid
+INSERT INTO authors (name) VALUES (?);
+
+-- name: InsertAuthor :one
+INSERT INTO authors (name) VALUES (?)
+RETURNING id;
@@ -0,0 +1,72 @@
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/endtoend/testdata/stdlib_package_pgx/go/entity"
+	"github.com/sqlc-dev/sqlc-gen-go/internal/endtoend/testdata/stdlib_package_pgx/go/std"
+)
+
+// authorsDriver answers every query with the author row
+type authorsDriver struct{ row []driver.Value }
+
+func (d *authorsDriver) Connect(context.Context) (driver.Conn, error) { return authorsConn{d}, nil }
+func (d *authorsDriver) Driver() driver.Driver                        { return nil }
+
+type authorsConn struct{ d *authorsDriver }
+
+func (c authorsConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not prepared") }
+func (c authorsConn) Close() error                        { return nil }
+func (c authorsConn) Begin() (driver.Tx, error)           { return nil, errors.New("no transactions") }
+
+func (c authorsConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return &authorsRows{row: c.d.row}, nil
+}
+
+type authorsRows struct{ row []driver.Value }
+
+func (r *authorsRows) Columns() []string { return []string{"id", "name", "bio"} }
+func (r *authorsRows) Close() error      { return nil }
+
+func (r *authorsRows) Next(dest []driver.Value) error {
+	if r.row == nil {
+		return io.EOF
+	}
+	copy(dest, r.row)
+	r.row = nil
+	return nil
+}
+
+func TestStdlibGetAuthor(t *testing.T) {
+	for _, test := range []struct {
+		row  []driver.Value
+		want entity.Author
+	}{
+		{[]driver.Value{int64(1), "Ann", nil}, entity.Author{ID: 1, Name: "Ann"}},
+		{[]driver.Value{int64(2), "Bob", "Writes"}, entity.Author{ID: 2, Name: "Bob", Bio: pgtype.Text{String: "Writes", Valid: true}}},
+	} {
+		db := sql.OpenDB(&authorsDriver{row: test.row})
+		author, err := dbstd.New(db).GetAuthor(context.Background(), test.want.ID)
+		db.Close()
+		if err != nil || author != test.want {
+			t.Errorf("GetAuthor() = %+v, %v, want %+v", author, err, test.want)
+		}
+	}
+}
+
+func TestCopyFromLeftOut(t *testing.T) {
+	if _, ok := reflect.TypeOf(&Queries{}).MethodByName("CopyAuthors"); !ok {
+		t.Error("the pgx/v5 package lacks the :copyfrom query")
+	}
+	if _, ok := reflect.TypeOf(&dbstd.Queries{}).MethodByName("CopyAuthors"); ok {
+		t.Error("the database/sql package has the :copyfrom query")
+	}
+}
@@ -0,0 +1,6 @@
+-- name: GetAuthor :one
+SELECT * FROM authors
+WHERE id = $1;
+
+-- name: CopyAuthors :copyfrom
+INSERT INTO authors (name, bio) VALUES ($1, $2);
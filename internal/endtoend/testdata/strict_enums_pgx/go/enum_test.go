@@ -0,0 +1,19 @@
+package querytest
+
+import (
+	"database/sql/driver"
+	"testing"
+)
+
+func TestStrictEnumScan(t *testing.T) {
+	var account Account
+	if err := account.Status.Scan("active"); err != nil || account.Status != StatusActive {
+		t.Errorf("Scan(active) = %v, Status %q", err, account.Status)
+	}
+	if err := account.Status.Scan([]byte("deleted")); err == nil {
+		t.Error("Scan(deleted) succeeded")
+	}
+	if _, ok := any(StatusActive).(driver.Valuer); ok {
+		t.Error("Status implements driver.Valuer, pgx encodes enums as strings")
+	}
+}
@@ -0,0 +1,7 @@
+-- name: GetAccount :one
+SELECT * FROM accounts
+WHERE id = $1;
+
+-- name: SetStatus :exec
+UPDATE accounts SET status = $2, prior = $3
+WHERE id = $1;
@@ -0,0 +1,36 @@
+package querytest
+
+import (
+	"testing"
+)
+
+func TestStrictEnumScan(t *testing.T) {
+	var account Account
+	if err := account.Status.Scan([]byte("banned")); err != nil || account.Status != StatusBanned {
+		t.Errorf("Scan(banned) = %v, Status %q", err, account.Status)
+	}
+	if err := account.Status.Scan("deleted"); err == nil {
+		t.Error("Scan(deleted) succeeded")
+	}
+	if account.Status != StatusBanned {
+		t.Errorf("Status = %q after a failed Scan, want it unchanged", account.Status)
+	}
+	if err := account.Prior.Scan(nil); err != nil || account.Prior.Valid {
+		t.Errorf("Scan(nil) = %v, Valid %t", err, account.Prior.Valid)
+	}
+}
+
+func TestStrictEnumValue(t *testing.T) {
+	if v, err := StatusActive.Value(); err != nil || v != "active" {
+		t.Errorf("StatusActive.Value() = %v, %v", v, err)
+	}
+	if _, err := Status("deleted").Value(); err == nil {
+		t.Error("Value() of an unknown Status succeeded")
+	}
+	if v, err := (NullStatus{}).Value(); err != nil || v != nil {
+		t.Errorf("Value() of a NULL NullStatus = %v, %v, want nil", v, err)
+	}
+	if _, err := (NullStatus{Status: "deleted", Valid: true}).Value(); err == nil {
+		t.Error("Value() of a NullStatus holding an unknown Status succeeded")
+	}
+}
@@ -0,0 +1,7 @@
+CREATE TYPE status AS ENUM ('active', 'banned');
+
+CREATE TABLE accounts (
+  id     BIGSERIAL PRIMARY KEY,
+  status status NOT NULL,
+  prior  status
+);
@@ -0,0 +1,7 @@
+-- name: GetUser :one
+SELECT * FROM users
+WHERE id = $1;
+
+-- name: UpdateUser :exec
+UPDATE users SET name = $2, email = $3
+WHERE id = $1;
@@ -0,0 +1,6 @@
+CREATE TABLE users (
+  name       text NOT NULL,
+  id         BIGSERIAL PRIMARY KEY,
+  email      text NOT NULL,
+  created_at timestamptz NOT NULL
+);
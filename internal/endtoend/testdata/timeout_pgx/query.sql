@@ -0,0 +1,20 @@
+-- name: GetAuthor :one
+-- @timeout 500ms
+SELECT * FROM authors
+WHERE id = $1 LIMIT 1;
+
+-- name: ListAuthors :many
+-- ListAuthors reads every author.
+-- @timeout 2s
+SELECT * FROM authors
+ORDER BY name;
+
+-- name: DeleteAuthor :exec
+-- @timeout 1m30s
+DELETE FROM authors
+WHERE id = $1;
+
+-- name: CountAuthors :one
+-- CountAuthors counts the authors.
+-- timeout: none, counting is cheap
+SELECT count(*) FROM authors;
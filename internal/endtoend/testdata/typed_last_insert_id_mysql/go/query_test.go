@@ -0,0 +1,35 @@
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+)
+
+// lastInsertIDResult is the result of an insert generating id
+type lastInsertIDResult int64
+
+func (r lastInsertIDResult) LastInsertId() (int64, error) { return int64(r), nil }
+func (r lastInsertIDResult) RowsAffected() (int64, error) { return 1, nil }
+
+// insertDB is a DBTX whose inserts generate id
+type insertDB struct {
+	DBTX
+	id int64
+}
+
+func (db insertDB) ExecContext(context.Context, string, ...interface{}) (sql.Result, error) {
+	return lastInsertIDResult(db.id), nil
+}
+
+func TestTypedLastInsertID(t *testing.T) {
+	var q Querier = New(insertDB{id: 42})
+	author, err := q.CreateAuthor(context.Background(), "Ann")
+	if err != nil || author != uint64(42) {
+		t.Errorf("CreateAuthor() = %d, %v, want 42", author, err)
+	}
+	book, err := q.CreateBook(context.Background(), "First")
+	if err != nil || book != int64(42) {
+		t.Errorf("CreateBook() = %d, %v, want 42", book, err)
+	}
+}
@@ -0,0 +1,5 @@
+-- name: CreateAuthor :execlastid
+INSERT INTO authors (name) VALUES (?);
+
+-- name: CreateBook :execlastid
+INSERT INTO books (title) VALUES (?);
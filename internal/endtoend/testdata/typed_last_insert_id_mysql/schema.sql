@@ -0,0 +1,9 @@
+CREATE TABLE authors (
+  id   bigint unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  name text NOT NULL
+);
+
+CREATE TABLE books (
+  id    bigint NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  title text NOT NULL
+);
@@ -0,0 +1,34 @@
+package querytest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// execTx is a transaction counting the statements run on it
+type execTx struct {
+	pgx.Tx
+	execs int
+}
+
+func (tx *execTx) Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error) {
+	tx.execs++
+	return pgconn.NewCommandTag("UPDATE 1"), nil
+}
+
+func TestWithTx(t *testing.T) {
+	db, tx := &execTx{}, &execTx{}
+	var q Querier = New(db)
+	if err := q.WithTx(tx).RenameAuthor(context.Background(), RenameAuthorParams{ID: 1, Name: "Ann"}); err != nil {
+		t.Fatal(err)
+	}
+	if db.execs != 0 || tx.execs != 1 {
+		t.Errorf("RenameAuthor ran %d statements on the db and %d on the transaction, want 0 and 1", db.execs, tx.execs)
+	}
+	if n, err := q.DeleteAuthor(context.Background(), 1); err != nil || n != 1 || db.execs != 1 {
+		t.Errorf("DeleteAuthor() = %d, %v after %d statements on the db", n, err, db.execs)
+	}
+}
@@ -0,0 +1,119 @@
+package golang
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/golden"
+)
+
+// TestEndToEnd runs the sqlc projects of endtoend/testdata and compares the
+// generated files with their go directory. request.json is the request sqlc
+// sends for schema.sql and query.sql, the plugin options are read from
+// sqlc.yaml so that the config documents the case. The go directories are
+// then vetted, and the hand-written tests next to the generated files run.
+// A go directory importing libraries this module does not require has its
+// own go.mod and go.sum.
+func TestEndToEnd(t *testing.T) {
+	configs, err := filepath.Glob(filepath.Join("endtoend", "testdata", "*", "sqlc.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The generated packages build in this module, unless their go directory
+	// has its own go.mod requiring the libraries they import
+	modules := map[string]*endToEndModule{".": {}}
+	for _, config := range configs {
+		dir := filepath.Dir(config)
+		out := filepath.Join(dir, "go")
+		mod, pkg := modules["."], "./"+filepath.ToSlash(out)+"/..."
+		if _, err := os.Stat(filepath.Join(out, "go.mod")); err == nil {
+			mod, pkg = &endToEndModule{}, "./..."
+			modules[out] = mod
+		}
+		mod.pkgs = append(mod.pkgs, pkg)
+		if tests, _ := filepath.Glob(filepath.Join(out, "*_test.go")); len(tests) > 0 {
+			mod.tested = append(mod.tested, pkg)
+		}
+		t.Run(filepath.Base(dir), func(t *testing.T) {
+			req, err := golden.ReadRequest(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if req.PluginOptions, err = endToEndOptions(config); err != nil {
+				t.Fatal(err)
+			}
+			resp, err := Generate(context.Background(), req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			golden.Compare(t, filepath.Join(dir, "go"), resp.Files)
+		})
+	}
+	if t.Failed() {
+		return
+	}
+	t.Run("compile", func(t *testing.T) {
+		compileEndToEnd(t, modules)
+	})
+}
+
+// endToEndModule lists the generated packages building in a module, and those
+// of them with tests
+type endToEndModule struct {
+	pkgs, tested []string
+}
+
+// compileEndToEnd runs go vet on the generated packages and go test on those
+// with tests, in the module directories they build in
+func compileEndToEnd(t *testing.T, modules map[string]*endToEndModule) {
+	if testing.Short() {
+		t.Skip("compiling the generated packages is slow")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("the go command is not available")
+	}
+	run := func(dir string, args ...string) {
+		cmd := exec.Command("go", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Errorf("go %s in %s failed: %s\n%s", strings.Join(args, " "), dir, err, out)
+		}
+	}
+	for dir, mod := range modules {
+		if len(mod.pkgs) == 0 {
+			continue
+		}
+		run(dir, append([]string{"vet"}, mod.pkgs...)...)
+		if len(mod.tested) > 0 {
+			run(dir, append([]string{"test", "-count=1"}, mod.tested...)...)
+		}
+	}
+}
+
+// endToEndOptions returns the options of the first codegen entry of the
+// sqlc.yaml at path, encoded as sqlc passes them to the plugin
+func endToEndOptions(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config struct {
+		SQL []struct {
+			Codegen []struct {
+				Options map[string]any `yaml:"options"`
+			} `yaml:"codegen"`
+		} `yaml:"sql"`
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	return json.Marshal(config.SQL[0].Codegen[0].Options)
+}
@@ -3,6 +3,8 @@ package golang
 import (
 	"strings"
 	"unicode"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
 )
 
 type Constant struct {
@@ -12,11 +14,16 @@ type Constant struct {
 }
 
 type Enum struct {
-	Name      string
+	Name string
+	// DBName is the name of the enum in the database, e.g. public.mood
+	DBName    string
 	Comment   string
 	Constants []Constant
 	NameTags  map[string]string
 	ValidTags map[string]string
+	// Package is the schema package the enum is generated into, empty for
+	// the models package
+	Package string
 }
 
 func (e Enum) NameTag() string {
@@ -27,6 +34,25 @@ func (e Enum) ValidTag() string {
 	return TagsToString(e.ValidTags)
 }
 
+// findEnumConfig returns the enums entry configured for schema.name. An empty
+// config is returned for enums without one, so that callers can read its
+// fields unconditionally.
+func findEnumConfig(options *opts.Options, defaultSchema, schema, name string) *opts.EnumConfig {
+	for _, cfg := range options.Enums {
+		rel, err := parseIdentifierString(cfg.Name)
+		if err != nil {
+			continue
+		}
+		if rel.Schema == "" {
+			rel.Schema = defaultSchema
+		}
+		if rel.Schema == schema && rel.Name == name {
+			return cfg
+		}
+	}
+	return &opts.EnumConfig{}
+}
+
 func enumReplacer(r rune) rune {
 	if strings.ContainsRune("-/:_", r) {
 		return '_'
@@ -0,0 +1,61 @@
+package golang
+
+import (
+	"fmt"
+
+	"github.com/sqlc-dev/plugin-sdk-go/metadata"
+)
+
+// explainPrefix is the name of the generated constant prefixing the SQL of
+// the queries with EXPLAIN
+const explainPrefix = "explainPrefix"
+
+// explainCommands are the commands of the queries that get an EXPLAIN method
+// with emit_explain_methods, copyfrom and batches send no single statement
+var explainCommands = map[string]struct{}{
+	metadata.CmdOne:        {},
+	metadata.CmdMany:       {},
+	metadata.CmdExec:       {},
+	metadata.CmdExecRows:   {},
+	metadata.CmdExecResult: {},
+}
+
+// explainedQueries returns the queries that get an EXPLAIN method. Queries
+// whose SQL is built when they run, by a filter annotation or sqlc.slice,
+// are left out.
+func explainedQueries(queries []Query) []Query {
+	var explained []Query
+	for _, q := range queries {
+		if _, ok := explainCommands[q.Cmd]; !ok {
+			continue
+		}
+		if q.Filter != nil || q.Arg.HasSqlcSlices() {
+			continue
+		}
+		explained = append(explained, q)
+	}
+	return explained
+}
+
+// validateExplain checks that the EXPLAIN methods and constant do not clash
+// with the methods and constants of the queries
+func validateExplain(queries []Query) error {
+	methods := map[string]struct{}{}
+	for _, q := range queries {
+		methods[q.MethodName] = struct{}{}
+		if q.ConstantName == explainPrefix {
+			return fmt.Errorf("emit_explain_methods: the constant of query %s is named %s", q.MethodName, explainPrefix)
+		}
+	}
+	for _, q := range explainedQueries(queries) {
+		if _, ok := methods[q.ExplainMethodName()]; ok {
+			return fmt.Errorf("emit_explain_methods: the EXPLAIN method of query %s is named as query %s", q.MethodName, q.ExplainMethodName())
+		}
+	}
+	return nil
+}
+
+// ExplainMethodName is the name of the method returning the plan of the query
+func (q Query) ExplainMethodName() string {
+	return "Explain" + q.MethodName
+}
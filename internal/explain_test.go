@@ -0,0 +1,52 @@
+package golang
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/sqlc-dev/plugin-sdk-go/metadata"
+)
+
+func TestExplainedQueries(t *testing.T) {
+	queries := []Query{
+		{MethodName: "GetAuthor", Cmd: metadata.CmdOne},
+		{MethodName: "ListAuthors", Cmd: metadata.CmdMany},
+		{MethodName: "DeleteAuthor", Cmd: metadata.CmdExecRows},
+		{MethodName: "CopyAuthors", Cmd: metadata.CmdCopyFrom},
+		{MethodName: "GetAuthors", Cmd: metadata.CmdBatchOne},
+		{MethodName: "FindAuthors", Cmd: metadata.CmdMany, Filter: &Filter{}},
+	}
+	var got []string
+	for _, q := range explainedQueries(queries) {
+		got = append(got, q.ExplainMethodName())
+	}
+	if want := []string{"ExplainGetAuthor", "ExplainListAuthors", "ExplainDeleteAuthor"}; !slices.Equal(got, want) {
+		t.Errorf("explainedQueries() = %v, want %v", got, want)
+	}
+}
+
+func TestValidateExplain(t *testing.T) {
+	for _, tc := range []struct {
+		queries []Query
+		err     string
+	}{
+		{[]Query{{MethodName: "GetAuthor", ConstantName: "getAuthor", Cmd: metadata.CmdOne}}, ""},
+		{[]Query{
+			{MethodName: "GetAuthor", ConstantName: "getAuthor", Cmd: metadata.CmdOne},
+			{MethodName: "ExplainGetAuthor", ConstantName: "explainGetAuthor", Cmd: metadata.CmdExec},
+		}, "the EXPLAIN method of query GetAuthor is named as query ExplainGetAuthor"},
+		{[]Query{{MethodName: "Prefix", ConstantName: explainPrefix, Cmd: metadata.CmdCopyFrom}}, "the constant of query Prefix is named explainPrefix"},
+	} {
+		err := validateExplain(tc.queries)
+		if tc.err == "" {
+			if err != nil {
+				t.Errorf("validateExplain() error = %v", err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("validateExplain() error = %v, want %q", err, tc.err)
+		}
+	}
+}
@@ -17,8 +17,16 @@ type Field struct {
 	Tags    map[string]string
 	Comment string
 	Column  *plugin.Column
+	// Sensitive fields are redacted by the String and LogValue methods of
+	// their struct
+	Sensitive bool
 	// EmbedFields contains the embedded fields that require scanning.
 	EmbedFields []Field
+	// EmbedNullable embeds are pointers, nil when all their columns are NULL
+	EmbedNullable bool
+	// EmbedValid embeds are followed by a <Name>Valid field, false when all
+	// their columns are NULL
+	EmbedValid bool
 }
 
 func (gf Field) Tag() string {
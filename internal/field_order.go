@@ -0,0 +1,30 @@
+package golang
+
+import (
+	"sort"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+// orderFields returns fields in the order they are declared in generated
+// structs. Scanning and argument lists always follow the column order, so
+// only the declaration is affected by the struct_field_order option.
+func orderFields(order string, fields []Field) []Field {
+	if order == "" || order == opts.FieldOrderColumn {
+		return fields
+	}
+
+	ordered := make([]Field, len(fields))
+	copy(ordered, fields)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if order == opts.FieldOrderCanonical {
+			// The ID leads in canonical order, so that it stays the first field
+			// whatever columns are added
+			if a, b := ordered[i].Name == "ID", ordered[j].Name == "ID"; a != b {
+				return a
+			}
+		}
+		return ordered[i].Name < ordered[j].Name
+	})
+	return ordered
+}
@@ -0,0 +1,32 @@
+package golang
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+func TestOrderFields(t *testing.T) {
+	fields := []Field{{Name: "Name"}, {Name: "ID"}, {Name: "CreatedAt"}, {Name: "Email"}}
+	for _, tc := range []struct {
+		order string
+		want  []string
+	}{
+		{"", []string{"Name", "ID", "CreatedAt", "Email"}},
+		{opts.FieldOrderColumn, []string{"Name", "ID", "CreatedAt", "Email"}},
+		{opts.FieldOrderAlphabetical, []string{"CreatedAt", "Email", "ID", "Name"}},
+		{opts.FieldOrderCanonical, []string{"ID", "CreatedAt", "Email", "Name"}},
+	} {
+		var got []string
+		for _, f := range orderFields(tc.order, fields) {
+			got = append(got, f.Name)
+		}
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("orderFields(%q) = %v, want %v", tc.order, got, tc.want)
+		}
+	}
+	if fields[0].Name != "Name" || fields[1].Name != "ID" {
+		t.Errorf("orderFields() reordered its argument: %v", fields)
+	}
+}
@@ -0,0 +1,61 @@
+package golang
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+)
+
+// HeaderData holds the variables available to the file_header and
+// header_template templates
+type HeaderData struct {
+	Year        int
+	Package     string
+	FileName    string
+	SourceName  string
+	SqlcVersion string
+}
+
+// renderHeader executes a header template for a generated file. Lines that
+// are not already comments are turned into line comments.
+func renderHeader(tmpl *template.Template, data HeaderData) (string, error) {
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+
+	text := strings.TrimSpace(b.String())
+	if text == "" {
+		return "", nil
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+		switch {
+		case line == "":
+			lines[i] = "//"
+		case strings.HasPrefix(line, "//"):
+			lines[i] = line
+		default:
+			lines[i] = "// " + line
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// insertFileHeader places header at the top of code, after the build
+// constraint if there is one, separated by a blank line so that it does not
+// become part of the package comment
+func insertFileHeader(code []byte, header string) []byte {
+	var out bytes.Buffer
+	if bytes.HasPrefix(code, []byte("//go:build ")) {
+		if i := bytes.Index(code, []byte("\n\n")); i != -1 {
+			out.Write(code[:i+2])
+			code = code[i+2:]
+		}
+	}
+	out.WriteString(header)
+	out.WriteString("\n\n")
+	out.Write(code)
+	return out.Bytes()
+}
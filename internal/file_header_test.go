@@ -0,0 +1,33 @@
+package golang
+
+import (
+	"testing"
+	"text/template"
+)
+
+func TestRenderHeader(t *testing.T) {
+	tmpl := template.Must(template.New("file_header").Parse("Copyright {{.Year}} Example Inc.\n\n// {{.FileName}} from {{.SourceName}}  \n"))
+	got, err := renderHeader(tmpl, HeaderData{Year: 2024, FileName: "query.sql.go", SourceName: "query.sql"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "// Copyright 2024 Example Inc.\n//\n// query.sql.go from query.sql"; got != want {
+		t.Errorf("renderHeader() = %q, want %q", got, want)
+	}
+
+	empty := template.Must(template.New("file_header").Parse("{{if .Package}}{{.Package}}{{end}}"))
+	if got, err := renderHeader(empty, HeaderData{}); err != nil || got != "" {
+		t.Errorf("renderHeader() of an empty header = %q, %v, want no header", got, err)
+	}
+}
+
+func TestInsertFileHeader(t *testing.T) {
+	for code, want := range map[string]string{
+		"// Code generated by sqlc. DO NOT EDIT.\n\npackage db\n":                      "// Header\n\n// Code generated by sqlc. DO NOT EDIT.\n\npackage db\n",
+		"//go:build go1.21\n\n// Code generated by sqlc. DO NOT EDIT.\n\npackage db\n": "//go:build go1.21\n\n// Header\n\n// Code generated by sqlc. DO NOT EDIT.\n\npackage db\n",
+	} {
+		if got := string(insertFileHeader([]byte(code), "// Header")); got != want {
+			t.Errorf("insertFileHeader(%q) = %q, want %q", code, got, want)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sqlc-dev/plugin-sdk-go/metadata"
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+// filterOperators are the operators of the "-- @filter name like" annotation
+// by the suffix of the method adding their condition
+var filterOperators = []struct {
+	Op     string
+	Suffix string
+}{
+	{"=", ""},
+	{"<>", "Not"},
+	{">", "After"},
+	{">=", "AtLeast"},
+	{"<", "Before"},
+	{"<=", "AtMost"},
+	{"like", "Like"},
+	{"ilike", "ILike"},
+}
+
+// Filter is the dynamic filter of a :many query annotated with
+// "-- @filter name like, created_at >" and "-- @order_by created_at, name",
+// a builder of the optional conditions and ordering its Filtered variant
+// composes onto the query. Only the values are passed by the caller, the
+// columns and operators are those of the annotations. The query is filtered as
+// a subquery, so it can not have a LIMIT or OFFSET, which would apply before
+// the conditions.
+type Filter struct {
+	Conditions []FilterCondition
+	OrderBy    []FilterOrder
+}
+
+type FilterCondition struct {
+	Method string // e.g. WhereNameLike
+	SQL    string // e.g. name LIKE
+	Type   string // Go type of the value
+}
+
+type FilterOrder struct {
+	Name   string // Constant name stem, e.g. CreatedAt
+	Column string
+}
+
+// queryFilter returns the dynamic filter of a query annotated with filter or
+// order_by annotations, whose columns must be columns of the rows of ret
+func queryFilter(req *plugin.GenerateRequest, options *opts.Options, query *plugin.Query, ret QueryValue, annotations map[string]string) (*Filter, error) {
+	conditions, hasConditions := annotations[annotationFilter]
+	orderBy, hasOrderBy := annotations[annotationOrderBy]
+	if !hasConditions && !hasOrderBy {
+		return nil, nil
+	}
+	if query.Cmd != metadata.CmdMany {
+		return nil, fmt.Errorf("filter and order_by are not supported for %s queries", query.Cmd)
+	}
+	if limitClause.MatchString(query.Text) {
+		return nil, fmt.Errorf("filter and order_by require a query without LIMIT or OFFSET")
+	}
+	field := func(column string) (*Field, error) {
+		if ret.Struct == nil {
+			if ret.DBName == column {
+				return &Field{Name: StructName(column, options), Type: ret.Type(), Column: ret.Column}, nil
+			}
+			return nil, fmt.Errorf("%s is not a column of the query", column)
+		}
+		for _, f := range ret.Struct.Fields {
+			name := f.DBName
+			if name == "" && f.Column != nil {
+				name = f.Column.Name
+			}
+			if name == column && len(f.EmbedFields) == 0 {
+				return &f, nil
+			}
+		}
+		return nil, fmt.Errorf("%s is not a column of the query", column)
+	}
+
+	var filter Filter
+	seen := map[string]bool{}
+	for _, c := range splitList(conditions) {
+		column, op, _ := strings.Cut(c, " ")
+		op = strings.ToLower(strings.TrimSpace(op))
+		f, err := field(column)
+		if err != nil {
+			return nil, fmt.Errorf("filter %q: %w", c, err)
+		}
+		suffix, ok := "", false
+		for _, o := range filterOperators {
+			if o.Op == op {
+				suffix, ok = o.Suffix, true
+			}
+		}
+		if !ok {
+			return nil, fmt.Errorf("filter %q: unknown operator %q", c, op)
+		}
+		method := "Where" + f.Name + suffix
+		if seen[method] {
+			return nil, fmt.Errorf("filter %q is listed twice", c)
+		}
+		seen[method] = true
+		filter.Conditions = append(filter.Conditions, FilterCondition{
+			Method: method,
+			SQL:    column + " " + strings.ToUpper(op),
+			Type:   filterValueType(req, options, f),
+		})
+	}
+	for _, column := range splitList(orderBy) {
+		f, err := field(column)
+		if err != nil {
+			return nil, fmt.Errorf("order_by: %w", err)
+		}
+		filter.OrderBy = append(filter.OrderBy, FilterOrder{Name: f.Name, Column: column})
+	}
+	return &filter, nil
+}
+
+// filterValueType returns the type of the values compared to a column, the
+// type of its non-null values
+func filterValueType(req *plugin.GenerateRequest, options *opts.Options, f *Field) string {
+	if f.Column == nil {
+		return f.Type
+	}
+	return nonNullType(req, options, f.Column)
+}
+
+// splitList returns the non-empty items of a comma-separated list
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func usesFilter(queries []Query) bool {
+	for _, q := range queries {
+		if q.Filter != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package golang
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sqlc-dev/plugin-sdk-go/metadata"
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+func TestQueryFilter(t *testing.T) {
+	req := &plugin.GenerateRequest{
+		Settings: &plugin.Settings{Engine: "postgresql"},
+		Catalog:  &plugin.Catalog{DefaultSchema: "public"},
+	}
+	options := &opts.Options{SqlPackage: "pgx/v5"}
+	ret := QueryValue{Struct: &Struct{Name: "Author", Fields: []Field{
+		{Name: "Name", DBName: "name", Type: "string", Column: &plugin.Column{Name: "name", Type: &plugin.Identifier{Name: "text"}, NotNull: true}},
+		{Name: "CreatedAt", DBName: "created_at", Type: "pgtype.Timestamptz", Column: &plugin.Column{Name: "created_at", Type: &plugin.Identifier{Name: "timestamptz"}}},
+	}}}
+	query := &plugin.Query{Cmd: metadata.CmdMany}
+
+	got, err := queryFilter(req, options, query, ret, map[string]string{
+		annotationFilter:  "name ILIKE, created_at >",
+		annotationOrderBy: "created_at, name",
+	})
+	if err != nil {
+		t.Fatalf("queryFilter() failed: %s", err)
+	}
+	want := &Filter{
+		Conditions: []FilterCondition{
+			{Method: "WhereNameILike", SQL: "name ILIKE", Type: "string"},
+			{Method: "WhereCreatedAtAfter", SQL: "created_at >", Type: "time.Time"},
+		},
+		OrderBy: []FilterOrder{{Name: "CreatedAt", Column: "created_at"}, {Name: "Name", Column: "name"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("queryFilter() mismatch (-want +got):\n%s", diff)
+	}
+
+	for _, annotations := range []map[string]string{
+		{annotationFilter: "bio like"},
+		{annotationFilter: "name ~"},
+		{annotationFilter: "name like, name like"},
+		{annotationOrderBy: "id"},
+	} {
+		if _, err := queryFilter(req, options, query, ret, annotations); err == nil {
+			t.Errorf("queryFilter(%v) succeeded", annotations)
+		}
+	}
+	for _, text := range []string{
+		"SELECT name, created_at FROM authors ORDER BY name LIMIT 10",
+		"SELECT name, created_at FROM authors OFFSET $1",
+	} {
+		limited := &plugin.Query{Cmd: metadata.CmdMany, Text: text}
+		if _, err := queryFilter(req, options, limited, ret, map[string]string{annotationFilter: "name ="}); err == nil {
+			t.Errorf("queryFilter() of %q succeeded", text)
+		}
+	}
+	one := &plugin.Query{Cmd: metadata.CmdOne}
+	if _, err := queryFilter(req, options, one, ret, map[string]string{annotationFilter: "name ="}); err == nil {
+		t.Errorf("queryFilter() of a :one query succeeded")
+	}
+}
@@ -0,0 +1,190 @@
+package golang
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sqlc-dev/plugin-sdk-go/sdk"
+)
+
+// fixtureValues are the sample values of the Go types of columns, %s is
+// replaced by a quoted string naming the field
+var fixtureValues = map[string]string{
+	"string":          "%s",
+	"bool":            "true",
+	"int":             "1",
+	"int8":            "1",
+	"int16":           "1",
+	"int32":           "1",
+	"int64":           "1",
+	"uint":            "1",
+	"uint8":           "1",
+	"uint16":          "1",
+	"uint32":          "1",
+	"uint64":          "1",
+	"float32":         "1.5",
+	"float64":         "1.5",
+	"[]byte":          "[]byte(%s)",
+	"[16]byte":        "[16]byte{6: 0x40, 8: 0x80, 15: 1}",
+	"time.Time":       "time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)",
+	"uuid.UUID":       `uuid.MustParse("00000000-0000-4000-8000-000000000001")`,
+	"json.RawMessage": "json.RawMessage(`{}`)",
+	"pgtype.Numeric":  "pgtype.Numeric{Int: big.NewInt(1), Valid: true}",
+}
+
+// fixtureStrings are the sample values of the database types that are read
+// into strings, which must be valid input for the column
+var fixtureStrings = map[string]string{
+	"numeric": "1.5",
+	"decimal": "1.5",
+	"money":   "1.50",
+	"json":    "{}",
+	"jsonb":   "{}",
+	"uuid":    "00000000-0000-4000-8000-000000000001",
+	"inet":    "127.0.0.1",
+	"cidr":    "127.0.0.0/8",
+	"date":    "2024-01-01",
+}
+
+// FixtureFile holds the factories emit_fixtures generates for the models and
+// the result structs of the queries
+type FixtureFile struct {
+	Fixtures []Fixture
+}
+
+// Fixture is the factory of a struct, whose fields are set to sample values.
+// Fields without a sample value, such as pointers, are left zero.
+type Fixture struct {
+	Name   string // e.g. "Author"
+	Type   string // e.g. "models.Author"
+	Fields []FixtureField
+}
+
+type FixtureField struct {
+	Name  string
+	Value string
+}
+
+// fixtureCandidate is a struct whose sample values are not resolved yet
+type fixtureCandidate struct {
+	Name   string
+	Type   string
+	Fields []Field
+}
+
+type fixtureBuilder struct {
+	enums   map[string]Enum
+	structs map[string]struct{}
+}
+
+// value returns a Go expression of type typ for a column of type dbType, or
+// false when the zero value is used
+func (b *fixtureBuilder) value(field, dbType, typ string) (string, bool) {
+	if v, ok := fixtureValues[typ]; ok {
+		if strings.Contains(v, "%s") {
+			sample, ok := fixtureStrings[dbType]
+			if !ok {
+				sample = toSnakeCase(field)
+			}
+			v = fmt.Sprintf(v, fmt.Sprintf("%q", sample))
+		}
+		return v, true
+	}
+	if elem, ok := strings.CutPrefix(typ, "[]"); ok {
+		v, ok := b.value(field, dbType, elem)
+		if !ok {
+			return "", false
+		}
+		return typ + "{" + v + "}", true
+	}
+	if strings.HasPrefix(typ, "*") || strings.HasPrefix(typ, "map[") {
+		return "", false
+	}
+
+	pkg, name := "", typ
+	if i := strings.LastIndex(typ, "."); i >= 0 {
+		pkg, name = typ[:i+1], typ[i+1:]
+	}
+	if e, ok := b.enums[name]; ok && len(e.Constants) > 0 {
+		return pkg + e.Constants[0].Name, true
+	}
+	if _, ok := b.structs[name]; ok {
+		return "New" + name + "Fixture()", true
+	}
+	if n, ok := findNullWrapper(b.enumNames(), typ); ok {
+		v, ok := b.value(field, dbType, n.ValueType)
+		if !ok {
+			return "", false
+		}
+		return fmt.Sprintf("%s{%s: %s, Valid: true}", typ, n.ValueField, v), true
+	}
+	return "", false
+}
+
+func (b *fixtureBuilder) enumNames() map[string]struct{} {
+	names := make(map[string]struct{}, len(b.enums))
+	for name := range b.enums {
+		names[name] = struct{}{}
+	}
+	return names
+}
+
+// buildFixtureFile returns the factories of the models and of the result
+// structs of the queries
+func buildFixtureFile(enums []Enum, structs []Struct, queries []Query) *FixtureFile {
+	b := &fixtureBuilder{enums: map[string]Enum{}, structs: map[string]struct{}{}}
+	for _, e := range enums {
+		b.enums[e.Name] = e
+	}
+	var candidates []fixtureCandidate
+	add := func(name, typ string, fields []Field) {
+		if _, ok := b.structs[name]; ok {
+			return
+		}
+		b.structs[name] = struct{}{}
+		candidates = append(candidates, fixtureCandidate{Name: name, Type: typ, Fields: fields})
+	}
+	for _, s := range structs {
+		add(s.Name, s.Type(), s.Fields)
+	}
+	for _, q := range queries {
+		if q.Ret.EmitStruct() && q.VariantOf == "" {
+			add(q.Ret.Type(), q.Ret.Type(), q.Ret.Struct.Fields)
+		}
+	}
+
+	// The values are resolved once every struct is known, as Row structs
+	// embed the models
+	file := &FixtureFile{}
+	for _, c := range candidates {
+		fixture := Fixture{Name: c.Name, Type: c.Type}
+		for _, f := range c.Fields {
+			dbType := ""
+			if f.Column != nil {
+				dbType = strings.TrimPrefix(strings.ToLower(sdk.DataType(f.Column.Type)), "pg_catalog.")
+			}
+			if v, ok := b.value(f.Name, dbType, f.Type); ok {
+				fixture.Fields = append(fixture.Fields, FixtureField{Name: f.Name, Value: v})
+			}
+		}
+		file.Fixtures = append(file.Fixtures, fixture)
+	}
+	return file
+}
+
+// usesPackage reports whether a struct type or a sample value refers to pkg
+func (f *FixtureFile) usesPackage(pkg string) bool {
+	re := regexp.MustCompile(`(^|[^\w.])` + regexp.QuoteMeta(pkg) + `\.`)
+	for _, fixture := range f.Fixtures {
+		if re.MatchString(fixture.Type) {
+			return true
+		}
+		for _, field := range fixture.Fields {
+			if re.MatchString(field.Value) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package golang
+
+import (
+	"testing"
+)
+
+func TestFixtureValue(t *testing.T) {
+	b := &fixtureBuilder{
+		enums:   map[string]Enum{"Status": {Name: "Status", Constants: []Constant{{Name: "StatusOpen", Value: "open"}}}},
+		structs: map[string]struct{}{"Book": {}},
+	}
+
+	for _, tc := range []struct {
+		field  string
+		dbType string
+		typ    string
+		want   string
+	}{
+		{"Name", "", "string", `"name"`},
+		{"AuthorID", "", "int64", "1"},
+		{"Bio", "", "pgtype.Text", `pgtype.Text{String: "bio", Valid: true}`},
+		{"Shipping", "", "sql.NullString", `sql.NullString{String: "shipping", Valid: true}`},
+		{"Status", "", "models.Status", "models.StatusOpen"},
+		{"Status", "", "NullStatus", "NullStatus{Status: StatusOpen, Valid: true}"},
+		{"Tags", "", "[]string", `[]string{"tags"}`},
+		{"Balance", "numeric", "sql.NullString", `sql.NullString{String: "1.5", Valid: true}`},
+		{"Book", "", "models.Book", "NewBookFixture()"},
+		{"Price", "", "*float64", ""},
+		{"Attrs", "", "pgtype.Hstore", ""},
+	} {
+		got, ok := b.value(tc.field, tc.dbType, tc.typ)
+		if got != tc.want || ok != (tc.want != "") {
+			t.Errorf("value(%q, %q, %q) = %q, %v, want %q", tc.field, tc.dbType, tc.typ, got, ok, tc.want)
+		}
+	}
+}
+
+func TestBuildFixtureFile(t *testing.T) {
+	f := buildFixtureFile(nil, []Struct{
+		{Name: "Book", Fields: []Field{{Name: "Title", Type: "string"}, {Name: "Embedding", Type: "*pgvector.Vector"}}},
+	}, nil)
+	if len(f.Fixtures) != 1 {
+		t.Fatalf("fixtures = %+v, want one", f.Fixtures)
+	}
+	if fields := f.Fixtures[0].Fields; len(fields) != 1 || fields[0] != (FixtureField{Name: "Title", Value: `"title"`}) {
+		t.Errorf("fields = %+v, want only Title", fields)
+	}
+	if f.usesPackage("pgvector") {
+		t.Error("zero fields must not import their packages")
+	}
+}
@@ -0,0 +1,194 @@
+package golang
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+// formatSource formats generated code with the configured formatter. On top
+// of gofmt, goimports groups the standard library imports before the others,
+// and gofumpt additionally applies the gofumpt rules the templates can break.
+func formatSource(options *opts.Options, src []byte) ([]byte, error) {
+	code, err := format.Source(src)
+	if err != nil {
+		return nil, err
+	}
+
+	switch options.Formatter {
+	case opts.FormatterGoimports:
+		return rewriteSource(code, importGroupEdits)
+	case opts.FormatterGofumpt:
+		return rewriteSource(code, importGroupEdits, gofumptEdits, declGroupEdits)
+	default:
+		return code, nil
+	}
+}
+
+// sourceEdit replaces the bytes between the offsets Start and End
+type sourceEdit struct {
+	Start, End int
+	Text       string
+}
+
+// rewriteSource applies the edits computed by each pass in turn, and formats
+// the result again
+func rewriteSource(code []byte, passes ...func(*token.FileSet, *ast.File, []byte) []sourceEdit) ([]byte, error) {
+	for _, pass := range passes {
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, "", code, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+
+		edits := pass(fset, file, code)
+		sort.Slice(edits, func(i, j int) bool { return edits[i].Start > edits[j].Start })
+		for _, e := range edits {
+			code = append(code[:e.Start:e.Start], append([]byte(e.Text), code[e.End:]...)...)
+		}
+
+		code, err = format.Source(code)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return code, nil
+}
+
+// importGroupEdits rewrites import blocks into a group of standard library
+// imports followed by a group of all other imports, each sorted by path
+func importGroupEdits(fset *token.FileSet, file *ast.File, code []byte) []sourceEdit {
+	var edits []sourceEdit
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT || !gen.Lparen.IsValid() || hasComments(fset, file, gen.Lparen, gen.Rparen) {
+			continue
+		}
+
+		var std, other []*ast.ImportSpec
+		for _, spec := range gen.Specs {
+			imp := spec.(*ast.ImportSpec)
+			path, _ := strconv.Unquote(imp.Path.Value)
+			if isStdImport(path) {
+				std = append(std, imp)
+			} else {
+				other = append(other, imp)
+			}
+		}
+
+		var b strings.Builder
+		b.WriteString("(\n")
+		for i, group := range [][]*ast.ImportSpec{std, other} {
+			if len(group) == 0 {
+				continue
+			}
+			if i > 0 && len(std) > 0 {
+				b.WriteString("\n")
+			}
+			sort.SliceStable(group, func(i, j int) bool { return group[i].Path.Value < group[j].Path.Value })
+			for _, imp := range group {
+				b.WriteString("\t")
+				b.Write(code[fset.Position(imp.Pos()).Offset:fset.Position(imp.End()).Offset])
+				b.WriteString("\n")
+			}
+		}
+		b.WriteString(")")
+
+		edits = append(edits, sourceEdit{
+			Start: fset.Position(gen.Lparen).Offset,
+			End:   fset.Position(gen.Rparen).Offset + 1,
+			Text:  b.String(),
+		})
+	}
+	return edits
+}
+
+// isStdImport reports whether path belongs to the standard library, whose
+// import paths have no dot in their first element
+func isStdImport(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
+
+// directiveComment matches comments that must not get a space after the
+// slashes, such as //go:build or //nolint:lll
+var directiveComment = regexp.MustCompile(`^//([a-z0-9]+:[a-z0-9]|line |export |extern |nolint)`)
+
+// gofumptEdits removes the blank lines gofumpt forbids at the start and end
+// of blocks, and adds the missing space after the slashes of line comments
+func gofumptEdits(fset *token.FileSet, file *ast.File, code []byte) []sourceEdit {
+	var edits []sourceEdit
+	removeBlankLines := func(from, to token.Pos) {
+		start, end := fset.Position(from).Offset, fset.Position(to).Offset
+		between := code[start:end]
+		if trimmed := bytes.TrimSpace(between); len(trimmed) == 0 && bytes.Count(between, []byte("\n")) > 1 {
+			edits = append(edits, sourceEdit{Start: start, End: end, Text: "\n"})
+		}
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.BlockStmt:
+			if len(n.List) == 0 {
+				return true
+			}
+			first, last := n.List[0].Pos(), n.List[len(n.List)-1].End()
+			for _, cg := range file.Comments {
+				if cg.Pos() > n.Lbrace && cg.End() < n.Rbrace {
+					first = min(first, cg.Pos())
+					last = max(last, cg.End())
+				}
+			}
+			removeBlankLines(n.Lbrace+1, first)
+			removeBlankLines(last, n.Rbrace)
+		}
+		return true
+	})
+
+	for _, cg := range file.Comments {
+		for _, c := range cg.List {
+			if len(c.Text) > 2 && strings.HasPrefix(c.Text, "//") && !strings.ContainsAny(c.Text[2:3], " \t/") && !directiveComment.MatchString(c.Text) {
+				offset := fset.Position(c.Pos()).Offset + 2
+				edits = append(edits, sourceEdit{Start: offset, End: offset, Text: " "})
+			}
+		}
+	}
+	return edits
+}
+
+// declGroupEdits unwraps the top-level declaration groups holding a single
+// spec, which gofumpt does not allow
+func declGroupEdits(fset *token.FileSet, file *ast.File, code []byte) []sourceEdit {
+	var edits []sourceEdit
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok == token.IMPORT || !gen.Lparen.IsValid() || len(gen.Specs) != 1 || hasComments(fset, file, gen.Lparen, gen.Rparen) {
+			continue
+		}
+		spec := gen.Specs[0]
+		edits = append(edits, sourceEdit{
+			Start: fset.Position(gen.Lparen).Offset,
+			End:   fset.Position(gen.Rparen).Offset + 1,
+			Text:  string(code[fset.Position(spec.Pos()).Offset:fset.Position(spec.End()).Offset]),
+		})
+	}
+	return edits
+}
+
+// hasComments reports whether a comment appears between from and to
+func hasComments(fset *token.FileSet, file *ast.File, from, to token.Pos) bool {
+	for _, cg := range file.Comments {
+		if cg.Pos() > from && cg.End() < to {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+package golang
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+func TestFormatSource(t *testing.T) {
+	src := `package db
+
+import (
+	"github.com/jackc/pgx/v5"
+	"context"
+)
+
+var (
+	_ = context.Background
+)
+
+func f(ctx context.Context) (pgx.Rows, error) {
+
+	//comment
+	return nil, nil
+
+}
+`
+	for _, test := range []struct {
+		formatter string
+		want      string
+	}{
+		{
+			formatter: "",
+			want: `package db
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	_ = context.Background
+)
+
+func f(ctx context.Context) (pgx.Rows, error) {
+
+	//comment
+	return nil, nil
+
+}
+`,
+		},
+		{
+			formatter: opts.FormatterGoimports,
+			want: `package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	_ = context.Background
+)
+
+func f(ctx context.Context) (pgx.Rows, error) {
+
+	//comment
+	return nil, nil
+
+}
+`,
+		},
+		{
+			formatter: opts.FormatterGofumpt,
+			want: `package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var _ = context.Background
+
+func f(ctx context.Context) (pgx.Rows, error) {
+	// comment
+	return nil, nil
+}
+`,
+		},
+	} {
+		got, err := formatSource(&opts.Options{Formatter: test.formatter}, []byte(src))
+		if err != nil {
+			t.Fatalf("formatSource() with %s failed: %s", test.formatter, err)
+		}
+		if diff := cmp.Diff(test.want, string(got)); diff != "" {
+			t.Errorf("formatSource() with %s mismatch (-want +got):\n%s", test.formatter, diff)
+		}
+	}
+}
+
+// TestFormatterDependencies pins the formatters to the standard library: the
+// rules of gofumpt are applied by formatSource rather than by linking
+// mvdan.cc/gofumpt and golang.org/x/tools into the WASM plugin.
+func TestFormatterDependencies(t *testing.T) {
+	if testing.Short() {
+		t.Skip("listing the dependencies of the plugin is slow")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("the go command is not available")
+	}
+	out, err := exec.Command("go", "list", "-deps", "github.com/sqlc-dev/sqlc-gen-go/plugin").CombinedOutput()
+	if err != nil {
+		t.Fatalf("go list failed: %s\n%s", err, out)
+	}
+	for _, dep := range strings.Fields(string(out)) {
+		if strings.HasPrefix(dep, "mvdan.cc/") || strings.HasPrefix(dep, "golang.org/x/tools/") {
+			t.Errorf("the plugin depends on %s", dep)
+		}
+	}
+}
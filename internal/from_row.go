@@ -0,0 +1,30 @@
+package golang
+
+// rowModels returns the models of the tables all of whose columns are fields
+// of a row struct, matched by name and type, which emit_model_from_row
+// generates constructors from the row for
+func rowModels(structs []Struct, row *Struct) []Struct {
+	fields := map[string]string{}
+	for _, f := range row.Fields {
+		if len(f.EmbedFields) == 0 {
+			fields[f.Name] = f.Type
+		}
+	}
+	var models []Struct
+	for _, s := range structs {
+		if s.Table == nil || len(s.Fields) == 0 {
+			continue
+		}
+		matches := true
+		for _, f := range s.Fields {
+			if typ, ok := fields[f.Name]; !ok || typ != f.Type {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			models = append(models, s)
+		}
+	}
+	return models
+}
@@ -0,0 +1,46 @@
+package golang
+
+import (
+	"testing"
+
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+)
+
+func TestRowModels(t *testing.T) {
+	author := Struct{
+		Table: &plugin.Identifier{Name: "authors"},
+		Name:  "Author",
+		Fields: []Field{
+			{Name: "ID", Type: "int64"},
+			{Name: "Name", Type: "string"},
+		},
+	}
+	book := Struct{
+		Table: &plugin.Identifier{Name: "books"},
+		Name:  "Book",
+		Fields: []Field{
+			{Name: "ID", Type: "int64"},
+			{Name: "Title", Type: "string"},
+		},
+	}
+	for _, tc := range []struct {
+		name   string
+		fields []Field
+		want   []string
+	}{
+		{"all columns", []Field{{Name: "ID", Type: "int64"}, {Name: "Name", Type: "string"}, {Name: "Count", Type: "int64"}}, []string{"Author"}},
+		{"missing column", []Field{{Name: "ID", Type: "int64"}}, nil},
+		{"type mismatch", []Field{{Name: "ID", Type: "int32"}, {Name: "Name", Type: "string"}}, nil},
+		{"embedded", []Field{{Name: "Author", Type: "Author", EmbedFields: author.Fields}}, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			for _, s := range rowModels([]Struct{author, book}, &Struct{Name: "Row", Fields: tc.fields}) {
+				got = append(got, s.Name)
+			}
+			if len(got) != len(tc.want) || (len(got) > 0 && got[0] != tc.want[0]) {
+				t.Errorf("rowModels() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -3,18 +3,21 @@ package golang
 import (
 	"bufio"
 	"bytes"
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
-	"go/format"
+	"maps"
 	"os"
-	"path/filepath"
+	"path"
+	"slices"
 	"strings"
 	"text/template"
 
 	"github.com/sqlc-dev/plugin-sdk-go/metadata"
 	"github.com/sqlc-dev/plugin-sdk-go/plugin"
 	"github.com/sqlc-dev/plugin-sdk-go/sdk"
+	"github.com/sqlc-dev/sqlc-gen-go/internal/debug"
 	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
 )
 
@@ -36,14 +39,52 @@ type tmplCtx struct {
 	JsonTagsIDUppercase       bool
 	EmitDBTags                bool
 	EmitPreparedQueries       bool
+	PrepareLazily             bool
+	EmitPreparedStatements    bool
 	EmitInterface             bool
+	EmitSplitQuerier          bool
+	UnexportedQueries         bool
+	ReceiverName              string
+	ValueReceivers            bool
 	EmitEmptySlices           bool
 	EmitMethodsWithDBArgument bool
 	EmitEnumValidMethod       bool
 	EmitAllEnumValues         bool
+	EmitEnumTextMethods       bool
+	StrictEnums               bool
+	EmitPoolConstructor       bool
+	EmitHealthCheck           bool
+	EmitParamsBuilders        bool
+	EmitRowEntityMethods      bool
+	ParamsBuilderMinFields    int
 	UsesCopyFrom              bool
+	CopyFromSQLite            bool
+	CopyFromChunkSize         int
+	EmitCopyFromChunking      bool
+	UsesCopyFromTimes         bool
+	CopyFromLocation          string
 	UsesBatch                 bool
+	UsesUpsert                bool
+	UsesKeyset                bool
+	UsesFilter                bool
+	EmitBatchResultSlices     bool
+	EmitBatchErrors           bool
+	UsesHstore                bool
+	UsesGenerics              bool
+	CompositeTypes            []string
+	NullConversions           []NullConversion
+	RangeHelpers              []RangeHelper
+	UsesOrbCodec              bool
+	Proto                     *ProtoFile
+	Fixtures                  *FixtureFile
+	IntegrationTest           *IntegrationTest
+	DocSources                []DocSource
+	DocNested                 []DocNested
+	VersionInfo               *VersionInfo
+	ExplainQueries            []Query
+	GrouperFunctions          []GrouperFunction
 	OmitSqlcVersion           bool
+	HeaderTemplate            bool
 	BuildTags                 string
 	OutputModelsPackage       string
 }
@@ -52,6 +93,114 @@ func (t *tmplCtx) OutputQuery(sourceName string) bool {
 	return t.SourceName == sourceName
 }
 
+// EmitRowEntities reports whether ToXxx methods returning the entities
+// embedded with sqlc.embed() should be generated for a result struct
+func (t *tmplCtx) EmitRowEntities(v QueryValue) bool {
+	return t.EmitRowEntityMethods && v.IsStruct() && len(v.EmbeddedFields()) > 0
+}
+
+// EmitParamsBuilder reports whether a fluent builder should be generated for
+// the given params struct.
+func (t *tmplCtx) EmitParamsBuilder(v QueryValue) bool {
+	if !t.EmitParamsBuilders || !v.IsStruct() {
+		return false
+	}
+	return len(v.UniqueFields()) >= t.ParamsBuilderMinFields
+}
+
+// querierInterface is an interface declared in the querier file
+type querierInterface struct {
+	Name    string
+	Comment string
+	Embeds  []string
+	Methods []string
+	Queries []Query
+}
+
+// QuerierInterfaces returns the interfaces of the querier file. With
+// emit_split_querier the queries are split between QuerierReader and
+// QuerierWriter by the handle they run on with read/write splitting, and
+// Querier embeds both.
+func (t *tmplCtx) QuerierInterfaces() []querierInterface {
+	if !t.EmitSplitQuerier {
+		return []querierInterface{{Name: "Querier", Methods: t.queriesMethods(), Queries: t.GoQueries}}
+	}
+	reader := querierInterface{
+		Name:    "QuerierReader",
+		Comment: "QuerierReader holds the queries reading data",
+	}
+	writer := querierInterface{
+		Name:    "QuerierWriter",
+		Comment: "QuerierWriter holds the queries changing data",
+	}
+	for _, q := range t.GoQueries {
+		if q.Route == routeReader {
+			reader.Queries = append(reader.Queries, q)
+		} else {
+			writer.Queries = append(writer.Queries, q)
+		}
+	}
+	return []querierInterface{reader, writer, {Name: "Querier", Embeds: []string{reader.Name, writer.Name}, Methods: t.queriesMethods()}}
+}
+
+// queriesMethods returns the methods of an unexported queries struct that are
+// not queries, which Querier declares so that they stay reachable
+func (t *tmplCtx) queriesMethods() []string {
+	if !t.UnexportedQueries {
+		return nil
+	}
+	var methods []string
+	if !t.EmitMethodsWithDBArgument {
+		if t.SQLDriver.IsPGX() {
+			methods = append(methods, "WithTx(tx pgx.Tx) Querier")
+		} else {
+			methods = append(methods, "WithTx(tx *sql.Tx) Querier")
+		}
+	}
+	if t.SQLDriver.IsPGX() && t.EmitHealthCheck {
+		methods = append(methods, "Healthy(ctx context.Context) error")
+	}
+	if !t.SQLDriver.IsPGX() && t.EmitPreparedQueries {
+		if t.PrepareLazily {
+			methods = append(methods, "Reprepare(ctx context.Context) error")
+		}
+		methods = append(methods, "Close() error")
+	}
+	return methods
+}
+
+// QueriesType returns the name of the queries struct, which is unexported
+// with emit_unexported_queries
+func (t *tmplCtx) QueriesType() string {
+	if t.UnexportedQueries {
+		return "queries"
+	}
+	return "Queries"
+}
+
+// QueriesReturnType returns the type returned by the constructors and WithTx.
+// An unexported queries struct is only reachable through the Querier
+// interface.
+func (t *tmplCtx) QueriesReturnType() string {
+	if t.UnexportedQueries {
+		return "Querier"
+	}
+	return "*Queries"
+}
+
+// Receiver returns the receiver of the methods of the queries struct, named
+// queries_receiver_name and a value with emit_value_receivers
+func (t *tmplCtx) Receiver() string {
+	if t.ValueReceivers {
+		return t.ReceiverName + " " + t.QueriesType()
+	}
+	return t.ReceiverName + " *" + t.QueriesType()
+}
+
+func (t *tmplCtx) codegenReceiverName() string {
+	return t.ReceiverName
+}
+
 func (t *tmplCtx) codegenDbarg() string {
 	if t.EmitMethodsWithDBArgument {
 		return "db DBTX, "
@@ -65,34 +214,92 @@ func (t *tmplCtx) codegenEmitPreparedQueries() bool {
 	return t.EmitPreparedQueries
 }
 
+// BatchResultSlices reports whether the batch method of q returns the results
+// of all the items rather than a BatchResults reading them with callbacks
+func (t *tmplCtx) BatchResultSlices(q Query) bool {
+	return t.EmitBatchResultSlices && (q.Cmd == metadata.CmdBatchMany || q.Cmd == metadata.CmdBatchOne)
+}
+
+// BatchResult returns the result type of the batch method of q
+func (t *tmplCtx) BatchResult(q Query) string {
+	switch {
+	case !t.BatchResultSlices(q):
+		return "*" + q.MethodName + "BatchResults"
+	case q.Cmd == metadata.CmdBatchMany:
+		return "([][]" + q.Ret.DefineType() + ", error)"
+	default:
+		return "([]" + q.Ret.DefineType() + ", error)"
+	}
+}
+
+// sqliteMaxVariables is the number of parameters a SQLite statement accepts
+// by default before SQLite 3.32
+const sqliteMaxVariables = 999
+
+// SQLiteCopyFromChunkSize returns the number of rows a multi-row INSERT of a
+// SQLite :copyfrom query inserts, which by default is as many as fit in the
+// parameters of a statement
+func (t *tmplCtx) SQLiteCopyFromChunkSize(q Query) int {
+	if t.CopyFromChunkSize > 0 {
+		return t.CopyFromChunkSize
+	}
+	return max(1, sqliteMaxVariables/len(q.Arg.ColumnNames()))
+}
+
+// codegenPreparedStmt returns the expression of the prepared statement a
+// query runs, nil for queries that opt out of preparation
+func (t *tmplCtx) codegenPreparedStmt(q Query) string {
+	switch {
+	case q.Unprepared:
+		return "nil"
+	case t.PrepareLazily:
+		return t.ReceiverName + ".stmt(ctx, " + q.ConstantName + ")"
+	default:
+		return t.ReceiverName + "." + q.FieldName
+	}
+}
+
+// codegenQuerySQL returns the expression of the SQL a pgx query method sends,
+// which selects the named prepared statement of the query when the Queries
+// run prepared statements
+func (t *tmplCtx) codegenQuerySQL(q Query) string {
+	if !t.EmitPreparedStatements || q.Unprepared {
+		return q.ConstantName
+	}
+	return fmt.Sprintf("%s.sql(%q, %s)", t.ReceiverName, q.ConstantName, q.ConstantName)
+}
+
 func (t *tmplCtx) codegenQueryMethod(q Query) string {
-	db := "q.db"
+	db := t.ReceiverName + ".db"
 	if t.EmitMethodsWithDBArgument {
 		db = "db"
 	}
 
-	switch q.Cmd {
-	case ":one":
+	switch {
+	case q.Cmd == ":one" || q.ReturningRowID:
 		if t.EmitPreparedQueries {
-			return "q.queryRow"
+			return t.ReceiverName + ".queryRow"
 		}
 		return db + ".QueryRowContext"
 
-	case ":many":
+	case q.Cmd == ":many":
 		if t.EmitPreparedQueries {
-			return "q.query"
+			return t.ReceiverName + ".query"
 		}
 		return db + ".QueryContext"
 
 	default:
 		if t.EmitPreparedQueries {
-			return "q.exec"
+			return t.ReceiverName + ".exec"
 		}
 		return db + ".ExecContext"
 	}
 }
 
 func (t *tmplCtx) codegenQueryRetval(q Query) (string, error) {
+	if q.ReturningRowID {
+		return "row :=", nil
+	}
 	switch q.Cmd {
 	case ":one":
 		return "row :=", nil
@@ -110,6 +317,8 @@ func (t *tmplCtx) codegenQueryRetval(q Query) (string, error) {
 }
 
 func Generate(ctx context.Context, req *plugin.GenerateRequest) (*plugin.GenerateResponse, error) {
+	phases := debug.NewPhases()
+	stop := phases.Track("parse options")
 	options, err := opts.Parse(req)
 	if err != nil {
 		return nil, err
@@ -118,13 +327,154 @@ func Generate(ctx context.Context, req *plugin.GenerateRequest) (*plugin.Generat
 	if err := opts.ValidateOpts(options); err != nil {
 		return nil, err
 	}
+	if _, err := resolveCompositeTypes(req, options); err != nil {
+		return nil, err
+	}
+	stop()
+	options.Phases = phases
+
+	if err := debug.Setup(options.DebugLog, options.DebugLogFormat); err != nil {
+		return nil, err
+	}
+
+	var prof *profiler
+	if options.EmitPprof {
+		prof = startProfiler()
+		defer prof.stopCPU()
+	}
+
+	// Nested configs may refer to queries by their SQL name, match them
+	// against the renamed method names
+	if options.Nested != nil {
+		for _, config := range options.Nested.Queries {
+			config.Query = QueryName(config.Query, options)
+		}
+	}
+
+	// Describing the options replaces generation, so that the resolved
+	// options can be inspected without touching the generated code
+	if options.DescribeOptions {
+		if err := populateNestedConfigWithDefaultValues(options); err != nil {
+			return nil, err
+		}
+		described, err := opts.Describe(options)
+		if err != nil {
+			return nil, err
+		}
+		return &plugin.GenerateResponse{Files: []*plugin.File{
+			{Name: "options.effective.json", Contents: append(described, '\n')},
+		}}, nil
+	}
+
+	// Incremental generation skips the query files whose inputs did not
+	// change since the run that wrote the manifest, read from the output
+	// directory or given in the options. sqlc does not let WASM plugins read
+	// files, they need the latter.
+	if options.Incremental {
+		if options.IncrementalManifest, err = readManifest(req, options); err != nil {
+			return nil, err
+		}
+	}
+	var inputs map[string]string
+	if options.IncrementalManifest != nil {
+		if inputs, err = queryFileInputs(req, options); err != nil {
+			return nil, err
+		}
+		options.SkipFiles = unchangedFiles(options.IncrementalManifest, inputs)
+	}
+
+	if options.MirrorQueryDirectories {
+		options.Packages = append(options.Packages, mirroredPackages(req, options)...)
+	}
+
+	var resp *plugin.GenerateResponse
+	if len(options.Packages) > 0 {
+		resp, err = generatePackages(req, options)
+	} else {
+		resp, err = generatePackage(req, options)
+	}
+	if err != nil {
+		return nil, err
+	}
 
-	enums := buildEnums(req, options)
+	if options.StdlibPackage != nil {
+		files, err := generateStdlibPackage(req, options)
+		if err != nil {
+			return nil, err
+		}
+		resp.Files = append(resp.Files, files...)
+	}
+
+	if options.EmitManifest {
+		manifest, err := buildManifest(req, options, resp.Files, options.IncrementalManifest, inputs)
+		if err != nil {
+			return nil, err
+		}
+		resp.Files = append(resp.Files, manifest)
+	}
+
+	if options.EmitOptionsSchema {
+		schema, err := opts.JSONSchema()
+		if err != nil {
+			return nil, err
+		}
+		name := "options.schema.json"
+		if options.OutputOptionsSchemaFileName != "" {
+			name = options.OutputOptionsSchemaFileName
+		}
+		resp.Files = append(resp.Files, &plugin.File{Name: name, Contents: append(schema, '\n')})
+	}
+
+	if prof != nil {
+		dir := "pprof"
+		if options.OutputPprofDirectory != "" {
+			dir = options.OutputPprofDirectory
+		}
+		profiles, err := prof.files(dir)
+		if err != nil {
+			return nil, err
+		}
+		resp.Files = append(resp.Files, profiles...)
+	}
+
+	slices.SortFunc(resp.Files, func(a, b *plugin.File) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	// A dry run only reports which files would be generated, sqlc writes
+	// every file of the response
+	if options.DryRun {
+		listing, err := buildDryRunListing(options, resp.Files)
+		if err != nil {
+			return nil, err
+		}
+		resp.Files = []*plugin.File{listing}
+	}
+
+	if options.ReportTimings {
+		phases.Report(os.Stderr)
+	}
+	if debug.Enabled() {
+		phases.Log()
+	}
+	return resp, nil
+}
+
+// generatePackage generates a single package holding all the queries of req
+func generatePackage(req *plugin.GenerateRequest, options *opts.Options) (*plugin.GenerateResponse, error) {
+	stop := options.Phases.Track("build structs and queries")
+	enums, err := buildEnums(req, options)
+	if err != nil {
+		return nil, err
+	}
 	structs := buildStructs(req, options)
 	queries, err := buildQueries(req, options, structs)
 	if err != nil {
 		return nil, err
 	}
+	stop()
+
+	stop = options.Phases.Track("build nested data")
 
 	// Populate nested config with default values to avoid checking it accross all the code
 	if err := populateNestedConfigWithDefaultValues(options); err != nil {
@@ -142,6 +492,7 @@ func Generate(ctx context.Context, req *plugin.GenerateRequest) (*plugin.Generat
 	if err != nil {
 		return nil, err
 	}
+	stop()
 
 	if options.OmitUnusedStructs {
 		enums, structs = filterUnusedStructs(options, enums, structs, queries)
@@ -155,6 +506,9 @@ func Generate(ctx context.Context, req *plugin.GenerateRequest) (*plugin.Generat
 }
 
 func validate(options *opts.Options, enums []Enum, structs []Struct, queries []Query) error {
+	if err := validateNameCollisions(options, enums, structs); err != nil {
+		return err
+	}
 	enumNames := make(map[string]struct{})
 	for _, enum := range enums {
 		enumNames[enum.Name] = struct{}{}
@@ -167,6 +521,25 @@ func validate(options *opts.Options, enums []Enum, structs []Struct, queries []Q
 		}
 		structNames[struckt.Name] = struct{}{}
 	}
+	if err := validateRedaction(structs); err != nil {
+		return err
+	}
+	for _, query := range queries {
+		for _, v := range []QueryValue{query.Arg, query.Ret} {
+			if v.Struct != nil {
+				if err := validateRedaction([]Struct{*v.Struct}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	constantNames := make(map[string]string)
+	for _, query := range queries {
+		if other, ok := constantNames[query.ConstantName]; ok {
+			return fmt.Errorf("queries %s and %s have the same constant name: %s", other, query.MethodName, query.ConstantName)
+		}
+		constantNames[query.ConstantName] = query.MethodName
+	}
 	if !options.EmitExportedQueries {
 		return nil
 	}
@@ -189,26 +562,56 @@ func generate(
 	queries []Query,
 	nested []Nested,
 ) (*plugin.GenerateResponse, error) {
+	mainEnums, mainStructs, schemaModels := splitSchemaPackages(options, enums, structs)
+	if err := validateSchemaPackages(options, mainEnums, mainStructs, schemaModels); err != nil {
+		return nil, err
+	}
+
 	i := &importer{
 		Options: options,
 		Queries: queries,
 		Enums:   enums,
 		Structs: structs,
 	}
+	for _, m := range schemaModels {
+		i.SchemaPackages = append(i.SchemaPackages, m.Package)
+	}
 
 	tctx := tmplCtx{
 		EmitInterface:             options.EmitInterface,
+		EmitSplitQuerier:          options.EmitSplitQuerier,
+		UnexportedQueries:         options.EmitUnexportedQueries,
+		ReceiverName:              cmp.Or(options.QueriesReceiverName, "q"),
+		ValueReceivers:            options.EmitValueReceivers,
 		EmitJSONTags:              options.EmitJsonTags,
 		JsonTagsIDUppercase:       options.JsonTagsIdUppercase,
 		EmitDBTags:                options.EmitDbTags,
 		EmitPreparedQueries:       options.EmitPreparedQueries,
+		PrepareLazily:             options.PrepareLazily,
+		EmitPreparedStatements:    options.EmitPreparedQueries && parseDriver(options.SqlPackage) == opts.SQLDriverPGXV5,
 		EmitEmptySlices:           options.EmitEmptySlices,
 		EmitMethodsWithDBArgument: options.EmitMethodsWithDbArgument,
 		EmitEnumValidMethod:       options.EmitEnumValidMethod,
 		EmitAllEnumValues:         options.EmitAllEnumValues,
+		EmitEnumTextMethods:       options.EmitEnumTextMethods,
+		StrictEnums:               options.StrictEnums,
+		EmitPoolConstructor:       options.EmitPoolConstructor,
+		EmitHealthCheck:           options.EmitHealthCheck,
+		EmitParamsBuilders:        options.EmitParamsBuilders,
+		EmitRowEntityMethods:      options.EmitRowEntityMethods,
+		ParamsBuilderMinFields:    int(*options.ParamsBuilderMinFields),
 		OutputModelsPackage:       options.OutputModelsPackage,
 		UsesCopyFrom:              usesCopyFrom(queries),
+		EmitCopyFromChunking:      options.EmitCopyfromChunking,
 		UsesBatch:                 usesBatch(queries),
+		UsesUpsert:                usesUpsert(queries),
+		UsesKeyset:                usesKeyset(queries),
+		UsesFilter:                usesFilter(queries),
+		EmitBatchResultSlices:     options.EmitBatchResultSlices,
+		EmitBatchErrors:           options.EmitBatchErrors,
+		UsesHstore:                usesHstore(options, structs, queries),
+		UsesGenerics:              true,
+		CompositeTypes:            compositeTypeNames(req, options),
 		SQLDriver:                 parseDriver(options.SqlPackage),
 		Q:                         "`",
 		Package:                   options.Package,
@@ -216,42 +619,168 @@ func generate(
 		Structs:                   structs,
 		Nested:                    nested,
 		SqlcVersion:               req.SqlcVersion,
+		HeaderTemplate:            options.HeaderTmpl != nil,
 		BuildTags:                 options.BuildTags,
 		OmitSqlcVersion:           options.OmitSqlcVersion,
 	}
 
 	if tctx.UsesCopyFrom && !tctx.SQLDriver.IsPGX() && options.SqlDriver != opts.SQLDriverGoSQLDriverMySQL {
-		return nil, errors.New(":copyfrom is only supported by pgx and github.com/go-sql-driver/mysql")
+		if req.Settings.Engine != "sqlite" {
+			return nil, errors.New(":copyfrom is only supported by pgx, github.com/go-sql-driver/mysql and sqlite")
+		}
+		tctx.CopyFromSQLite = true
+		tctx.CopyFromChunkSize = int(options.CopyfromChunkSize)
 	}
 
 	if tctx.UsesCopyFrom && options.SqlDriver == opts.SQLDriverGoSQLDriverMySQL {
-		if err := checkNoTimesForMySQLCopyFrom(queries); err != nil {
-			return nil, err
-		}
 		tctx.SQLDriver = opts.SQLDriverGoSQLDriverMySQL
+		tctx.UsesCopyFromTimes = usesCopyFromTimes(queries)
+		tctx.CopyFromLocation = "time.UTC"
+		if options.MysqlCopyfromLocation == opts.CopyfromLocationLocal {
+			tctx.CopyFromLocation = "time.Local"
+		}
 	}
 
 	if tctx.UsesBatch && !tctx.SQLDriver.IsPGX() {
 		return nil, errors.New(":batch* commands are only supported by pgx")
 	}
 
+	if usesBulk(queries) && !tctx.SQLDriver.IsPGX() {
+		return nil, errors.New("bulk annotations are only supported by pgx")
+	}
+
+	if tctx.UsesUpsert && !tctx.SQLDriver.IsPGX() {
+		return nil, errors.New("upsert annotations are only supported by pgx")
+	}
+
+	if (tctx.UsesKeyset || usesPaged(queries)) && !tctx.SQLDriver.IsPGX() {
+		return nil, errors.New("paginate annotations are only supported by pgx")
+	}
+
+	if usesDerived(queries) && !tctx.SQLDriver.IsPGX() {
+		return nil, errors.New("derive annotations are only supported by pgx")
+	}
+
+	if tctx.UsesFilter && !tctx.SQLDriver.IsPGX() {
+		return nil, errors.New("filter and order_by annotations are only supported by pgx")
+	}
+
+	if options.EmitTypedLastInsertId && req.Settings.GetEngine() != "mysql" {
+		return nil, errors.New("emit_typed_last_insert_id is only supported by mysql")
+	}
+
+	if options.EmitExplainMethods {
+		if req.Settings.GetEngine() != "postgresql" {
+			return nil, errors.New("emit_explain_methods is only supported by postgresql")
+		}
+		if err := validateExplain(queries); err != nil {
+			return nil, err
+		}
+		tctx.ExplainQueries = explainedQueries(queries)
+	}
+
+	for _, q := range queries {
+		if q.ConstantName == tctx.ReceiverName {
+			return nil, fmt.Errorf("queries_receiver_name %s is the name of the SQL constant of query %s", tctx.ReceiverName, q.MethodName)
+		}
+		for _, a := range q.Arg.Pairs() {
+			if a.Name == tctx.ReceiverName {
+				return nil, fmt.Errorf("queries_receiver_name %s is the name of a parameter of query %s", tctx.ReceiverName, q.MethodName)
+			}
+		}
+	}
+
+	// The methods of these features are not declared by Querier, which is the
+	// only way to reach an unexported queries struct
+	if options.EmitUnexportedQueries {
+		switch {
+		case len(retriedQueries(queries)) > 0 || len(cachedQueries(queries)) > 0:
+			return nil, errors.New("retry and cache annotations cannot be used with emit_unexported_queries")
+		case usesBulk(queries) || tctx.UsesUpsert || tctx.UsesKeyset || usesPaged(queries) || usesDerived(queries) || tctx.UsesFilter:
+			return nil, errors.New("bulk, upsert, paginate, derive and filter annotations cannot be used with emit_unexported_queries")
+		case options.EmitCopyfromChunking:
+			return nil, errors.New("emit_copyfrom_chunking cannot be used with emit_unexported_queries")
+		}
+	}
+
+	if options.EmitNullConversions {
+		if tctx.SQLDriver != opts.SQLDriverPGXV5 {
+			return nil, errors.New("emit_null_conversions is only supported by pgx/v5")
+		}
+		tctx.NullConversions = buildNullConversions(structs, queries)
+	}
+
+	if options.EmitRangeHelpers {
+		if tctx.SQLDriver != opts.SQLDriverPGXV5 {
+			return nil, errors.New("emit_range_helpers is only supported by pgx/v5")
+		}
+		tctx.RangeHelpers = buildRangeHelpers(structs, queries)
+	}
+
+	if options.GeometryType == opts.GeometryTypeOrb && tctx.SQLDriver == opts.SQLDriverPGXV5 {
+		_, tctx.UsesOrbCodec = usedTypes(structs, queries)["orb.Geometry"]
+	}
+
+	if options.EmitProto {
+		if tctx.SQLDriver == opts.SQLDriverPGXV4 {
+			return nil, errors.New("emit_proto is not supported by pgx/v4")
+		}
+		proto, err := buildProtoFile(options, enums, structs, queries, nested)
+		if err != nil {
+			return nil, err
+		}
+		tctx.Proto = proto
+		i.Proto = proto
+	}
+
+	if options.EmitFixtures {
+		if tctx.SQLDriver == opts.SQLDriverPGXV4 {
+			return nil, errors.New("emit_fixtures is not supported by pgx/v4")
+		}
+		tctx.Fixtures = buildFixtureFile(enums, structs, queries)
+		i.Fixtures = tctx.Fixtures
+	}
+
+	if options.EmitIntegrationTests {
+		test, err := buildIntegrationTest(req, options, queries)
+		if err != nil {
+			return nil, err
+		}
+		tctx.IntegrationTest = test
+		i.IntegrationTest = test
+	}
+
+	if options.EmitDocFile {
+		tctx.DocSources = buildDocSources(queries)
+		tctx.DocNested = buildDocNested(nested)
+	}
+
+	if options.EmitGrouperInterface {
+		tctx.GrouperFunctions = buildGrouperFunctions(nested)
+	}
+
+	if options.EmitVersionFile {
+		tctx.VersionInfo = buildVersionInfo(options, req.SqlcVersion)
+	}
+
 	var tmpl *template.Template
 	funcMap := template.FuncMap{
-		"lowerTitle": sdk.LowerTitle,
-		"upperTitle": upperTitle,
-		"comment":    sdk.DoubleSlashComment,
-		"escape":     sdk.EscapeBacktick,
-		"imports":    i.Imports,
-		"hasImports": i.HasImports,
-		"hasPrefix":  strings.HasPrefix,
-		"trimPrefix": strings.TrimPrefix,
-		"camelCase":  ToCamelCase,
-		"ternary":    ternary,
-		"joinTags":   joinTags,
-		"list":       list,
-		"add":        add,
-		"dict":       dict,
-		"set":        set,
+		"lowerTitle":  sdk.LowerTitle,
+		"upperTitle":  upperTitle,
+		"comment":     sdk.DoubleSlashComment,
+		"escape":      sdk.EscapeBacktick,
+		"imports":     i.Imports,
+		"hasImports":  i.HasImports,
+		"hasPrefix":   strings.HasPrefix,
+		"trimPrefix":  strings.TrimPrefix,
+		"trimPackage": trimPackage,
+		"camelCase":   ToCamelCase,
+		"ternary":     ternary,
+		"joinTags":    joinTags,
+		"list":        list,
+		"add":         add,
+		"dict":        dict,
+		"set":         set,
 		"render": func(name string, data any) string {
 			var buf bytes.Buffer
 			if err := tmpl.ExecuteTemplate(&buf, name, data); err != nil {
@@ -259,77 +788,152 @@ func generate(
 			}
 			return buf.String()
 		},
+		"declFields": func(fields []Field) []Field {
+			return orderFields(options.StructFieldOrder, fields)
+		},
+		"emitLogValue": func() bool {
+			return goVersionAtLeast(options, slogGoVersion)
+		},
+		"usesGenerics": func() bool {
+			return tctx.UsesGenerics
+		},
+		"generatedHeader": func(withSource bool) (string, error) {
+			data := HeaderData{
+				Year:        options.FileHeaderYear,
+				Package:     tctx.Package,
+				FileName:    tctx.FileName,
+				SqlcVersion: tctx.SqlcVersion,
+			}
+			if withSource {
+				data.SourceName = tctx.SourceName
+			}
+			return renderHeader(options.HeaderTmpl, data)
+		},
 
 		// Nullable type helpers for embed fields
 		"getNullableType":       getNullableType,
 		"getNullableValueField": getNullableValueField,
+		"getEmbedScanType":      getEmbedScanType,
+		"getEmbedValidCheck":    getEmbedValidCheck,
+		"getEmbedValue":         getEmbedValue,
+		"getEmbedNotNullCheck":  getEmbedNotNullCheck,
 
 		// These methods are Go specific, they do not belong in the codegen package
 		// (as that is language independent)
 		"dbarg":               tctx.codegenDbarg,
 		"emitPreparedQueries": tctx.codegenEmitPreparedQueries,
+		"preparedStmt":        tctx.codegenPreparedStmt,
+		"querySQL":            tctx.codegenQuerySQL,
 		"queryMethod":         tctx.codegenQueryMethod,
 		"queryRetval":         tctx.codegenQueryRetval,
+		"queriesType":         tctx.QueriesType,
+		"queriesReturnType":   tctx.QueriesReturnType,
+		"receiver":            tctx.Receiver,
+		"recv":                tctx.codegenReceiverName,
 	}
 
+	stop := options.Phases.Track("parse templates")
 	tmpl = template.Must(
 		template.New("table").
 			Funcs(funcMap).
-			ParseFS(
-				templates,
-				"templates/*.tmpl",
-				"templates/*/*.tmpl",
-			),
+			ParseFS(templates, templateFiles(tctx.SQLDriver, templateFeatures{
+				Interface:  options.EmitInterface,
+				CopyFrom:   tctx.UsesCopyFrom,
+				Batch:      tctx.UsesBatch,
+				ReadWrite:  options.EmitReadWriteSplit,
+				Background: options.EmitContextLessMethods,
+				Explain:    len(tctx.ExplainQueries) > 0,
+				Retry:      len(retriedQueries(queries)) > 0,
+				Nested:     len(nested) > 0,
+			})...),
 	)
+	stop()
 
 	output := map[string]string{}
 
+	if options.DumpNestedData {
+		dump, err := buildNestedDump(&tctx, queries)
+		if err != nil {
+			return nil, err
+		}
+		dumpFileName := "nested_data.json"
+		if options.OutputNestedDataFileName != "" {
+			dumpFileName = options.OutputNestedDataFileName
+		}
+		output[dumpFileName] = string(dump)
+	}
+
 	execute := func(fileName, packageName, templateName string) error {
-		imports := i.Imports(fileName)
-		replacedQueries := replaceConflictedArg(imports, queries)
+		// Query and nested files only render the queries of their SQL file
+		fileQueries := queries
+		switch templateName {
+		case "queryFile":
+			fileQueries = i.sourceQueries(fileName)
+		case "nestedCoreFile":
+			fileQueries = i.sourceQueries(extractSqlFileNameFromNestedFileName(options, fileName))
+		}
+		replacedQueries := replaceConflictedArg(i.Imports(fileName), fileQueries)
 
 		var b bytes.Buffer
 		w := bufio.NewWriter(&b)
 		tctx.FileName = fileName
 		tctx.SourceName = fileName
 		if templateName == "nestedCoreFile" {
-			tctx.SourceName = extractSqlFileNameFromNestedFileName(fileName)
+			tctx.SourceName = extractSqlFileNameFromNestedFileName(options, fileName)
 		}
 
 		tctx.GoQueries = replacedQueries
 		tctx.Package = packageName
+		tctx.BuildTags = buildTagsFor(options, templateName)
+		if templateName == "nestedCoreFile" || templateName == "nestedUtilsFile" {
+			tctx.BuildTags = goVersionConstraint(options, tctx.BuildTags, genericsGoVersion, tctx.UsesGenerics)
+		}
 
+		debug.Templates.Printf("rendering %s with template %s", fileName, templateName)
+		stop := options.Phases.Track("execute templates")
 		err := tmpl.ExecuteTemplate(w, templateName, &tctx)
 		w.Flush()
+		stop()
 		if err != nil {
 			return err
 		}
-		code, err := format.Source(b.Bytes())
+		stop = options.Phases.Track("format")
+		code, err := formatSource(options, b.Bytes())
+		stop()
 		if err != nil {
 			// Write debug info to stderr instead of stdout to avoid corrupting protobuf
 			fmt.Fprintf(os.Stderr, "Source formatting error for %s:\n%s\n", fileName, b.String())
 			return fmt.Errorf("source error: %w", err)
 		}
 
-		if templateName == "queryFile" || templateName == "nestedUtilsFile" {
-			if options.OutputQueryFilesDirectory != "" {
-				fileName = filepath.Join(options.OutputQueryFilesDirectory, fileName)
-			}
-		}
-
 		if templateName == "queryFile" {
-			if options.OutputFilesSuffix != "" {
-				fileName += options.OutputFilesSuffix
-			}
+			fileName = queryFileName(options, fileName)
 		}
 
 		if templateName == "nestedUtilsFile" {
+			if options.OutputQueryFilesDirectory != "" {
+				fileName = path.Join(options.OutputQueryFilesDirectory, fileName)
+			}
 			fileName = strings.TrimSuffix(fileName, ".go") + options.OutputFilesSuffix
 		}
 
 		if !strings.HasSuffix(fileName, ".go") {
 			fileName += ".go"
 		}
+
+		if options.FileHeaderTmpl != nil {
+			header, err := renderHeader(options.FileHeaderTmpl, HeaderData{
+				Year:        options.FileHeaderYear,
+				Package:     packageName,
+				FileName:    fileName,
+				SourceName:  tctx.SourceName,
+				SqlcVersion: tctx.SqlcVersion,
+			})
+			if err != nil {
+				return fmt.Errorf("file_header: %w", err)
+			}
+			code = insertFileHeader(code, header)
+		}
 		output[fileName] = string(code)
 		return nil
 	}
@@ -361,6 +965,80 @@ func generate(
 		nestedUtilsFileName = options.OutputNestedUtilsFileName
 	}
 
+	grouperFileName := "grouper.go"
+	if options.OutputGrouperFileName != "" {
+		grouperFileName = options.OutputGrouperFileName
+	}
+
+	nullConvFileName := "nullconv.go"
+	if options.OutputNullConvFileName != "" {
+		nullConvFileName = options.OutputNullConvFileName
+	}
+
+	rangesFileName := "ranges.go"
+	if options.OutputRangesFileName != "" {
+		rangesFileName = options.OutputRangesFileName
+	}
+
+	postgisFileName := "postgis.go"
+	if options.OutputPostgisFileName != "" {
+		postgisFileName = options.OutputPostgisFileName
+	}
+
+	docFileName := "doc.go"
+	if options.OutputDocFileName != "" {
+		docFileName = options.OutputDocFileName
+	}
+	versionFileName := "version.go"
+	if options.OutputVersionFileName != "" {
+		versionFileName = options.OutputVersionFileName
+	}
+
+	readWriteFileName := "readwrite.go"
+	if options.OutputReadWriteFileName != "" {
+		readWriteFileName = options.OutputReadWriteFileName
+	}
+
+	backgroundFileName := "background.go"
+	if options.OutputBackgroundFileName != "" {
+		backgroundFileName = options.OutputBackgroundFileName
+	}
+
+	explainFileName := "explain.go"
+	if options.OutputExplainFileName != "" {
+		explainFileName = options.OutputExplainFileName
+	}
+
+	retryFileName := "retry.go"
+	if options.OutputRetryFileName != "" {
+		retryFileName = options.OutputRetryFileName
+	}
+
+	cacheFileName := "cache.go"
+	if options.OutputCacheFileName != "" {
+		cacheFileName = options.OutputCacheFileName
+	}
+
+	protoGoFileName := "proto.go"
+	if options.OutputProtoGoFileName != "" {
+		protoGoFileName = options.OutputProtoGoFileName
+	}
+
+	graphqlGoFileName := "graphql.go"
+	if options.OutputGraphqlGoFileName != "" {
+		graphqlGoFileName = options.OutputGraphqlGoFileName
+	}
+
+	fixturesFileName := "fixtures.go"
+	if options.OutputFixturesFileName != "" {
+		fixturesFileName = options.OutputFixturesFileName
+	}
+
+	integrationFileName := "queries_integration_test.go"
+	if options.OutputIntegrationFileName != "" {
+		integrationFileName = options.OutputIntegrationFileName
+	}
+
 	modelsPackageName := options.Package
 	if options.OutputModelsPackage != "" {
 		modelsPackageName = options.OutputModelsPackage
@@ -369,9 +1047,35 @@ func generate(
 	if err := execute(dbFileName, options.Package, "dbFile"); err != nil {
 		return nil, err
 	}
+	// Models of schema_packages are generated into their own packages, the
+	// models file only holds those of the default schema
+	tctx.Enums, tctx.Structs = mainEnums, mainStructs
+	i.setModels(mainEnums, mainStructs, "")
+	// With output_enums_file_name the enums of the default schema get a file
+	// of their own, next to the models
+	if options.OutputEnumsFileName != "" {
+		if len(mainEnums) > 0 {
+			tctx.Structs = nil
+			i.setModels(mainEnums, nil, "")
+			if err := execute(options.OutputEnumsFileName, modelsPackageName, "enumsFile"); err != nil {
+				return nil, err
+			}
+		}
+		tctx.Enums, tctx.Structs = nil, mainStructs
+		i.setModels(nil, mainStructs, "")
+	}
 	if err := execute(modelsFileName, modelsPackageName, "modelsFile"); err != nil {
 		return nil, err
 	}
+	for _, m := range schemaModels {
+		tctx.Enums, tctx.Structs = m.Enums, m.Structs
+		i.setModels(m.Enums, m.Structs, m.Package)
+		if err := execute(schemaModelsFileName(m.Package), m.Package, "modelsFile"); err != nil {
+			return nil, err
+		}
+	}
+	tctx.Enums, tctx.Structs = enums, structs
+	i.setModels(enums, structs, "")
 	if options.EmitInterface {
 		if err := execute(querierFileName, options.Package, "interfaceFile"); err != nil {
 			return nil, err
@@ -387,13 +1091,103 @@ func generate(
 			return nil, err
 		}
 	}
+	if len(tctx.NullConversions) > 0 {
+		if err := execute(nullConvFileName, options.Package, "nullconvFile"); err != nil {
+			return nil, err
+		}
+	}
+	if len(tctx.RangeHelpers) > 0 {
+		if err := execute(rangesFileName, options.Package, "rangesFile"); err != nil {
+			return nil, err
+		}
+	}
+	if tctx.UsesOrbCodec {
+		if err := execute(postgisFileName, options.Package, "postgisFile"); err != nil {
+			return nil, err
+		}
+	}
+	if options.EmitDocFile {
+		if err := execute(docFileName, options.Package, "docFile"); err != nil {
+			return nil, err
+		}
+	}
+	if tctx.VersionInfo != nil {
+		if err := execute(versionFileName, options.Package, "versionFile"); err != nil {
+			return nil, err
+		}
+	}
+	if options.EmitReadWriteSplit {
+		if err := execute(readWriteFileName, options.Package, "readWriteFile"); err != nil {
+			return nil, err
+		}
+	}
+	if options.EmitContextLessMethods {
+		if err := execute(backgroundFileName, options.Package, "backgroundFile"); err != nil {
+			return nil, err
+		}
+	}
+	if len(tctx.ExplainQueries) > 0 {
+		if err := execute(explainFileName, options.Package, "explainFile"); err != nil {
+			return nil, err
+		}
+	}
+	// The retry decorator is only generated once a query opts in
+	if len(retriedQueries(queries)) > 0 {
+		if err := execute(retryFileName, options.Package, "retryFile"); err != nil {
+			return nil, err
+		}
+	}
+	// The caching decorator is only generated once a query opts in
+	if len(cachedQueries(queries)) > 0 {
+		if err := execute(cacheFileName, options.Package, "cacheFile"); err != nil {
+			return nil, err
+		}
+	}
+
+	if tctx.Proto != nil {
+		if err := execute(protoGoFileName, options.Package, "protoFile"); err != nil {
+			return nil, err
+		}
+		protoFileName := "models.proto"
+		if options.OutputProtoFileName != "" {
+			protoFileName = options.OutputProtoFileName
+		}
+		output[protoFileName] = string(tctx.Proto.Contents())
+	}
+
+	if tctx.Fixtures != nil {
+		if err := execute(fixturesFileName, options.Package, "fixturesFile"); err != nil {
+			return nil, err
+		}
+	}
+
+	if tctx.IntegrationTest != nil {
+		if err := execute(integrationFileName, options.Package, "integrationTestFile"); err != nil {
+			return nil, err
+		}
+	}
+
+	// The schema only has the types of the grouped results of nested queries
+	if options.EmitGraphql && len(graphqlQueries(queries)) > 0 {
+		if err := execute(graphqlGoFileName, options.Package, "graphqlFile"); err != nil {
+			return nil, err
+		}
+		graphqlFileName := "schema.graphql"
+		if options.OutputGraphqlFileName != "" {
+			graphqlFileName = options.OutputGraphqlFileName
+		}
+		output[graphqlFileName] = string(buildGraphQLSchema(enums, structs, queries, nested).Contents())
+	}
 
 	files := map[string]struct{}{}
 	for _, gq := range queries {
 		files[gq.SourceName] = struct{}{}
 	}
 
-	for source := range files {
+	for _, source := range slices.Sorted(maps.Keys(files)) {
+		if _, ok := options.SkipFiles[queryFileName(options, source)]; ok {
+			continue
+		}
 		if err := execute(source, options.Package, "queryFile"); err != nil {
 			return nil, err
 		}
@@ -404,23 +1198,76 @@ func generate(
 		// Generate _nested.sql files
 		for _, nestedItem := range nested {
 			nestedFileName := getNestedFileName(options, nestedItem.SourceFileName)
+			if _, ok := options.SkipFiles[nestedFileName]; ok {
+				continue
+			}
 			if err := execute(nestedFileName, options.Package, "nestedCoreFile"); err != nil {
 				return nil, err
 			}
+			// Releases older than genericsGoVersion build a copy of the
+			// grouping code without type parameters, which does not need
+			// the helpers of nested.gen
+			if !goVersionAtLeast(options, genericsGoVersion) {
+				tctx.UsesGenerics = false
+				err := execute(compatNestedFileName(nestedFileName), options.Package, "nestedCoreFile")
+				tctx.UsesGenerics = true
+				if err != nil {
+					return nil, err
+				}
+			}
 		}
 
 		// Generate nested.gen if any nested files were generated
 		if err := execute(nestedUtilsFileName, options.Package, "nestedUtilsFile"); err != nil {
 			return nil, err
 		}
+
+		if len(tctx.GrouperFunctions) > 0 {
+			if err := execute(grouperFileName, options.Package, "grouperFile"); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if options.EmitJsonSchema {
+		schema, err := buildJSONSchema(enums, structs, queries, nested)
+		if err != nil {
+			return nil, err
+		}
+		jsonSchemaFileName := "models.schema.json"
+		if options.OutputJsonSchemaFileName != "" {
+			jsonSchemaFileName = options.OutputJsonSchemaFileName
+		}
+		output[jsonSchemaFileName] = string(schema)
+	}
+
+	if len(options.OpenapiSchemas) > 0 {
+		doc, err := buildOpenAPI(options.Package, options.OpenapiSchemas, enums, structs, queries, nested)
+		if err != nil {
+			return nil, err
+		}
+		openAPIFileName := "openapi.json"
+		if options.OutputOpenapiFileName != "" {
+			openAPIFileName = options.OutputOpenapiFileName
+		}
+		output[openAPIFileName] = string(doc)
+	}
+
+	if options.EmitTypescript {
+		typescriptFileName := "models.ts"
+		if options.OutputTypescriptFileName != "" {
+			typescriptFileName = options.OutputTypescriptFileName
+		}
+		output[typescriptFileName] = string(buildTypeScriptFile(enums, structs, queries, nested).Contents())
 	}
 
 	resp := plugin.GenerateResponse{}
 
-	for filename, code := range output {
+	// Files are sorted so that repeated runs produce identical responses
+	for _, filename := range slices.Sorted(maps.Keys(output)) {
 		resp.Files = append(resp.Files, &plugin.File{
 			Name:     filename,
-			Contents: []byte(code),
+			Contents: []byte(output[filename]),
 		})
 	}
 
@@ -476,8 +1323,22 @@ func getNestedSourceWithConfigs(options *opts.Options, queries []Query, structs
 
 var nestedFileNameSuffix = "_nested.sql"
 
+// queryFileName returns the name of the file holding the queries of the SQL
+// file sourceName. Like all generated file names it is slash separated.
+func queryFileName(options *opts.Options, sourceName string) string {
+	fileName := opts.SlashPath(sourceName)
+	if options.OutputQueryFilesDirectory != "" {
+		fileName = path.Join(options.OutputQueryFilesDirectory, fileName)
+	}
+	fileName += options.OutputFilesSuffix
+	if !strings.HasSuffix(fileName, ".go") {
+		fileName += ".go"
+	}
+	return fileName
+}
+
 func getNestedFileName(options *opts.Options, fileName string) string {
-	baseFileName := strings.TrimSuffix(fileName, ".sql")
+	baseFileName := strings.TrimSuffix(opts.SlashPath(fileName), ".sql")
 	nestedFileName := baseFileName + nestedFileNameSuffix + ".go"
 	if options.OutputFilesSuffix != "" {
 		nestedFileName = baseFileName + nestedFileNameSuffix + options.OutputFilesSuffix + ".go"
@@ -485,30 +1346,51 @@ func getNestedFileName(options *opts.Options, fileName string) string {
 
 	// Apply output_query_files_directory logic like query files
 	if options.OutputQueryFilesDirectory != "" {
-		nestedFileName = filepath.Join(options.OutputQueryFilesDirectory, nestedFileName)
+		nestedFileName = path.Join(options.OutputQueryFilesDirectory, nestedFileName)
 	}
 
 	return nestedFileName
 }
 
+// compatNestedFileName returns the name of the copy of a nested file built by
+// the Go releases older than genericsGoVersion
+func compatNestedFileName(nestedFileName string) string {
+	return strings.TrimSuffix(nestedFileName, ".go") + "_compat.go"
+}
+
 func isNestedFileName(fileName string) bool {
 	return strings.Contains(fileName, nestedFileNameSuffix) && strings.HasSuffix(fileName, ".go")
 }
 
-func extractSqlFileNameFromNestedFileName(fileName string) string {
+func extractSqlFileNameFromNestedFileName(options *opts.Options, fileName string) string {
+	fileName = opts.SlashPath(fileName)
+
+	// Keep the directory of mirrored query files, relative to the query output
+	// directory
+	dir := ""
+	if options.MirrorQueryDirectories {
+		rel := fileName
+		if options.OutputQueryFilesDirectory != "" && options.OutputQueryFilesDirectory != "." {
+			rel = strings.TrimPrefix(fileName, options.OutputQueryFilesDirectory+"/")
+		}
+		if d := path.Dir(rel); d != "." {
+			dir = d
+		}
+	}
+
 	// Remove directory path if present and .go extension
-	baseName := strings.TrimSuffix(filepath.Base(fileName), ".go")
+	baseName := strings.TrimSuffix(path.Base(fileName), ".go")
 
 	// Remove output files suffix if present
 	nestedIndex := strings.Index(baseName, nestedFileNameSuffix)
 	if nestedIndex != -1 {
 		// Extract everything before "_nested.sql"
 		sourceBase := baseName[:nestedIndex]
-		return sourceBase + ".sql"
+		return path.Join(dir, sourceBase+".sql")
 	}
 
 	// Fallback: if pattern doesn't match expected format, return as-is with .sql
-	return strings.TrimSuffix(baseName, nestedFileNameSuffix) + ".sql"
+	return path.Join(dir, strings.TrimSuffix(baseName, nestedFileNameSuffix)+".sql")
 }
 
 func usesCopyFrom(queries []Query) bool {
@@ -531,18 +1413,35 @@ func usesBatch(queries []Query) bool {
 	return false
 }
 
-func checkNoTimesForMySQLCopyFrom(queries []Query) error {
+func usesBulk(queries []Query) bool {
+	for _, q := range queries {
+		if q.BulkSQL != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// copyFromTimeTypes are the types of the MySQL :copyfrom values that are
+// formatted as DATETIME in the location of the session for LOAD DATA
+var copyFromTimeTypes = map[string]struct{}{
+	"time.Time":    {},
+	"*time.Time":   {},
+	"sql.NullTime": {},
+}
+
+func usesCopyFromTimes(queries []Query) bool {
 	for _, q := range queries {
 		if q.Cmd != metadata.CmdCopyFrom {
 			continue
 		}
 		for _, f := range q.Arg.CopyFromMySQLFields() {
-			if f.Type == "time.Time" {
-				return fmt.Errorf("values with a timezone are not yet supported")
+			if _, ok := copyFromTimeTypes[f.Type]; ok {
+				return true
 			}
 		}
 	}
-	return nil
+	return false
 }
 
 func filterUnusedStructs(options *opts.Options, enums []Enum, structs []Struct, queries []Query) ([]Enum, []Struct) {
@@ -553,7 +1452,7 @@ func filterUnusedStructs(options *opts.Options, enums []Enum, structs []Struct,
 			keepTypes[query.Arg.Type()] = struct{}{}
 			if query.Arg.IsStruct() {
 				for _, field := range query.Arg.Struct.Fields {
-					keepTypes[field.Type] = struct{}{}
+					keepTypes[trimSliceAndPointerPrefix(field.Type)] = struct{}{}
 				}
 			}
 		}
@@ -561,9 +1460,9 @@ func filterUnusedStructs(options *opts.Options, enums []Enum, structs []Struct,
 			keepTypes[query.Ret.Type()] = struct{}{}
 			if query.Ret.IsStruct() {
 				for _, field := range query.Ret.Struct.Fields {
-					keepTypes[field.Type] = struct{}{}
+					keepTypes[trimSliceAndPointerPrefix(field.Type)] = struct{}{}
 					for _, embedField := range field.EmbedFields {
-						keepTypes[embedField.Type] = struct{}{}
+						keepTypes[trimSliceAndPointerPrefix(embedField.Type)] = struct{}{}
 					}
 				}
 			}
@@ -572,15 +1471,20 @@ func filterUnusedStructs(options *opts.Options, enums []Enum, structs []Struct,
 
 	keepEnums := make([]Enum, 0, len(enums))
 	for _, enum := range enums {
-		var enumType string
-		if options.ModelsPackageImportPath != "" {
+		var enumType, nullType string
+		if enum.Package != "" {
+			enumType = enum.Package + "." + enum.Name
+			nullType = enum.Package + ".Null" + enum.Name
+		} else if options.ModelsPackageImportPath != "" {
 			enumType = options.OutputModelsPackage + "." + enum.Name
+			nullType = "Null" + enumType
 		} else {
 			enumType = enum.Name
+			nullType = "Null" + enumType
 		}
 
 		_, keep := keepTypes[enumType]
-		_, keepNull := keepTypes["Null"+enumType]
+		_, keepNull := keepTypes[nullType]
 		if keep || keepNull {
 			keepEnums = append(keepEnums, enum)
 		}
@@ -0,0 +1,249 @@
+package golang
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+// syntheticColumnTypes are cycled through by the columns of synthetic tables
+var syntheticColumnTypes = []string{"text", "int4", "bool", "timestamptz", "numeric", "uuid", "jsonb", "int8"}
+
+// syntheticRequest returns a request for tables of columns each, with a get,
+// list and update query per table. A nested query embeds the first table and
+// groups the next depth tables into its struct.
+func syntheticRequest(tables, columns, depth int) *plugin.GenerateRequest {
+	ident := func(name string) *plugin.Identifier { return &plugin.Identifier{Name: name} }
+	schema := &plugin.Schema{Name: "public"}
+	var queries []*plugin.Query
+	for t := 0; t < tables; t++ {
+		table := &plugin.Identifier{Schema: "public", Name: fmt.Sprintf("table%d", t)}
+		cols := []*plugin.Column{{Name: "id", Type: ident("uuid"), NotNull: true, Table: table}}
+		if t > 0 {
+			cols = append(cols, &plugin.Column{Name: "parent_id", Type: ident("uuid"), NotNull: true, Table: table})
+		}
+		for c := len(cols); c < columns; c++ {
+			cols = append(cols, &plugin.Column{
+				Name:    fmt.Sprintf("column%d", c),
+				Type:    ident(syntheticColumnTypes[c%len(syntheticColumnTypes)]),
+				NotNull: c%2 == 0,
+				Table:   table,
+			})
+		}
+		schema.Tables = append(schema.Tables, &plugin.Table{Rel: table, Columns: cols})
+
+		id := &plugin.Parameter{Number: 1, Column: cols[0]}
+		var params []*plugin.Parameter
+		for i, c := range cols {
+			params = append(params, &plugin.Parameter{Number: int32(i + 1), Column: c})
+		}
+		filename := fmt.Sprintf("table%d.sql", t)
+		queries = append(queries,
+			&plugin.Query{Name: fmt.Sprintf("GetTable%d", t), Cmd: ":one", Text: "SELECT * FROM " + table.Name + " WHERE id = $1", Filename: filename, Columns: cols, Params: []*plugin.Parameter{id}},
+			&plugin.Query{Name: fmt.Sprintf("ListTable%d", t), Cmd: ":many", Text: "SELECT * FROM " + table.Name, Filename: filename, Columns: cols},
+			&plugin.Query{Name: fmt.Sprintf("UpdateTable%d", t), Cmd: ":exec", Text: "UPDATE " + table.Name + " SET ...", Filename: filename, Params: params},
+		)
+	}
+
+	options := map[string]any{
+		"package":                    "db",
+		"sql_package":                "pgx/v5",
+		"emit_json_tags":             true,
+		"output_models_package":      "entity",
+		"models_package_import_path": "example.com/db/entity",
+		"nested":                     &opts.NestedConfig{},
+	}
+	if depth > 0 && depth < tables {
+		var cols []*plugin.Column
+		cols = append(cols, schema.Tables[0].Columns...)
+		var group []*opts.NestedGroupConfig
+		composite, entity := true, false
+		for t := 1; t <= depth; t++ {
+			table := schema.Tables[t].Rel
+			cols = append(cols, &plugin.Column{Name: table.Name, Type: ident(table.Name), EmbedTable: table})
+			group = append(group, &opts.NestedGroupConfig{StructIn: fmt.Sprintf("Table%d", t), IsComposite: &entity})
+		}
+		queries = append(queries, &plugin.Query{Name: "ListNested", Cmd: ":many", Text: "SELECT ...", Filename: "nested.sql", Columns: cols})
+		options["nested"] = &opts.NestedConfig{
+			Composites: []*opts.NestedCompositeConfig{{Name: "Table0Group", StructRootIn: "Table0", Group: group}},
+			Queries:    []*opts.NestedQueryConfig{{Query: "ListNested", StructRoot: "Table0Group", IsComposite: &composite}},
+		}
+	}
+	pluginOptions, err := json.Marshal(options)
+	if err != nil {
+		panic(err)
+	}
+
+	return &plugin.GenerateRequest{
+		SqlcVersion:   "v1.29.0",
+		Settings:      &plugin.Settings{Engine: "postgresql"},
+		PluginOptions: pluginOptions,
+		Catalog:       &plugin.Catalog{DefaultSchema: "public", Schemas: []*plugin.Schema{schema}},
+		Queries:       queries,
+	}
+}
+
+func BenchmarkGenerate(b *testing.B) {
+	for _, bc := range []struct {
+		name    string
+		tables  int
+		columns int
+		depth   int
+	}{
+		{"small", 5, 10, 2},
+		{"wide", 20, 200, 0},
+		{"many_queries", 200, 15, 0},
+		{"nested", 12, 20, 10},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				// Generate resolves the options in place, each run needs its
+				// own request
+				b.StopTimer()
+				req := syntheticRequest(bc.tables, bc.columns, bc.depth)
+				b.StartTimer()
+				if _, err := Generate(context.Background(), req); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkColdStart generates a package of a single query, as the WASM
+// plugin does on every run, whose time is mostly spent parsing the templates
+func BenchmarkColdStart(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		req := syntheticRequest(1, 1, 0)
+		req.Queries = req.Queries[:1]
+		b.StartTimer()
+		if _, err := Generate(context.Background(), req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestSyntheticRequest(t *testing.T) {
+	resp, err := Generate(context.Background(), syntheticRequest(4, 12, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := map[string]bool{}
+	for _, f := range resp.Files {
+		names[f.Name] = true
+	}
+	for _, name := range []string{"table0.sql.go", "table3.sql.go", "nested.sql.go", "models.go"} {
+		if !names[name] {
+			t.Errorf("%s was not generated", name)
+		}
+	}
+}
+
+// TestGenerateDeterministic generates the same request repeatedly, the
+// files of every run must come in the same order with the same contents
+func TestGenerateDeterministic(t *testing.T) {
+	generate := func() *plugin.GenerateResponse {
+		req := syntheticRequest(6, 12, 3)
+		var options map[string]any
+		if err := json.Unmarshal(req.PluginOptions, &options); err != nil {
+			t.Fatal(err)
+		}
+		options["emit_interface"] = true
+		options["emit_manifest"] = true
+		options["packages"] = []map[string]string{{"match": "table5.sql", "package": "reports", "out": "reports"}}
+		req.PluginOptions, _ = json.Marshal(options)
+		resp, err := Generate(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	want := generate()
+	if !slices.IsSortedFunc(want.Files, func(a, b *plugin.File) int { return strings.Compare(a.Name, b.Name) }) {
+		t.Error("the files of the response are not sorted by name")
+	}
+	for run := 0; run < 10; run++ {
+		got := generate()
+		if len(got.Files) != len(want.Files) {
+			t.Fatalf("run %d generates %d files, want %d", run, len(got.Files), len(want.Files))
+		}
+		for i, f := range want.Files {
+			if got.Files[i].Name != f.Name || !bytes.Equal(got.Files[i].Contents, f.Contents) {
+				t.Fatalf("run %d generates %s at position %d, which differs from %s of the first run", run, got.Files[i].Name, i, f.Name)
+			}
+		}
+	}
+}
+
+// TestDescribeOptions checks that describe_options returns the options
+// resolved from the profile, the global options and the nested defaults
+func TestValidateConstantNames(t *testing.T) {
+	queries := []Query{
+		{MethodName: "GetUser", ConstantName: "GetUserQuery"},
+		{MethodName: "ListUsers", ConstantName: "GetUserQuery"},
+	}
+	err := validate(&opts.Options{}, nil, nil, queries)
+	if want := "queries GetUser and ListUsers have the same constant name: GetUserQuery"; err == nil || err.Error() != want {
+		t.Errorf("validate() error = %v, want %q", err, want)
+	}
+
+	queries[1].ConstantName = "User"
+	structs := []Struct{{Name: "User", Table: &plugin.Identifier{Name: "users"}}}
+	if err := validate(&opts.Options{}, nil, structs, queries); err != nil {
+		t.Errorf("validate() error = %v for an unexported constant named as a struct", err)
+	}
+	if err := validate(&opts.Options{EmitExportedQueries: true}, nil, structs, queries); err == nil {
+		t.Error("validate() accepts an exported constant named as a struct")
+	}
+}
+
+func TestDescribeOptions(t *testing.T) {
+	req := &plugin.GenerateRequest{
+		Settings:      &plugin.Settings{Engine: "postgresql"},
+		Catalog:       &plugin.Catalog{DefaultSchema: "public"},
+		PluginOptions: []byte(`{"package": "db", "profile": "pgx-strict", "describe_options": true, "nested": {"queries": [{"query": "ListAuthors", "group": [{"struct_in": "Book"}]}]}}`),
+		GlobalOptions: []byte(`{"rename": {"author": "Writer"}}`),
+	}
+	resp, err := Generate(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Files) != 1 || resp.Files[0].Name != "options.effective.json" {
+		t.Fatalf("describe_options generates %d files, want only options.effective.json", len(resp.Files))
+	}
+
+	var described struct {
+		SqlPackage   string             `json:"sql_package"`
+		StrictEnums  bool               `json:"strict_enums"`
+		EmitJsonTags *bool              `json:"emit_json_tags"`
+		Rename       map[string]string  `json:"rename"`
+		Nested       *opts.NestedConfig `json:"nested"`
+	}
+	if err := json.Unmarshal(resp.Files[0].Contents, &described); err != nil {
+		t.Fatal(err)
+	}
+	if described.SqlPackage != "pgx/v5" || !described.StrictEnums {
+		t.Errorf("the options of the profile are not resolved: %s", resp.Files[0].Contents)
+	}
+	if described.EmitJsonTags == nil {
+		t.Errorf("emit_json_tags is not listed although it is unset")
+	}
+	if described.Rename["author"] != "Writer" {
+		t.Errorf("the global rename is not merged: %v", described.Rename)
+	}
+	if group := described.Nested.Queries[0].Group[0]; group.StructOut != "Book" || group.IsComposite == nil {
+		t.Errorf("the nested defaults are not populated: %+v", group)
+	}
+}
@@ -1,6 +1,7 @@
 package golang
 
 import (
+	"slices"
 	"strings"
 
 	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
@@ -14,25 +15,60 @@ func addExtraGoStructTags(tags map[string]string, req *plugin.GenerateRequest, o
 		if oride.GoType.StructTags == nil {
 			continue
 		}
-		if !override.Matches(col.Table, req.Catalog.DefaultSchema) {
-			// Different table.
-			continue
-		}
-		cname := col.Name
-		if col.OriginalName != "" {
-			cname = col.OriginalName
-		}
-		if !sdk.MatchString(oride.ColumnName, cname) {
-			// Different column.
-			continue
+		if override.IsPattern() {
+			if !overridePatternMatches(&override, col, req.Catalog.DefaultSchema) {
+				continue
+			}
+		} else {
+			if !override.Matches(col.Table, req.Catalog.DefaultSchema) {
+				// Different table.
+				continue
+			}
+			cname := col.Name
+			if col.OriginalName != "" {
+				cname = col.OriginalName
+			}
+			if !sdk.MatchString(oride.ColumnName, cname) {
+				// Different column.
+				continue
+			}
 		}
 		// Add the extra tags.
-		for k, v := range oride.GoType.StructTags {
-			tags[k] = v
+		applyStructTags(tags, oride.GoType.StructTags, override.StructTagsMode)
+	}
+}
+
+// applyStructTags adds the tags of an override to the tags of a field, as
+// directed by its struct_tags_mode
+func applyStructTags(tags, extra map[string]string, mode string) {
+	if mode == opts.StructTagsModeReplaceAll {
+		clear(tags)
+	}
+	for k, v := range extra {
+		if current, ok := tags[k]; ok && mode == opts.StructTagsModeMerge {
+			v = mergeStructTag(current, v)
 		}
+		tags[k] = v
 	}
 }
 
+// mergeStructTag merges the value of a tag into the current one: the name of
+// value, when not empty, replaces the current name, and its options are added
+// to the current options, e.g. "id" and ",omitempty" give "id,omitempty"
+func mergeStructTag(current, value string) string {
+	parts := strings.Split(current, ",")
+	extra := strings.Split(value, ",")
+	if extra[0] != "" {
+		parts[0] = extra[0]
+	}
+	for _, option := range extra[1:] {
+		if option != "" && !slices.Contains(parts[1:], option) {
+			parts = append(parts, option)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
 func goType(req *plugin.GenerateRequest, options *opts.Options, col *plugin.Column) string {
 	// Check if the column's type has been overridden
 	for _, override := range options.Overrides {
@@ -58,11 +94,35 @@ func goType(req *plugin.GenerateRequest, options *opts.Options, col *plugin.Colu
 		return "[]" + typ
 	}
 	if col.IsArray {
-		return strings.Repeat("[]", int(col.ArrayDims)) + typ
+		if options.ArrayNulls == opts.ArrayNullsElements || options.ArrayNulls == opts.ArrayNullsBoth {
+			typ = goInnerType(req, options, arrayElementColumn(col))
+		}
+		typ = strings.Repeat("[]", int(col.ArrayDims)) + typ
+		if options.ArrayNulls == opts.ArrayNullsArray || options.ArrayNulls == opts.ArrayNullsBoth {
+			if !col.NotNull && typesDriver(options).IsPGX() {
+				typ = "*" + typ
+			}
+		}
+		return typ
 	}
 	return typ
 }
 
+// arrayElementColumn describes a single, possibly NULL, element of the array
+// column col, so that element types go through the same nullable type
+// mapping and db_type overrides as regular columns
+func arrayElementColumn(col *plugin.Column) *plugin.Column {
+	return &plugin.Column{
+		Name:         col.Name,
+		OriginalName: col.OriginalName,
+		Type:         col.Type,
+		Table:        col.Table,
+		Length:       col.Length,
+		Unsigned:     col.Unsigned,
+		IsNamedParam: col.IsNamedParam,
+	}
+}
+
 func goInnerType(req *plugin.GenerateRequest, options *opts.Options, col *plugin.Column) string {
 	columnType := sdk.DataType(col.Type)
 	notNull := col.NotNull || col.IsArray
@@ -73,11 +133,28 @@ func goInnerType(req *plugin.GenerateRequest, options *opts.Options, col *plugin
 		if oride.GoType.TypeName == "" {
 			continue
 		}
-		if oride.DbType != "" && oride.DbType == columnType && oride.Nullable != notNull && oride.Unsigned == col.Unsigned {
+		if oride.Nullable == notNull || oride.Unsigned != col.Unsigned {
+			continue
+		}
+		if override.IsPattern() {
+			if overridePatternMatches(&override, col, req.Catalog.DefaultSchema) {
+				return oride.GoType.TypeName
+			}
+			continue
+		}
+		if oride.DbType != "" && dbTypeMatches(oride.DbType, columnType, req.Catalog.DefaultSchema) {
 			return oride.GoType.TypeName
 		}
 	}
 
+	// Overrides of a domain's base type apply to the domain's columns when
+	// the domain is listed in the domains option and no override names it
+	if req.Settings.Engine == "postgresql" {
+		if typ, ok := domainOverrideType(req, options, col, notNull); ok {
+			return typ
+		}
+	}
+
 	// TODO: Extend the engine interface to handle types
 	switch req.Settings.Engine {
 	case "mysql":
@@ -90,3 +167,80 @@ func goInnerType(req *plugin.GenerateRequest, options *opts.Options, col *plugin
 		return "interface{}"
 	}
 }
+
+// domainOverrideType returns the Go type of the first db_type override that
+// matches a base type of the domain column col, following domains over other
+// domains
+func domainOverrideType(req *plugin.GenerateRequest, options *opts.Options, col *plugin.Column, notNull bool) (string, bool) {
+	rel := &plugin.Identifier{Schema: col.Type.GetSchema(), Name: col.Type.GetName()}
+	if rel.Schema == "" {
+		rel.Schema = req.Catalog.DefaultSchema
+	}
+	// ValidateOpts rejects domains resolving to themselves, the bound only
+	// guards against options that were not validated
+	for range len(options.Domains) {
+		base, ok := domainBaseType(options, rel)
+		if !ok {
+			return "", false
+		}
+		baseType := sdk.DataType(base)
+		for _, override := range options.Overrides {
+			oride := override.ShimOverride
+			if oride.GoType.TypeName == "" || override.IsPattern() {
+				continue
+			}
+			if oride.Nullable == notNull || oride.Unsigned != col.Unsigned {
+				continue
+			}
+			if oride.DbType != "" && dbTypeMatches(oride.DbType, baseType, req.Catalog.DefaultSchema) {
+				return oride.GoType.TypeName, true
+			}
+		}
+		rel = base
+		if rel.Schema == "" {
+			rel.Schema = req.Catalog.DefaultSchema
+		}
+	}
+	return "", false
+}
+
+// dbTypeMatches reports whether the db_type of an override refers to
+// columnType. Types of the default schema, such as domains and enums, match
+// with and without the schema qualifier.
+func dbTypeMatches(dbType, columnType, defaultSchema string) bool {
+	if dbType == columnType {
+		return true
+	}
+	if defaultSchema == "" {
+		return false
+	}
+	return dbType == strings.TrimPrefix(columnType, defaultSchema+".") ||
+		strings.TrimPrefix(dbType, defaultSchema+".") == columnType
+}
+
+// overridePatternMatches reports whether an override with column_name_pattern
+// or db_type_pattern applies to the column, whatever its table. The db_type of
+// the override, when set, must match too. Types are matched with and without
+// their schema, so that ^timestamptz$ matches pg_catalog.timestamptz.
+func overridePatternMatches(override *opts.Override, col *plugin.Column, defaultSchema string) bool {
+	if override.ColumnNameRegexp != nil {
+		cname := col.Name
+		if col.OriginalName != "" {
+			cname = col.OriginalName
+		}
+		if !override.ColumnNameRegexp.MatchString(cname) {
+			return false
+		}
+	}
+	columnType := sdk.DataType(col.Type)
+	if override.DBType != "" && !dbTypeMatches(override.DBType, columnType, defaultSchema) {
+		return false
+	}
+	if override.DBTypeRegexp != nil {
+		unqualified := columnType[strings.LastIndex(columnType, ".")+1:]
+		if !override.DBTypeRegexp.MatchString(columnType) && !override.DBTypeRegexp.MatchString(unqualified) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,154 @@
+package golang
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+func TestArrayNulls(t *testing.T) {
+	req := &plugin.GenerateRequest{
+		Settings: &plugin.Settings{Engine: "postgresql"},
+		Catalog:  &plugin.Catalog{DefaultSchema: "public"},
+	}
+	for _, tc := range []struct {
+		sqlPackage string
+		arrayNulls string
+		notNull    string
+		nullable   string
+	}{
+		{"pgx/v5", "", "[]string", "[]string"},
+		{"pgx/v5", opts.ArrayNullsElements, "[]pgtype.Text", "[]pgtype.Text"},
+		{"pgx/v5", opts.ArrayNullsArray, "[]string", "*[]string"},
+		{"pgx/v5", opts.ArrayNullsBoth, "[]pgtype.Text", "*[]pgtype.Text"},
+		{"pgx/v4", opts.ArrayNullsBoth, "[]sql.NullString", "*[]sql.NullString"},
+		{"database/sql", opts.ArrayNullsElements, "[]sql.NullString", "[]sql.NullString"},
+		// database/sql scans NULL arrays into nil slices, so they are never pointers
+		{"database/sql", opts.ArrayNullsBoth, "[]sql.NullString", "[]sql.NullString"},
+	} {
+		t.Run(tc.sqlPackage+"/"+tc.arrayNulls, func(t *testing.T) {
+			options := &opts.Options{SqlPackage: tc.sqlPackage, ArrayNulls: tc.arrayNulls}
+			for _, col := range []struct {
+				notNull bool
+				want    string
+			}{
+				{true, tc.notNull},
+				{false, tc.nullable},
+			} {
+				got := goType(req, options, &plugin.Column{Type: &plugin.Identifier{Name: "text"}, NotNull: col.notNull, IsArray: true, ArrayDims: 1})
+				if got != col.want {
+					t.Errorf("goType() of a text[] column with NotNull %t = %s, want %s", col.notNull, got, col.want)
+				}
+			}
+		})
+	}
+
+	options := &opts.Options{SqlPackage: "pgx/v5", ArrayNulls: opts.ArrayNullsBoth}
+	got := goType(req, options, &plugin.Column{Type: &plugin.Identifier{Name: "int4"}, IsArray: true, ArrayDims: 2})
+	if want := "*[][]pgtype.Int4"; got != want {
+		t.Errorf("goType() of an int4[][] column = %s, want %s", got, want)
+	}
+}
+
+func TestDomainOverrides(t *testing.T) {
+	const (
+		domainOverride = `{"db_type": "email_address", "go_type": "example.com/mail.Address"}`
+		baseOverride   = `{"db_type": "text", "go_type": "example.com/text.String"}`
+	)
+	for _, tc := range []struct {
+		name      string
+		domains   string
+		overrides string
+		dbType    string
+		notNull   bool
+		want      string
+	}{
+		{"domain override", `{"email_address": "text"}`, domainOverride + "," + baseOverride, "email_address", true, "mail.Address"},
+		{"domain override listed last", `{"email_address": "text"}`, baseOverride + "," + domainOverride, "email_address", true, "mail.Address"},
+		{"domain override without base", `{}`, domainOverride, "email_address", true, "mail.Address"},
+		{"qualified domain", `{"public.email_address": "text"}`, baseOverride, "public.email_address", true, "text.String"},
+		{"base override", `{"email_address": "text"}`, baseOverride, "email_address", true, "text.String"},
+		{"base override through domains", `{"work_email": "email_address", "email_address": "text"}`, baseOverride, "work_email", true, "text.String"},
+		{"base override of another nullability", `{"email_address": "text"}`, baseOverride, "email_address", false, "pgtype.Text"},
+		{"base type", `{"email_address": "text"}`, "", "email_address", true, "string"},
+		{"unlisted domain", `{}`, baseOverride, "email_address", true, "interface{}"},
+		{"base column", `{"email_address": "text"}`, domainOverride, "text", true, "string"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := &plugin.GenerateRequest{
+				Settings:      &plugin.Settings{Engine: "postgresql"},
+				Catalog:       &plugin.Catalog{DefaultSchema: "public"},
+				PluginOptions: []byte(`{"package": "db", "sql_package": "pgx/v5", "domains": ` + tc.domains + `, "overrides": [` + tc.overrides + `]}`),
+			}
+			options, err := opts.Parse(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			typ, err := parseIdentifierString(tc.dbType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := goType(req, options, &plugin.Column{Type: typ, NotNull: tc.notNull}); got != tc.want {
+				t.Errorf("goType() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOverridePatterns(t *testing.T) {
+	req := &plugin.GenerateRequest{
+		Settings: &plugin.Settings{Engine: "postgresql"},
+		Catalog:  &plugin.Catalog{DefaultSchema: "public"},
+		PluginOptions: []byte(`{"package": "db", "sql_package": "pgx/v5", "overrides": [
+			{"column_name_pattern": "_at$", "db_type": "timestamptz", "go_type": "time.Time"},
+			{"db_type_pattern": "^num", "go_type": "github.com/shopspring/decimal.Decimal"}
+		]}`),
+	}
+	options, err := opts.Parse(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		column string
+		dbType string
+		want   string
+	}{
+		{"created_at", "timestamptz", "time.Time"},
+		// The db_type of the override must match too
+		{"expires_at", "date", "pgtype.Date"},
+		{"created", "timestamptz", "pgtype.Timestamptz"},
+		{"price", "numeric", "decimal.Decimal"},
+		{"price", "pg_catalog.numeric", "decimal.Decimal"},
+		{"amount", "int4", "int32"},
+	} {
+		typ, err := parseIdentifierString(tc.dbType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		col := &plugin.Column{Name: tc.column, Type: typ, NotNull: true, Table: &plugin.Identifier{Name: "orders"}}
+		if got := goType(req, options, col); got != tc.want {
+			t.Errorf("goType() of %s %s = %s, want %s", tc.column, tc.dbType, got, tc.want)
+		}
+	}
+}
+
+func TestApplyStructTags(t *testing.T) {
+	extra := map[string]string{"json": ",omitempty", "validate": "required"}
+	for _, tc := range []struct {
+		mode string
+		want map[string]string
+	}{
+		{opts.StructTagsModeReplace, map[string]string{"json": ",omitempty", "db": "id", "validate": "required"}},
+		{opts.StructTagsModeMerge, map[string]string{"json": "id,omitempty", "db": "id", "validate": "required"}},
+		{opts.StructTagsModeReplaceAll, map[string]string{"json": ",omitempty", "validate": "required"}},
+	} {
+		tags := map[string]string{"json": "id", "db": "id"}
+		applyStructTags(tags, extra, tc.mode)
+		if diff := cmp.Diff(tc.want, tags); diff != "" {
+			t.Errorf("applyStructTags() in mode %s mismatch (-want +got):\n%s", tc.mode, diff)
+		}
+	}
+}
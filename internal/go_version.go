@@ -0,0 +1,43 @@
+package golang
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+// genericsGoVersion is the minor version of the first Go release the nested
+// grouping code is generated with type parameters for. For older releases it
+// is generated again with the row getter interfaces instead.
+const genericsGoVersion = 21
+
+// goVersionAtLeast reports whether the go_version targeted by the generated
+// code is at least 1.minor. Without go_version the latest release is assumed.
+func goVersionAtLeast(options *opts.Options, minor int) bool {
+	if options.GoVersion == "" {
+		return true
+	}
+	parts := strings.Split(options.GoVersion, ".")
+	v, err := strconv.Atoi(parts[1])
+	return err == nil && v >= minor
+}
+
+// goVersionConstraint adds a go1.minor release constraint to the build
+// constraint tags of a file relying on that release, or the opposite
+// !go1.minor constraint to the file replacing it on older releases. The
+// constraint is only added when go_version targets older releases.
+func goVersionConstraint(options *opts.Options, tags string, minor int, available bool) string {
+	if goVersionAtLeast(options, minor) {
+		return tags
+	}
+	release := fmt.Sprintf("go1.%d", minor)
+	if !available {
+		release = "!" + release
+	}
+	if tags == "" {
+		return release
+	}
+	return fmt.Sprintf("(%s) && %s", tags, release)
+}
@@ -0,0 +1,29 @@
+package golang
+
+import (
+	"testing"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+func TestGoVersionConstraint(t *testing.T) {
+	for _, test := range []struct {
+		goVersion string
+		tags      string
+		available bool
+		want      string
+	}{
+		{"", "", true, ""},
+		{"1.21", "integration", true, "integration"},
+		{"1.22", "", false, ""},
+		{"1.20", "", true, "go1.21"},
+		{"1.20", "", false, "!go1.21"},
+		{"1.18", "integration || e2e", true, "(integration || e2e) && go1.21"},
+		{"1.18", "integration", false, "(integration) && !go1.21"},
+	} {
+		options := &opts.Options{GoVersion: test.goVersion}
+		if got := goVersionConstraint(options, test.tags, genericsGoVersion, test.available); got != test.want {
+			t.Errorf("goVersionConstraint(%q, %q, %t) = %q, want %q", test.goVersion, test.tags, test.available, got, test.want)
+		}
+	}
+}
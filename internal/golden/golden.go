@@ -0,0 +1,160 @@
+// Package golden runs captured GenerateRequests through the plugin and
+// compares the generated files with golden output trees, so that changes to
+// the templates are reviewed as diffs of the generated code.
+//
+// Each case is a directory holding the request.json written by
+// debug/capture, an optional options.json replacing its plugin options, and
+// the expected files under output/. Run the tests with -update to rewrite the
+// output trees. The *_test.go files of a tree are tests of the generated code,
+// and its go.mod and go.sum the module it builds in, they are neither compared
+// nor rewritten.
+package golden
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden output trees with the generated files")
+
+// Generator is the entry point of the plugin, e.g. golang.Generate
+type Generator func(context.Context, *plugin.GenerateRequest) (*plugin.GenerateResponse, error)
+
+// Run runs each case in dir as a subtest
+func Run(t *testing.T, dir string, generate Generator) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		caseDir := filepath.Join(dir, entry.Name())
+		t.Run(entry.Name(), func(t *testing.T) {
+			RunCase(t, caseDir, generate)
+		})
+	}
+}
+
+// RunCase generates the files of the case in dir and compares them with its
+// output tree
+func RunCase(t *testing.T, dir string, generate Generator) {
+	t.Helper()
+	req, err := ReadRequest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := generate(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Compare(t, filepath.Join(dir, "output"), resp.Files)
+}
+
+// Compare compares files with the output tree in outDir, or rewrites the tree
+// with them when the tests run with -update
+func Compare(t *testing.T, outDir string, files []*plugin.File) {
+	t.Helper()
+	got := map[string]string{}
+	for _, f := range files {
+		got[filepath.ToSlash(f.Name)] = string(f.Contents)
+	}
+
+	if *update {
+		if err := writeTree(outDir, got); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := readTree(outDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("generated files differ from %s (-want +got), run with -update to accept them:\n%s", outDir, diff)
+	}
+}
+
+// ReadRequest reads the request.json of a case, with the plugin options of
+// its options.json if there is one
+func ReadRequest(dir string) (*plugin.GenerateRequest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "request.json"))
+	if err != nil {
+		return nil, err
+	}
+	var req plugin.GenerateRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, err
+	}
+	options, err := os.ReadFile(filepath.Join(dir, "options.json"))
+	switch {
+	case err == nil:
+		req.PluginOptions = options
+	case !os.IsNotExist(err):
+		return nil, err
+	}
+	return &req, nil
+}
+
+// handWritten reports whether the file at path is a test written next to the
+// generated files, or the module they build in, which the trees leave alone
+func handWritten(path string) bool {
+	switch filepath.Base(path) {
+	case "go.mod", "go.sum":
+		return true
+	}
+	return strings.HasSuffix(path, "_test.go")
+}
+
+func readTree(dir string) (map[string]string, error) {
+	files := map[string]string{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || handWritten(path) {
+			return err
+		}
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(name)] = string(contents)
+		return nil
+	})
+	return files, err
+}
+
+func writeTree(dir string, files map[string]string) error {
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || handWritten(path) {
+			return err
+		}
+		return os.Remove(path)
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for name, contents := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+package golang
+
+import (
+	"testing"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/golden"
+)
+
+func TestGolden(t *testing.T) {
+	golden.Run(t, "testdata/golden", Generate)
+}
@@ -0,0 +1,264 @@
+package golang
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sqlc-dev/plugin-sdk-go/sdk"
+)
+
+// graphqlBuiltinScalars are the scalars every GraphQL schema has
+var graphqlBuiltinScalars = map[string]struct{}{
+	"String":  {},
+	"Int":     {},
+	"Float":   {},
+	"Boolean": {},
+	"ID":      {},
+}
+
+// graphqlScalars maps Go types to GraphQL scalars, Time, UUID and Any are
+// the names of the scalars gqlgen binds to them
+var graphqlScalars = map[string]string{
+	"string":          "String",
+	"bool":            "Boolean",
+	"int":             "Int",
+	"int8":            "Int",
+	"int16":           "Int",
+	"int32":           "Int",
+	"int64":           "Int",
+	"uint":            "Int",
+	"uint8":           "Int",
+	"uint16":          "Int",
+	"uint32":          "Int",
+	"uint64":          "Int",
+	"float32":         "Float",
+	"float64":         "Float",
+	"[]byte":          "Bytes",
+	"[16]byte":        "UUID",
+	"uuid.UUID":       "UUID",
+	"time.Time":       "Time",
+	"json.RawMessage": "JSON",
+	"interface{}":     "Any",
+	"any":             "Any",
+}
+
+// graphqlQualifier matches the package qualifiers of Go types
+var graphqlQualifier = regexp.MustCompile(`\w+\.`)
+
+// GraphQLSchema describes the types of the grouped results of the nested
+// queries and the Query type resolving them
+type GraphQLSchema struct {
+	Scalars []string
+	Types   []GraphQLType
+	Queries []GraphQLField
+}
+
+// GraphQLType is an object type, or an input type for query parameters
+type GraphQLType struct {
+	Name    string
+	Input   bool
+	Comment string
+	Fields  []GraphQLField
+}
+
+type GraphQLField struct {
+	Name string
+	Args string // e.g. "(id: UUID!)"
+	Type string // e.g. "[Book!]"
+}
+
+type graphqlBuilder struct {
+	structs map[string]outputStruct
+	enums   map[string]struct{}
+	scalars map[string]struct{}
+	seen    map[string]struct{}
+	types   []GraphQLType
+}
+
+// typ returns the GraphQL type of the Go type typ, adding the types it refers
+// to to the schema
+func (b *graphqlBuilder) typ(typ string) (string, bool) {
+	if elem, ok := strings.CutPrefix(typ, "*"); ok {
+		t, _ := b.typ(elem)
+		return t, true
+	}
+	if n, ok := findNullWrapper(b.enums, typ); ok {
+		t, _ := b.typ(n.ValueType)
+		return t, true
+	}
+	if s, ok := graphqlScalars[typ]; ok {
+		if _, ok := graphqlBuiltinScalars[s]; !ok {
+			b.scalars[s] = struct{}{}
+		}
+		return s, false
+	}
+	if elem, ok := strings.CutPrefix(typ, "[]"); ok {
+		t, _ := b.typ(elem)
+		// nil slices are null
+		return "[" + t + "!]", true
+	}
+
+	name := typ[strings.LastIndex(typ, ".")+1:]
+	if s, ok := b.structs[name]; ok {
+		b.object(s, false)
+		return name, false
+	}
+	if _, ok := b.enums[name]; ok {
+		return "String", false
+	}
+	// Other types are custom scalars, e.g. pgtype.Numeric is Numeric
+	scalar := graphqlQualifier.ReplaceAllString(typ, "")
+	scalar = strings.Map(func(r rune) rune {
+		if r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, scalar)
+	scalar = upperTitle(scalar)
+	b.scalars[scalar] = struct{}{}
+	return scalar, false
+}
+
+// field returns the GraphQL field of a Go field, named after its JSON
+// property so that gqlgen binds it
+func (b *graphqlBuilder) field(goName, typ string, tags map[string]string) (GraphQLField, bool) {
+	name, _, ok := jsonProperty(goName, tags)
+	if !ok {
+		return GraphQLField{}, false
+	}
+	if _, tagged := tags["json"]; !tagged {
+		name = toCamelCase(toSnakeCase(goName))
+	}
+	t, nullable := b.typ(typ)
+	if !nullable {
+		t += "!"
+	}
+	return GraphQLField{Name: name, Type: t}, true
+}
+
+// object adds the type of a struct and the ones it refers to
+func (b *graphqlBuilder) object(s outputStruct, input bool) {
+	if _, ok := b.seen[s.Name]; ok {
+		return
+	}
+	b.seen[s.Name] = struct{}{}
+	t := GraphQLType{Name: s.Name, Input: input, Comment: s.Comment}
+	for _, f := range s.Fields {
+		if field, ok := b.field(f.Name, f.Type, f.Tags); ok {
+			t.Fields = append(t.Fields, field)
+		}
+	}
+	for _, n := range s.Nested {
+		if field, ok := b.field(n.FieldName, n.FieldType, n.FieldTags); ok {
+			t.Fields = append(t.Fields, field)
+		}
+	}
+	b.types = append(b.types, t)
+}
+
+// graphqlQueries returns the queries that return grouped results
+func graphqlQueries(queries []Query) []Query {
+	var grouped []Query
+	for _, q := range queries {
+		if q.ShouldCallGroupFunction() {
+			grouped = append(grouped, q)
+		}
+	}
+	return grouped
+}
+
+// buildGraphQLSchema returns the schema of the grouped results of the nested
+// queries, with a Query field per query
+func buildGraphQLSchema(enums []Enum, structs []Struct, queries []Query, nested []Nested) *GraphQLSchema {
+	b := &graphqlBuilder{
+		structs: map[string]outputStruct{},
+		enums:   map[string]struct{}{},
+		scalars: map[string]struct{}{},
+		seen:    map[string]struct{}{},
+	}
+	for _, e := range enums {
+		b.enums[e.Name] = struct{}{}
+	}
+	walkOutputStructs(structs, queries, nested, func(s outputStruct) {
+		if _, ok := b.structs[s.Name]; !ok {
+			b.structs[s.Name] = s
+		}
+	})
+
+	schema := &GraphQLSchema{}
+	for _, q := range graphqlQueries(queries) {
+		field := GraphQLField{Name: sdk.LowerTitle(q.MethodName), Type: q.GroupReturnType + "!"}
+		if q.Cmd == ":one" {
+			field.Type = q.GroupReturnType
+		} else {
+			field.Type = "[" + field.Type + "]!"
+		}
+		if s, ok := b.structs[q.GroupReturnType]; ok {
+			b.object(s, false)
+		}
+
+		var args []string
+		switch {
+		case q.Arg.isEmpty():
+		case q.Arg.EmitStruct():
+			b.object(outputStruct{Name: q.Arg.Type(), Fields: q.Arg.Struct.Fields}, true)
+			args = append(args, q.Arg.Name+": "+q.Arg.Type()+"!")
+		default:
+			for _, a := range q.Arg.Pairs() {
+				t, nullable := b.typ(a.Type)
+				if !nullable {
+					t += "!"
+				}
+				args = append(args, a.Name+": "+t)
+			}
+		}
+		if len(args) > 0 {
+			field.Args = "(" + strings.Join(args, ", ") + ")"
+		}
+		schema.Queries = append(schema.Queries, field)
+	}
+
+	schema.Types = b.types
+	for s := range b.scalars {
+		schema.Scalars = append(schema.Scalars, s)
+	}
+	sort.Strings(schema.Scalars)
+	return schema
+}
+
+// Contents renders the schema in the GraphQL schema definition language
+func (s *GraphQLSchema) Contents() []byte {
+	var b strings.Builder
+	b.WriteString("# Code generated by sqlc. DO NOT EDIT.\n")
+	if len(s.Scalars) > 0 {
+		b.WriteString("\n")
+	}
+	for _, scalar := range s.Scalars {
+		fmt.Fprintf(&b, "scalar %s\n", scalar)
+	}
+	for _, t := range s.Types {
+		b.WriteString("\n")
+		if comment := strings.TrimSpace(t.Comment); comment != "" {
+			fmt.Fprintf(&b, "\"\"\"\n%s\n\"\"\"\n", comment)
+		}
+		kind := "type"
+		if t.Input {
+			kind = "input"
+		}
+		fmt.Fprintf(&b, "%s %s {\n", kind, t.Name)
+		for _, f := range t.Fields {
+			fmt.Fprintf(&b, "  %s: %s\n", f.Name, f.Type)
+		}
+		b.WriteString("}\n")
+	}
+	if len(s.Queries) > 0 {
+		b.WriteString("\ntype Query {\n")
+		for _, f := range s.Queries {
+			fmt.Fprintf(&b, "  %s%s: %s\n", f.Name, f.Args, f.Type)
+		}
+		b.WriteString("}\n")
+	}
+	return []byte(b.String())
+}
@@ -0,0 +1,78 @@
+package golang
+
+import (
+	"testing"
+)
+
+func TestGraphQLType(t *testing.T) {
+	b := &graphqlBuilder{
+		structs: map[string]outputStruct{"Book": {Name: "Book", Fields: []Field{{Name: "Title", Type: "string"}}}},
+		enums:   map[string]struct{}{"Status": {}},
+		scalars: map[string]struct{}{},
+		seen:    map[string]struct{}{},
+	}
+
+	for _, tc := range []struct {
+		typ      string
+		want     string
+		nullable bool
+	}{
+		{"string", "String", false},
+		{"int64", "Int", false},
+		{"*int32", "Int", true},
+		{"pgtype.Text", "String", true},
+		{"sql.NullTime", "Time", true},
+		{"NullStatus", "String", true},
+		{"Status", "String", false},
+		{"pgtype.UUID", "UUID", true},
+		{"pgtype.Numeric", "Numeric", false},
+		{"[]string", "[String!]", true},
+		{"[]*Book", "[Book!]", true},
+		{"models.Book", "Book", false},
+	} {
+		got, nullable := b.typ(tc.typ)
+		if got != tc.want || nullable != tc.nullable {
+			t.Errorf("typ(%q) = %q, %v, want %q, %v", tc.typ, got, nullable, tc.want, tc.nullable)
+		}
+	}
+
+	if len(b.types) != 1 || b.types[0].Name != "Book" {
+		t.Errorf("types = %+v, want Book once", b.types)
+	}
+	for _, s := range []string{"Time", "UUID", "Numeric"} {
+		if _, ok := b.scalars[s]; !ok {
+			t.Errorf("scalar %s is not declared", s)
+		}
+	}
+}
+
+func TestGraphQLSchemaContents(t *testing.T) {
+	s := &GraphQLSchema{
+		Scalars: []string{"Time"},
+		Types: []GraphQLType{
+			{Name: "AuthorGroup", Fields: []GraphQLField{{Name: "name", Type: "String!"}, {Name: "books", Type: "[Book!]"}}},
+			{Name: "ListParams", Input: true, Fields: []GraphQLField{{Name: "limit", Type: "Int!"}}},
+		},
+		Queries: []GraphQLField{{Name: "listAuthors", Args: "(arg: ListParams!)", Type: "[AuthorGroup!]!"}},
+	}
+	want := `# Code generated by sqlc. DO NOT EDIT.
+
+scalar Time
+
+type AuthorGroup {
+  name: String!
+  books: [Book!]
+}
+
+input ListParams {
+  limit: Int!
+}
+
+type Query {
+  listAuthors(arg: ListParams!): [AuthorGroup!]!
+}
+`
+	if got := string(s.Contents()); got != want {
+		t.Errorf("Contents() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,33 @@
+package golang
+
+// GrouperFunction is a Group function of a nested query, declared as a
+// method of the Grouper interface
+type GrouperFunction struct {
+	Name   string
+	Row    string
+	Result string
+}
+
+// buildGrouperFunctions returns the Group functions generated for nested,
+// in the order of the nested files. Queries whose root struct is generated
+// by another query reuse its function and have none of their own.
+func buildGrouperFunctions(nested []Nested) []GrouperFunction {
+	var functions []GrouperFunction
+	for _, n := range nested {
+		for _, item := range n.NestedDataItems {
+			if item.RootStructData != nil && item.RootStructData.SkipStructGeneration {
+				continue
+			}
+			ptr := ""
+			if item.EmitPointers {
+				ptr = "*"
+			}
+			functions = append(functions, GrouperFunction{
+				Name:   item.FunctionName,
+				Row:    ptr + item.Query.MethodName + "Row",
+				Result: ptr + item.RootStructName,
+			})
+		}
+	}
+	return functions
+}
@@ -0,0 +1,51 @@
+package golang
+
+import (
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+// hstoreTypeName is the name of the map type generated into the models file
+// for hstore columns when the driver has no hstore type of its own
+const hstoreTypeName = "Hstore"
+
+func hstoreType(options *opts.Options) string {
+	if options.ModelsPackageImportPath != "" {
+		return options.OutputModelsPackage + "." + hstoreTypeName
+	}
+	return hstoreTypeName
+}
+
+// usesHstore reports whether the generated Hstore type is referenced by any
+// model or query
+func usesHstore(options *opts.Options, structs []Struct, queries []Query) bool {
+	if typesDriver(options).IsPGX() {
+		return false
+	}
+	typ := hstoreType(options)
+	for _, s := range structs {
+		for _, f := range s.Fields {
+			if trimSliceAndPointerPrefix(f.Type) == typ {
+				return true
+			}
+		}
+	}
+	for _, q := range queries {
+		for _, v := range []QueryValue{q.Arg, q.Ret} {
+			if v.isEmpty() {
+				continue
+			}
+			if !v.IsStruct() {
+				if trimSliceAndPointerPrefix(v.Typ) == typ {
+					return true
+				}
+				continue
+			}
+			for _, f := range v.Struct.Fields {
+				if trimSliceAndPointerPrefix(f.Type) == typ {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package golang
+
+import (
+	"testing"
+
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+func TestHstoreType(t *testing.T) {
+	req := &plugin.GenerateRequest{Catalog: &plugin.Catalog{DefaultSchema: "public"}}
+	for _, tc := range []struct {
+		options opts.Options
+		want    string
+	}{
+		{opts.Options{SqlPackage: "pgx/v5"}, "pgtype.Hstore"},
+		{opts.Options{SqlPackage: "pgx/v4"}, "pgtype.Hstore"},
+		// A nil map is NULL, so nullable columns use the same type
+		{opts.Options{SqlPackage: "database/sql"}, "Hstore"},
+		{opts.Options{SqlPackage: "database/sql", OutputModelsPackage: "entity", ModelsPackageImportPath: "example.com/db/entity"}, "entity.Hstore"},
+	} {
+		for _, notNull := range []bool{true, false} {
+			got := postgresType(req, &tc.options, &plugin.Column{Type: &plugin.Identifier{Name: "hstore"}, NotNull: notNull})
+			if got != tc.want {
+				t.Errorf("postgresType() of an hstore column with NotNull %t and sql_package %s = %s, want %s", notNull, tc.options.SqlPackage, got, tc.want)
+			}
+		}
+	}
+}
+
+func TestUsesHstore(t *testing.T) {
+	model := []Struct{{Name: "Product", Fields: []Field{{Name: "Attributes", Type: "Hstore"}}}}
+	param := []Query{{Arg: QueryValue{Name: "attributes", Typ: "Hstore"}}}
+	stdlib := &opts.Options{SqlPackage: "database/sql"}
+	for _, tc := range []struct {
+		name    string
+		options *opts.Options
+		structs []Struct
+		queries []Query
+		want    bool
+	}{
+		{"model field", stdlib, model, nil, true},
+		{"query parameter", stdlib, nil, param, true},
+		{"no hstore", stdlib, []Struct{{Name: "Product", Fields: []Field{{Name: "Name", Type: "string"}}}}, nil, false},
+		{"pgx", &opts.Options{SqlPackage: "pgx/v5"}, model, param, false},
+	} {
+		if got := usesHstore(tc.options, tc.structs, tc.queries); got != tc.want {
+			t.Errorf("%s: usesHstore() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/sqlc-dev/plugin-sdk-go/metadata"
+	"github.com/sqlc-dev/sqlc-gen-go/internal/debug"
 	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
 )
 
@@ -62,6 +63,42 @@ type importer struct {
 	Queries []Query
 	Enums   []Enum
 	Structs []Struct
+	// SchemaPackages lists the packages generated for schemas by
+	// schema_packages, SchemaPackage is the one whose models are being
+	// generated, if any
+	SchemaPackages []string
+	SchemaPackage  string
+	// Proto holds the messages the converters of emit_proto are generated for
+	Proto *ProtoFile
+	// Fixtures holds the factories of emit_fixtures
+	Fixtures *FixtureFile
+	// IntegrationTest is the test of emit_integration_tests
+	IntegrationTest *IntegrationTest
+
+	// bySource indexes Queries by SourceName and cache holds the imports
+	// already computed per file, so that generating a file does not scan
+	// the queries of every other file
+	bySource map[string][]Query
+	cache    map[string][][]ImportSpec
+}
+
+// setModels switches the enums and structs imports are computed for, which
+// invalidates the imports computed so far
+func (i *importer) setModels(enums []Enum, structs []Struct, schemaPackage string) {
+	i.Enums, i.Structs, i.SchemaPackage = enums, structs, schemaPackage
+	i.cache = nil
+}
+
+// sourceQueries returns the queries generated from the SQL file name, in the
+// order of Queries
+func (i *importer) sourceQueries(name string) []Query {
+	if i.bySource == nil {
+		i.bySource = make(map[string][]Query)
+		for _, q := range i.Queries {
+			i.bySource[q.SourceName] = append(i.bySource[q.SourceName], q)
+		}
+	}
+	return i.bySource[name]
 }
 
 func (i *importer) usesType(typ string) bool {
@@ -81,6 +118,19 @@ func (i *importer) HasImports(filename string) bool {
 }
 
 func (i *importer) Imports(filename string) [][]ImportSpec {
+	if imports, ok := i.cache[filename]; ok {
+		return imports
+	}
+	imports := i.fileImports(filename)
+	debug.Importer.Printf("%s imports %d standard and %d other packages", filename, len(imports[0]), len(imports[1]))
+	if i.cache == nil {
+		i.cache = make(map[string][][]ImportSpec)
+	}
+	i.cache[filename] = imports
+	return imports
+}
+
+func (i *importer) fileImports(filename string) [][]ImportSpec {
 	dbFileName := "db.go"
 	if i.Options.OutputDbFileName != "" {
 		dbFileName = i.Options.OutputDbFileName
@@ -89,6 +139,7 @@ func (i *importer) Imports(filename string) [][]ImportSpec {
 	if i.Options.OutputModelsFileName != "" {
 		modelsFileName = i.Options.OutputModelsFileName
 	}
+	enumsFileName := i.Options.OutputEnumsFileName
 	querierFileName := "querier.go"
 	if i.Options.OutputQuerierFileName != "" {
 		querierFileName = i.Options.OutputQuerierFileName
@@ -105,12 +156,78 @@ func (i *importer) Imports(filename string) [][]ImportSpec {
 	if i.Options.OutputNestedUtilsFileName != "" {
 		nestedUtilsFileName = i.Options.OutputNestedUtilsFileName
 	}
+	grouperFileName := "grouper.go"
+	if i.Options.OutputGrouperFileName != "" {
+		grouperFileName = i.Options.OutputGrouperFileName
+	}
+	nullConvFileName := "nullconv.go"
+	if i.Options.OutputNullConvFileName != "" {
+		nullConvFileName = i.Options.OutputNullConvFileName
+	}
+	rangesFileName := "ranges.go"
+	if i.Options.OutputRangesFileName != "" {
+		rangesFileName = i.Options.OutputRangesFileName
+	}
+	postgisFileName := "postgis.go"
+	if i.Options.OutputPostgisFileName != "" {
+		postgisFileName = i.Options.OutputPostgisFileName
+	}
+	docFileName := "doc.go"
+	if i.Options.OutputDocFileName != "" {
+		docFileName = i.Options.OutputDocFileName
+	}
+	versionFileName := "version.go"
+	if i.Options.OutputVersionFileName != "" {
+		versionFileName = i.Options.OutputVersionFileName
+	}
+	readWriteFileName := "readwrite.go"
+	if i.Options.OutputReadWriteFileName != "" {
+		readWriteFileName = i.Options.OutputReadWriteFileName
+	}
+	backgroundFileName := "background.go"
+	if i.Options.OutputBackgroundFileName != "" {
+		backgroundFileName = i.Options.OutputBackgroundFileName
+	}
+	explainFileName := "explain.go"
+	if i.Options.OutputExplainFileName != "" {
+		explainFileName = i.Options.OutputExplainFileName
+	}
+	retryFileName := "retry.go"
+	if i.Options.OutputRetryFileName != "" {
+		retryFileName = i.Options.OutputRetryFileName
+	}
+	cacheFileName := "cache.go"
+	if i.Options.OutputCacheFileName != "" {
+		cacheFileName = i.Options.OutputCacheFileName
+	}
+	protoGoFileName := "proto.go"
+	if i.Options.OutputProtoGoFileName != "" {
+		protoGoFileName = i.Options.OutputProtoGoFileName
+	}
+	graphqlGoFileName := "graphql.go"
+	if i.Options.OutputGraphqlGoFileName != "" {
+		graphqlGoFileName = i.Options.OutputGraphqlGoFileName
+	}
+	fixturesFileName := "fixtures.go"
+	if i.Options.OutputFixturesFileName != "" {
+		fixturesFileName = i.Options.OutputFixturesFileName
+	}
+	integrationFileName := "queries_integration_test.go"
+	if i.Options.OutputIntegrationFileName != "" {
+		integrationFileName = i.Options.OutputIntegrationFileName
+	}
+
+	if i.SchemaPackage != "" {
+		return mergeImports(i.modelImports())
+	}
 
 	switch filename {
 	case dbFileName:
 		return mergeImports(i.dbImports())
 	case modelsFileName:
 		return mergeImports(i.modelImports())
+	case enumsFileName:
+		return mergeImports(i.enumImports())
 	case querierFileName:
 		return mergeImports(i.interfaceImports())
 	case copyfromFileName:
@@ -119,6 +236,36 @@ func (i *importer) Imports(filename string) [][]ImportSpec {
 		return mergeImports(i.batchImports())
 	case nestedUtilsFileName:
 		return mergeImports(i.nestedUtilsImports())
+	case grouperFileName:
+		return mergeImports(fileImports{})
+	case nullConvFileName:
+		return mergeImports(i.nullConvImports())
+	case rangesFileName:
+		return mergeImports(i.rangesImports())
+	case postgisFileName:
+		return mergeImports(i.postgisImports())
+	case docFileName:
+		return mergeImports(fileImports{})
+	case versionFileName:
+		return mergeImports(fileImports{})
+	case readWriteFileName:
+		return mergeImports(i.interfaceImports(), i.readWriteImports())
+	case backgroundFileName:
+		return mergeImports(i.interfaceImports())
+	case explainFileName:
+		return mergeImports(i.explainImports())
+	case retryFileName:
+		return mergeImports(i.methodImports(retriedQueries(i.Queries)), i.retryImports())
+	case cacheFileName:
+		return mergeImports(i.methodImports(cachedQueries(i.Queries)), i.cacheImports())
+	case protoGoFileName:
+		return mergeImports(i.protoImports())
+	case graphqlGoFileName:
+		return mergeImports(i.methodImports(graphqlQueries(i.Queries)))
+	case fixturesFileName:
+		return mergeImports(i.fixturesImports())
+	case integrationFileName:
+		return mergeImports(i.integrationTestImports())
 	}
 
 	if isNestedFileName(filename) {
@@ -139,14 +286,38 @@ func (i *importer) dbImports() fileImports {
 	case opts.SQLDriverPGXV4:
 		pkg = append(pkg, ImportSpec{Path: "github.com/jackc/pgconn"})
 		pkg = append(pkg, ImportSpec{Path: "github.com/jackc/pgx/v4"})
+		if i.Options.EmitPoolConstructor {
+			pkg = append(pkg, ImportSpec{Path: "github.com/jackc/pgx/v4/pgxpool"})
+		}
 	case opts.SQLDriverPGXV5:
 		pkg = append(pkg, ImportSpec{Path: "github.com/jackc/pgx/v5/pgconn"})
 		pkg = append(pkg, ImportSpec{Path: "github.com/jackc/pgx/v5"})
+		if i.Options.EmitPoolConstructor {
+			pkg = append(pkg, ImportSpec{Path: "github.com/jackc/pgx/v5/pgxpool"})
+		}
+		if i.Options.EmitPreparedQueries {
+			std = append(std, ImportSpec{Path: "fmt"})
+		}
 	default:
 		std = append(std, ImportSpec{Path: "database/sql"})
 		if i.Options.EmitPreparedQueries {
 			std = append(std, ImportSpec{Path: "fmt"})
 		}
+		if i.Options.PrepareLazily {
+			std = append(std, ImportSpec{Path: "sync"})
+		}
+	}
+
+	if sqlpkg.IsPGX() && usesKeyset(i.Queries) {
+		std = append(std, ImportSpec{Path: "encoding/base64"}, ImportSpec{Path: "encoding/json"})
+	}
+	if sqlpkg.IsPGX() && usesFilter(i.Queries) {
+		std = append(std, ImportSpec{Path: "strings"})
+	}
+	if sqlpkg.IsPGX() && (usesKeyset(i.Queries) || usesFilter(i.Queries)) {
+		if sqlpkg != opts.SQLDriverPGXV5 || !i.Options.EmitPreparedQueries {
+			std = append(std, ImportSpec{Path: "fmt"})
+		}
 	}
 
 	sort.Slice(std, func(i, j int) bool { return std[i].Path < std[j].Path })
@@ -170,7 +341,8 @@ var pqtypeTypes = map[string]struct{}{
 	"pqtype.NullRawMessage": {},
 }
 
-func buildImports(options *opts.Options, queries []Query, outputFile OutputFile, uses func(string) bool) (map[string]struct{}, map[ImportSpec]struct{}) {
+func (i *importer) buildImports(queries []Query, outputFile OutputFile, uses func(string) bool) (map[string]struct{}, map[ImportSpec]struct{}) {
+	options := i.Options
 	pkg := make(map[ImportSpec]struct{})
 	std := make(map[string]struct{})
 
@@ -199,7 +371,7 @@ func buildImports(options *opts.Options, queries []Query, outputFile OutputFile,
 	}
 
 	if uses("pgtype.") {
-		if sqlpkg == opts.SQLDriverPGXV5 {
+		if typesDriver(options) == opts.SQLDriverPGXV5 {
 			pkg[ImportSpec{Path: "github.com/jackc/pgx/v5/pgtype"}] = struct{}{}
 		} else {
 			pkg[ImportSpec{Path: "github.com/jackc/pgtype"}] = struct{}{}
@@ -234,8 +406,24 @@ func buildImports(options *opts.Options, queries []Query, outputFile OutputFile,
 	if uses("uuid.NullUUID") && !overrideNullUUID {
 		pkg[ImportSpec{Path: "github.com/google/uuid"}] = struct{}{}
 	}
+	_, overrideDecimal := overrideTypes["decimal.Decimal"]
+	if uses("decimal.") && !overrideDecimal {
+		pkg[ImportSpec{Path: "github.com/shopspring/decimal"}] = struct{}{}
+	}
+	_, overrideApd := overrideTypes["apd.Decimal"]
+	if uses("apd.") && !overrideApd {
+		pkg[ImportSpec{Path: "github.com/cockroachdb/apd/v3"}] = struct{}{}
+	}
+	_, overrideGeom := overrideTypes["geom.T"]
+	if uses("geom.") && !overrideGeom {
+		pkg[ImportSpec{Path: "github.com/twpayne/go-geom", ID: "geom"}] = struct{}{}
+	}
+	_, overrideOrb := overrideTypes["orb.Geometry"]
+	if uses("orb.") && !overrideOrb {
+		pkg[ImportSpec{Path: "github.com/paulmach/orb"}] = struct{}{}
+	}
 	_, overrideVector := overrideTypes["pgvector.Vector"]
-	if uses("pgvector.Vector") && !overrideVector {
+	if uses("pgvector.") && !overrideVector {
 		pkg[ImportSpec{Path: "github.com/pgvector/pgvector-go"}] = struct{}{}
 	}
 
@@ -265,23 +453,23 @@ func buildImports(options *opts.Options, queries []Query, outputFile OutputFile,
 			}
 
 			// Check if the return type struct contains a type from models package (possibly an enum field or an embedded struct)
-			if outputFile != OutputFileInterface && q.hasRetType() && q.Ret.IsStruct() {
+			if outputFile != OutputFileInterface && q.hasRetType() && q.Ret.IsStruct() && q.Ret.EmitStruct() {
 				for _, f := range q.Ret.Struct.Fields {
-					if strings.HasPrefix(f.Type, options.OutputModelsPackage+".") {
+					if hasPrefixIgnoringSliceAndPointerPrefix(f.Type, options.OutputModelsPackage+".") {
 						return true
 					}
 				}
 			}
 
 			// Check if the argument type is from models package (possibly an enum)
-			if !q.Arg.isEmpty() && strings.HasPrefix(q.Arg.Type(), options.OutputModelsPackage+".") {
+			if !q.Arg.isEmpty() && hasPrefixIgnoringSliceAndPointerPrefix(q.Arg.Type(), options.OutputModelsPackage+".") {
 				return true
 			}
 
 			// Check if the argument struct contains a type from models package (possibly an enum field)
-			if outputFile != OutputFileInterface && !q.Arg.isEmpty() && q.Arg.IsStruct() {
+			if outputFile != OutputFileInterface && !q.Arg.isEmpty() && q.Arg.IsStruct() && q.Arg.EmitStruct() {
 				for _, f := range q.Arg.Struct.Fields {
-					if strings.HasPrefix(f.Type, options.OutputModelsPackage+".") {
+					if hasPrefixIgnoringSliceAndPointerPrefix(f.Type, options.OutputModelsPackage+".") {
 						return true
 					}
 				}
@@ -295,12 +483,43 @@ func buildImports(options *opts.Options, queries []Query, outputFile OutputFile,
 		pkg[ImportSpec{Path: options.ModelsPackageImportPath}] = struct{}{}
 	}
 
+	// Models of other schemas are imported from their schema_packages
+	for _, name := range i.SchemaPackages {
+		if name != i.SchemaPackage && uses(name+".") {
+			pkg[ImportSpec{Path: schemaPackageImportPath(options, name)}] = struct{}{}
+		}
+	}
+	if i.SchemaPackage != "" && options.ModelsPackageImportPath != "" && uses(options.OutputModelsPackage+".") {
+		pkg[ImportSpec{Path: options.ModelsPackageImportPath}] = struct{}{}
+	}
+
 	return std, pkg
 }
 
 func (i *importer) interfaceImports() fileImports {
-	std, pkg := buildImports(i.Options, i.Queries, OutputFileInterface, func(name string) bool {
-		for _, q := range i.Queries {
+	std, pkg := i.methodImportSets(i.Queries)
+	if i.Options.EmitUnexportedQueries && !i.Options.EmitMethodsWithDbArgument {
+		// Querier declares WithTx of the unexported queries struct
+		switch parseDriver(i.Options.SqlPackage) {
+		case opts.SQLDriverPGXV4:
+			pkg[ImportSpec{Path: "github.com/jackc/pgx/v4"}] = struct{}{}
+		case opts.SQLDriverPGXV5:
+			pkg[ImportSpec{Path: "github.com/jackc/pgx/v5"}] = struct{}{}
+		default:
+			std["database/sql"] = struct{}{}
+		}
+	}
+	return sortedImports(std, pkg)
+}
+
+// methodImports returns the imports of the signatures of the query methods
+func (i *importer) methodImports(queries []Query) fileImports {
+	return sortedImports(i.methodImportSets(queries))
+}
+
+func (i *importer) methodImportSets(queries []Query) (map[string]struct{}, map[ImportSpec]struct{}) {
+	std, pkg := i.buildImports(queries, OutputFileInterface, func(name string) bool {
+		for _, q := range queries {
 			if q.hasRetType() {
 				if usesBatch([]Query{q}) {
 					continue
@@ -320,20 +539,47 @@ func (i *importer) interfaceImports() fileImports {
 
 	std["context"] = struct{}{}
 
-	return sortedImports(std, pkg)
+	return std, pkg
 }
 
 func (i *importer) modelImports() fileImports {
-	std, pkg := buildImports(i.Options, nil, OutputFileModel, i.usesType)
+	std, pkg := i.buildImports(nil, OutputFileModel, i.usesType)
 
 	if len(i.Enums) > 0 {
 		std["fmt"] = struct{}{}
 		std["database/sql/driver"] = struct{}{}
 	}
 
+	if usesHstore(i.Options, i.Structs, i.Queries) {
+		std["fmt"] = struct{}{}
+		std["database/sql/driver"] = struct{}{}
+		std["strings"] = struct{}{}
+	}
+	i.addRedactionImports(std, i.Structs, nil)
+
 	return sortedImports(std, pkg)
 }
 
+// addRedactionImports adds the imports of the String and LogValue methods of
+// the structs with sensitive fields
+func (i *importer) addRedactionImports(std map[string]struct{}, structs []Struct, queries []Query) {
+	if !usesRedaction(structs, queries) {
+		return
+	}
+	std["fmt"] = struct{}{}
+	if goVersionAtLeast(i.Options, slogGoVersion) {
+		std["log/slog"] = struct{}{}
+	}
+}
+
+func (i *importer) enumImports() fileImports {
+	std := map[string]struct{}{
+		"database/sql/driver": {},
+		"fmt":                 {},
+	}
+	return sortedImports(std, nil)
+}
+
 func sortedImports(std map[string]struct{}, pkg map[ImportSpec]struct{}) fileImports {
 	pkgs := make([]ImportSpec, 0, len(pkg))
 	for spec := range pkg {
@@ -344,26 +590,29 @@ func sortedImports(std map[string]struct{}, pkg map[ImportSpec]struct{}) fileImp
 		stds = append(stds, ImportSpec{Path: path})
 	}
 	sort.Slice(stds, func(i, j int) bool { return stds[i].Path < stds[j].Path })
-	sort.Slice(pkgs, func(i, j int) bool { return pkgs[i].Path < pkgs[j].Path })
+	sort.Slice(pkgs, func(i, j int) bool {
+		if pkgs[i].Path != pkgs[j].Path {
+			return pkgs[i].Path < pkgs[j].Path
+		}
+		return pkgs[i].ID < pkgs[j].ID
+	})
 	return fileImports{stds, pkgs}
 }
 
 func (i *importer) queryImports(filename string) fileImports {
 	var gq []Query
 	anyNonCopyFrom := false
-	for _, query := range i.Queries {
+	for _, query := range i.sourceQueries(filename) {
 		if usesBatch([]Query{query}) {
 			continue
 		}
-		if query.SourceName == filename {
-			gq = append(gq, query)
-			if query.Cmd != metadata.CmdCopyFrom {
-				anyNonCopyFrom = true
-			}
+		gq = append(gq, query)
+		if query.Cmd != metadata.CmdCopyFrom {
+			anyNonCopyFrom = true
 		}
 	}
 
-	std, pkg := buildImports(i.Options, gq, OutputFileQuery, func(name string) bool {
+	std, pkg := i.buildImports(gq, OutputFileQuery, func(name string) bool {
 		for _, q := range gq {
 			if q.hasRetType() {
 				if q.Ret.EmitStruct() {
@@ -373,6 +622,16 @@ func (i *importer) queryImports(filename string) fileImports {
 						}
 					}
 				}
+				// Check the variables the pgx templates scan embed columns into
+				if q.Ret.IsStruct() && parseDriver(i.Options.SqlPackage).IsPGX() {
+					for _, f := range q.Ret.Struct.Fields {
+						for _, embed := range f.EmbedFields {
+							if hasPrefixIgnoringSliceAndPointerPrefix(getEmbedScanType(embed, i.Options.OutputModelsPackage), name) {
+								return true
+							}
+						}
+					}
+				}
 				if hasPrefixIgnoringSliceAndPointerPrefix(q.Ret.Type(), name) {
 					return true
 				}
@@ -391,6 +650,22 @@ func (i *importer) queryImports(filename string) fileImports {
 					return true
 				}
 			}
+			// Check the keys decoded from the cursor of a page
+			if q.Keyset != nil {
+				for _, c := range q.Keyset.Columns {
+					if hasPrefixIgnoringSliceAndPointerPrefix(c.Type, name) {
+						return true
+					}
+				}
+			}
+			// Check the values of the conditions of a filter
+			if q.Filter != nil {
+				for _, c := range q.Filter.Conditions {
+					if hasPrefixIgnoringSliceAndPointerPrefix(c.Type, name) {
+						return true
+					}
+				}
+			}
 		}
 		return false
 	})
@@ -445,14 +720,33 @@ func (i *importer) queryImports(filename string) fileImports {
 	if anyNonCopyFrom {
 		std["context"] = struct{}{}
 	}
+	for _, q := range gq {
+		if q.Cmd != metadata.CmdCopyFrom && q.Timeout != "" {
+			std["time"] = struct{}{}
+		}
+	}
 
 	sqlpkg := parseDriver(i.Options.SqlPackage)
+	for _, q := range gq {
+		if q.Filter != nil && len(q.Filter.OrderBy) > 0 {
+			std["fmt"] = struct{}{}
+		}
+	}
+	if usesUpsert(gq) {
+		std["errors"] = struct{}{}
+		if sqlpkg == opts.SQLDriverPGXV4 {
+			pkg[ImportSpec{Path: "github.com/jackc/pgx/v4"}] = struct{}{}
+		} else {
+			pkg[ImportSpec{Path: "github.com/jackc/pgx/v5"}] = struct{}{}
+		}
+	}
 	if sqlcSliceScan() && !sqlpkg.IsPGX() {
 		std["strings"] = struct{}{}
 	}
 	if sliceScan() && !sqlpkg.IsPGX() {
 		pkg[ImportSpec{Path: "github.com/lib/pq"}] = struct{}{}
 	}
+	i.addRedactionImports(std, nil, gq)
 
 	return sortedImports(std, pkg)
 }
@@ -464,7 +758,7 @@ func (i *importer) copyfromImports() fileImports {
 			copyFromQueries = append(copyFromQueries, q)
 		}
 	}
-	std, pkg := buildImports(i.Options, copyFromQueries, OutputFileCopyfrom, func(name string) bool {
+	std, pkg := i.buildImports(copyFromQueries, OutputFileCopyfrom, func(name string) bool {
 		for _, q := range copyFromQueries {
 			if q.hasRetType() {
 				if strings.HasPrefix(q.Ret.Type(), name) {
@@ -481,12 +775,22 @@ func (i *importer) copyfromImports() fileImports {
 	})
 
 	std["context"] = struct{}{}
+	if usesTimeout(copyFromQueries) {
+		std["time"] = struct{}{}
+	}
+	if !parseDriver(i.Options.SqlPackage).IsPGX() && i.Options.SqlDriver != opts.SQLDriverGoSQLDriverMySQL {
+		std["database/sql"] = struct{}{}
+		std["strings"] = struct{}{}
+	}
 	if i.Options.SqlDriver == opts.SQLDriverGoSQLDriverMySQL {
 		std["io"] = struct{}{}
 		std["fmt"] = struct{}{}
 		std["sync/atomic"] = struct{}{}
 		pkg[ImportSpec{Path: "github.com/go-sql-driver/mysql"}] = struct{}{}
 		pkg[ImportSpec{Path: "github.com/hexon/mysqltsv"}] = struct{}{}
+		if usesCopyFromTimes(copyFromQueries) {
+			std["time"] = struct{}{}
+		}
 	}
 
 	return sortedImports(std, pkg)
@@ -499,7 +803,7 @@ func (i *importer) batchImports() fileImports {
 			batchQueries = append(batchQueries, q)
 		}
 	}
-	std, pkg := buildImports(i.Options, batchQueries, OutputFileBatch, func(name string) bool {
+	std, pkg := i.buildImports(batchQueries, OutputFileBatch, func(name string) bool {
 		for _, q := range batchQueries {
 			if q.hasRetType() {
 				if q.Ret.EmitStruct() {
@@ -531,6 +835,10 @@ func (i *importer) batchImports() fileImports {
 
 	std["context"] = struct{}{}
 	std["errors"] = struct{}{}
+	if i.Options.EmitBatchErrors {
+		std["fmt"] = struct{}{}
+	}
+	i.addRedactionImports(std, nil, batchQueries)
 	sqlpkg := parseDriver(i.Options.SqlPackage)
 	switch sqlpkg {
 	case opts.SQLDriverPGXV4:
@@ -543,15 +851,159 @@ func (i *importer) batchImports() fileImports {
 }
 
 func (i *importer) nestedCoreImports(filename string) fileImports {
-	var gq []Query
-	for _, query := range i.Queries {
-		if query.SourceName == extractSqlFileNameFromNestedFileName(filename) {
-			gq = append(gq, query)
+	gq := i.sourceQueries(extractSqlFileNameFromNestedFileName(i.Options, filename))
+	std, pkg := i.buildImports(gq, OutputFileModel, i.usesType)
+
+	return sortedImports(std, pkg)
+}
+
+// retryImports returns the imports the detection of transient errors needs,
+// the methods overridden by the retry decorator need those of their signatures
+func (i *importer) retryImports() fileImports {
+	std := []ImportSpec{{Path: "errors"}, {Path: "math/rand"}, {Path: "time"}}
+	switch parseDriver(i.Options.SqlPackage) {
+	case opts.SQLDriverPGXV4:
+		return fileImports{Std: std, Dep: []ImportSpec{{Path: "github.com/jackc/pgconn"}}}
+	case opts.SQLDriverPGXV5:
+		return fileImports{Std: std, Dep: []ImportSpec{{Path: "github.com/jackc/pgx/v5/pgconn"}}}
+	default:
+		return fileImports{Std: append(std, ImportSpec{Path: "database/sql/driver"})}
+	}
+}
+
+// explainImports returns the imports of the EXPLAIN methods, which take the
+// parameters of the queries and return the plan
+func (i *importer) explainImports() fileImports {
+	// Only the parameters of the queries appear in the methods
+	var queries []Query
+	for _, q := range explainedQueries(i.Queries) {
+		queries = append(queries, Query{Cmd: metadata.CmdExec, Arg: q.Arg})
+	}
+	std, pkg := i.buildImports(queries, OutputFileInterface, func(name string) bool {
+		for _, q := range queries {
+			for _, f := range q.Arg.Pairs() {
+				if hasPrefixIgnoringSliceAndPointerPrefix(f.Type, name) {
+					return true
+				}
+			}
+		}
+		return false
+	})
+	std["context"] = struct{}{}
+	for _, q := range queries {
+		if strings.Contains(q.Arg.Params(), "pq.Array(") {
+			pkg[ImportSpec{Path: "github.com/lib/pq"}] = struct{}{}
 		}
 	}
+	return sortedImports(std, pkg)
+}
 
-	std, pkg := buildImports(i.Options, gq, OutputFileModel, i.usesType)
+// cacheImports returns the imports the cache keys and ttls need and that of
+// the transaction type, the methods overridden by the caching decorator need
+// those of their signatures
+func (i *importer) cacheImports() fileImports {
+	tx := i.readWriteImports()
+	std := []ImportSpec{
+		{Path: "crypto/sha256"},
+		{Path: "encoding/hex"},
+		{Path: "encoding/json"},
+		{Path: "time"},
+	}
+	return fileImports{Std: append(std, tx.Std...), Dep: tx.Dep}
+}
 
+// readWriteImports returns the import of the transaction type of WithTx, the
+// query methods need the same imports as the Querier interface
+func (i *importer) readWriteImports() fileImports {
+	switch parseDriver(i.Options.SqlPackage) {
+	case opts.SQLDriverPGXV4:
+		return fileImports{Dep: []ImportSpec{{Path: "github.com/jackc/pgx/v4"}}}
+	case opts.SQLDriverPGXV5:
+		return fileImports{Dep: []ImportSpec{{Path: "github.com/jackc/pgx/v5"}}}
+	default:
+		return fileImports{Std: []ImportSpec{{Path: "database/sql"}}}
+	}
+}
+
+// protoImports returns the imports of the protobuf converters, the package
+// protoc generates for the messages is imported as pb
+func (i *importer) protoImports() fileImports {
+	std := map[string]struct{}{}
+	if i.Proto.usesPackage("sql") {
+		std["database/sql"] = struct{}{}
+	}
+	pkg := map[ImportSpec]struct{}{{ID: "pb", Path: i.Proto.GoPackage}: {}}
+	for name, path := range map[string]string{
+		"pgtype":      "github.com/jackc/pgx/v5/pgtype",
+		"uuid":        "github.com/google/uuid",
+		"proto":       "google.golang.org/protobuf/proto",
+		"timestamppb": "google.golang.org/protobuf/types/known/timestamppb",
+	} {
+		if i.Proto.usesPackage(name) {
+			pkg[ImportSpec{Path: path}] = struct{}{}
+		}
+	}
+	if i.Options.ModelsPackageImportPath != "" && i.Proto.usesPackage(i.Options.OutputModelsPackage) {
+		pkg[ImportSpec{Path: i.Options.ModelsPackageImportPath}] = struct{}{}
+	}
+	return sortedImports(std, pkg)
+}
+
+func (i *importer) fixturesImports() fileImports {
+	std := map[string]struct{}{}
+	for name, path := range map[string]string{
+		"sql":  "database/sql",
+		"json": "encoding/json",
+		"big":  "math/big",
+		"time": "time",
+	} {
+		if i.Fixtures.usesPackage(name) {
+			std[path] = struct{}{}
+		}
+	}
+	pkg := map[ImportSpec]struct{}{}
+	for name, path := range map[string]string{
+		"pgtype": "github.com/jackc/pgx/v5/pgtype",
+		"uuid":   "github.com/google/uuid",
+	} {
+		if i.Fixtures.usesPackage(name) {
+			pkg[ImportSpec{Path: path}] = struct{}{}
+		}
+	}
+	if i.Options.ModelsPackageImportPath != "" && i.Fixtures.usesPackage(i.Options.OutputModelsPackage) {
+		pkg[ImportSpec{Path: i.Options.ModelsPackageImportPath}] = struct{}{}
+	}
+	return sortedImports(std, pkg)
+}
+
+func (i *importer) integrationTestImports() fileImports {
+	std := map[string]struct{}{
+		"context":       {},
+		"errors":        {},
+		"os":            {},
+		"path/filepath": {},
+		"reflect":       {},
+		"testing":       {},
+	}
+	pkg := map[ImportSpec]struct{}{}
+	switch sqlpkg := parseDriver(i.Options.SqlPackage); {
+	case sqlpkg == opts.SQLDriverPGXV4:
+		pkg[ImportSpec{Path: "github.com/jackc/pgx/v4"}] = struct{}{}
+	case sqlpkg == opts.SQLDriverPGXV5:
+		pkg[ImportSpec{Path: "github.com/jackc/pgx/v5"}] = struct{}{}
+	default:
+		std["database/sql"] = struct{}{}
+	}
+	if i.IntegrationTest.Engine == "mysql" {
+		pkg[ImportSpec{Path: i.IntegrationTest.DriverImport}] = struct{}{}
+		pkg[ImportSpec{ID: "tcmysql", Path: "github.com/testcontainers/testcontainers-go/modules/mysql"}] = struct{}{}
+	} else {
+		std["strings"] = struct{}{}
+		if i.IntegrationTest.DriverImport != "" {
+			pkg[ImportSpec{ID: "_", Path: i.IntegrationTest.DriverImport}] = struct{}{}
+		}
+		pkg[ImportSpec{Path: "github.com/testcontainers/testcontainers-go/modules/postgres"}] = struct{}{}
+	}
 	return sortedImports(std, pkg)
 }
 
@@ -563,10 +1015,51 @@ func (i *importer) nestedUtilsImports() fileImports {
 	}
 }
 
+func (i *importer) nullConvImports() fileImports {
+	var std []ImportSpec
+	if usesTimeConversion(buildNullConversions(i.Structs, i.Queries)) {
+		std = append(std, ImportSpec{Path: "time"})
+	}
+	return fileImports{
+		Std: std,
+		Dep: []ImportSpec{{Path: "github.com/jackc/pgx/v5/pgtype"}},
+	}
+}
+
+func (i *importer) rangesImports() fileImports {
+	var std []ImportSpec
+	if usesTimeRange(buildRangeHelpers(i.Structs, i.Queries)) {
+		std = append(std, ImportSpec{Path: "time"})
+	}
+	return fileImports{
+		Std: std,
+		Dep: []ImportSpec{{Path: "github.com/jackc/pgx/v5/pgtype"}},
+	}
+}
+
+func (i *importer) postgisImports() fileImports {
+	return fileImports{
+		Std: []ImportSpec{{Path: "context"}, {Path: "database/sql/driver"}, {Path: "errors"}},
+		Dep: []ImportSpec{
+			{Path: "github.com/jackc/pgx/v5"},
+			{Path: "github.com/jackc/pgx/v5/pgtype"},
+			{Path: "github.com/paulmach/orb"},
+			{Path: "github.com/paulmach/orb/encoding/ewkb"},
+		},
+	}
+}
+
 func trimSliceAndPointerPrefix(v string) string {
-	v = strings.TrimPrefix(v, "[]")
-	v = strings.TrimPrefix(v, "*")
-	return v
+	for {
+		switch {
+		case strings.HasPrefix(v, "[]"):
+			v = v[2:]
+		case strings.HasPrefix(v, "*"):
+			v = v[1:]
+		default:
+			return v
+		}
+	}
 }
 
 func hasPrefixIgnoringSliceAndPointerPrefix(s, prefix string) bool {
@@ -0,0 +1,82 @@
+package golang
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/sqlc-dev/plugin-sdk-go/metadata"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+func TestSourceQueries(t *testing.T) {
+	i := &importer{Queries: []Query{
+		{MethodName: "GetAuthor", SourceName: "authors.sql"},
+		{MethodName: "ListBooks", SourceName: "books.sql"},
+		{MethodName: "ListAuthors", SourceName: "authors.sql"},
+	}}
+	for name, want := range map[string][]string{
+		"authors.sql": {"GetAuthor", "ListAuthors"},
+		"books.sql":   {"ListBooks"},
+		"tags.sql":    nil,
+	} {
+		var got []string
+		for _, q := range i.sourceQueries(name) {
+			got = append(got, q.MethodName)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("sourceQueries(%s) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestImportsCache(t *testing.T) {
+	timeField := []Struct{{Name: "Author", Fields: []Field{{Name: "CreatedAt", Type: "time.Time"}}}}
+	i := &importer{
+		Options: &opts.Options{SqlPackage: "pgx/v5"},
+		Queries: []Query{
+			{MethodName: "GetAuthor", Cmd: metadata.CmdOne, SourceName: "authors.sql", Arg: QueryValue{Name: "createdAt", Typ: "time.Time"}, Ret: QueryValue{Name: "id", Typ: "int64"}},
+			{MethodName: "ListBooks", Cmd: metadata.CmdMany, SourceName: "books.sql", Ret: QueryValue{Name: "title", Typ: "string"}},
+		},
+		Structs: timeField,
+	}
+	usesTime := func(imports [][]ImportSpec) bool {
+		return slices.Contains(imports[0], ImportSpec{Path: "time"})
+	}
+
+	if !usesTime(i.Imports("authors.sql")) {
+		t.Errorf("authors.sql does not import time: %v", i.Imports("authors.sql"))
+	}
+	if usesTime(i.Imports("books.sql")) {
+		t.Errorf("books.sql imports time: %v", i.Imports("books.sql"))
+	}
+	if !usesTime(i.Imports("models.go")) {
+		t.Errorf("models.go does not import time: %v", i.Imports("models.go"))
+	}
+
+	// Switching the models invalidates the imports computed for them
+	i.setModels(nil, []Struct{{Name: "Author", Fields: []Field{{Name: "ID", Type: "int64"}}}}, "")
+	if usesTime(i.Imports("models.go")) {
+		t.Errorf("models.go still imports time after setModels: %v", i.Imports("models.go"))
+	}
+}
+
+func TestReplaceConflictedArg(t *testing.T) {
+	imports := [][]ImportSpec{{{Path: "time"}}, {{Path: "github.com/google/uuid"}}}
+	queries := []Query{
+		{MethodName: "GetEvent", Arg: QueryValue{Name: "time"}},
+		{MethodName: "GetUser", Arg: QueryValue{Name: "uuid"}},
+		{MethodName: "GetAuthor", Arg: QueryValue{Name: "id"}},
+	}
+	var got []string
+	for _, q := range replaceConflictedArg(imports, queries) {
+		got = append(got, q.Arg.Name)
+	}
+	if want := []string{"argTime", "argUUID", "id"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("replaceConflictedArg() names the arguments %q, want %q", got, want)
+	}
+	if queries[0].Arg.Name != "time" {
+		t.Errorf("replaceConflictedArg() modified the queries it was given")
+	}
+}
@@ -0,0 +1,162 @@
+package golang
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+	"google.golang.org/protobuf/proto"
+)
+
+// queryFileInputs hashes the inputs of the query and nested files generated
+// from each SQL file, keyed by file name. The hash covers the options, the
+// catalog, the queries contributing to output shared between files and the
+// queries of the SQL file, so that a file is only reused when nothing it
+// could be generated from has changed.
+func queryFileInputs(req *plugin.GenerateRequest, options *opts.Options) (map[string]string, error) {
+	marshal := proto.MarshalOptions{Deterministic: true}
+	common := sha256.New()
+	// The settings repeat the plugin options, which are hashed without
+	// incremental_manifest below
+	settings := &plugin.Settings{}
+	if req.Settings != nil {
+		settings = proto.Clone(req.Settings).(*plugin.Settings)
+	}
+	if settings.Codegen != nil {
+		settings.Codegen.Options = nil
+	}
+	for _, m := range []proto.Message{settings, req.Catalog} {
+		b, err := marshal.Marshal(m)
+		if err != nil {
+			return nil, err
+		}
+		common.Write(b)
+	}
+	common.Write([]byte(req.SqlcVersion))
+	pluginOptions, err := hashedPluginOptions(req.PluginOptions)
+	if err != nil {
+		return nil, err
+	}
+	common.Write(pluginOptions)
+	common.Write(req.GlobalOptions)
+	for _, q := range req.Queries {
+		if !sharesOutput(options, q) {
+			continue
+		}
+		b, err := marshal.Marshal(q)
+		if err != nil {
+			return nil, err
+		}
+		common.Write(b)
+	}
+
+	hashes := map[string][]byte{}
+	var names []string
+	for _, q := range req.Queries {
+		filename, dir := q.Filename, ""
+		if p := packageFor(options, q.Filename); p != nil {
+			filename, dir = path.Base(opts.SlashPath(filename)), p.Out
+		}
+		source := querySourceName(options, filename)
+		name := path.Join(dir, queryFileName(options, source))
+		if _, ok := hashes[name]; !ok {
+			hashes[name] = common.Sum(nil)
+			names = append(names, name, path.Join(dir, getNestedFileName(options, source)))
+		}
+		b, err := marshal.Marshal(q)
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(append(hashes[name], b...))
+		hashes[name] = sum[:]
+	}
+
+	inputs := map[string]string{}
+	for i := 0; i < len(names); i += 2 {
+		inputs[names[i]] = hex.EncodeToString(hashes[names[i]])
+		inputs[names[i+1]] = inputs[names[i]]
+	}
+	return inputs, nil
+}
+
+// sharedAnnotations are the annotations adding helpers to db.go
+var sharedAnnotations = []string{annotationUpsert, annotationPaginate, annotationFilter, annotationOrderBy}
+
+// sharesOutput reports whether a query contributes to output shared between
+// files. The struct roots of nested queries are generated in the first
+// nested file using them, and some annotations add helpers to db.go.
+func sharesOutput(options *opts.Options, q *plugin.Query) bool {
+	if options.Nested != nil {
+		name, source := QueryName(q.Name, options), path.Base(opts.SlashPath(q.Filename))
+		for _, config := range options.Nested.Queries {
+			if config.Query == name || config.Query == source {
+				return true
+			}
+		}
+	}
+	_, annotations := splitAnnotations(q.Comments)
+	for _, key := range sharedAnnotations {
+		if _, ok := annotations[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// readManifest reads the manifest the previous run wrote to out, for
+// incremental. sqlc runs process plugins in its working directory, which out
+// is relative to. Without a previous manifest, or when the plugin runs as a
+// WASM module that cannot read files, every file is generated.
+func readManifest(req *plugin.GenerateRequest, options *opts.Options) (*opts.Manifest, error) {
+	name := filepath.FromSlash(manifestFileName(options))
+	if out := req.GetSettings().GetCodegen().GetOut(); out != "" {
+		name = filepath.Join(filepath.FromSlash(opts.SlashPath(out)), name)
+	}
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return &opts.Manifest{}, nil
+	}
+	var manifest opts.Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("incremental: reading %s: %w", name, err)
+	}
+	return &manifest, nil
+}
+
+// hashedPluginOptions returns the plugin options without
+// incremental_manifest, which changes with every run without changing the
+// generated code
+func hashedPluginOptions(pluginOptions []byte) ([]byte, error) {
+	if len(pluginOptions) == 0 {
+		return nil, nil
+	}
+	var options map[string]json.RawMessage
+	if err := json.Unmarshal(pluginOptions, &options); err != nil {
+		return nil, err
+	}
+	delete(options, "incremental_manifest")
+	return json.Marshal(options)
+}
+
+// unchangedFiles returns the files of the previous run whose inputs did not
+// change, which do not need to be generated again. They are left out of the
+// response so that sqlc keeps them as they are on disk, the plugin cannot
+// read them back, and the manifest carries over their entries.
+func unchangedFiles(previous *opts.Manifest, inputs map[string]string) map[string]struct{} {
+	unchanged := map[string]struct{}{}
+	if previous == nil {
+		return unchanged
+	}
+	for _, f := range previous.Files {
+		if f.Inputs != "" && f.Inputs == inputs[f.Name] {
+			unchanged[f.Name] = struct{}{}
+		}
+	}
+	return unchanged
+}
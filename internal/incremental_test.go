@@ -0,0 +1,200 @@
+package golang
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+func TestIncrementalManifest(t *testing.T) {
+	generate := func(req *plugin.GenerateRequest, previous *opts.Manifest) (map[string]*plugin.File, *opts.Manifest) {
+		t.Helper()
+		var options map[string]any
+		if err := json.Unmarshal(req.PluginOptions, &options); err != nil {
+			t.Fatal(err)
+		}
+		options["emit_manifest"] = true
+		options["incremental_manifest"] = previous
+		req.PluginOptions, _ = json.Marshal(options)
+		// sqlc repeats the plugin options in the settings
+		req.Settings.Codegen = &plugin.Codegen{Out: "db", Plugin: "golang", Options: req.PluginOptions}
+		resp, err := Generate(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files := map[string]*plugin.File{}
+		for _, f := range resp.Files {
+			files[f.Name] = f
+		}
+		var manifest opts.Manifest
+		if err := json.Unmarshal(files["manifest.json"].Contents, &manifest); err != nil {
+			t.Fatal(err)
+		}
+		return files, &manifest
+	}
+
+	req := syntheticRequest(2, 4, 0)
+	first, manifest := generate(req, &opts.Manifest{})
+	for _, name := range []string{"table0.sql.go", "table1.sql.go"} {
+		if _, ok := first[name]; !ok {
+			t.Fatalf("the first run does not generate %s", name)
+		}
+	}
+
+	for _, q := range req.Queries {
+		if q.Filename == "table1.sql" {
+			q.Text += " LIMIT 10"
+		}
+	}
+	second, next := generate(req, manifest)
+	if _, ok := second["table0.sql.go"]; ok {
+		t.Errorf("table0.sql.go is generated again although its inputs did not change")
+	}
+	if _, ok := second["table1.sql.go"]; !ok {
+		t.Errorf("table1.sql.go is not generated again although its queries changed")
+	}
+	entry := func(m *opts.Manifest, name string) opts.ManifestFile {
+		for _, f := range m.Files {
+			if f.Name == name {
+				return f
+			}
+		}
+		t.Fatalf("the manifest does not list %s", name)
+		return opts.ManifestFile{}
+	}
+	if got, want := entry(next, "table0.sql.go"), entry(manifest, "table0.sql.go"); got.SHA256 != want.SHA256 || got.Inputs != want.Inputs {
+		t.Errorf("the manifest entry of the skipped table0.sql.go is %+v, want %+v", got, want)
+	}
+	if got, want := entry(next, "table1.sql.go"), entry(manifest, "table1.sql.go"); got.Inputs == want.Inputs {
+		t.Errorf("the inputs of table1.sql.go did not change")
+	}
+}
+
+func TestQueryFileInputsSharedQueries(t *testing.T) {
+	request := func(listAuthors, getAuthor []string) *plugin.GenerateRequest {
+		return &plugin.GenerateRequest{
+			Settings: &plugin.Settings{Engine: "postgresql"},
+			Catalog:  &plugin.Catalog{DefaultSchema: "public"},
+			Queries: []*plugin.Query{
+				{Name: "ListAuthors", Cmd: ":many", Text: listAuthors[0], Comments: listAuthors[1:], Filename: "authors.sql"},
+				{Name: "GetAuthor", Cmd: ":one", Text: getAuthor[0], Comments: getAuthor[1:], Filename: "authors.sql"},
+				{Name: "ListBooks", Cmd: ":many", Text: "SELECT * FROM books", Filename: "books.sql"},
+			},
+		}
+	}
+	nested := &opts.Options{Nested: &opts.NestedConfig{Queries: []*opts.NestedQueryConfig{{Query: "ListAuthors", StructRoot: "AuthorGroup"}}}}
+
+	for _, test := range []struct {
+		name           string
+		options        *opts.Options
+		before, after  *plugin.GenerateRequest
+		booksUnchanged bool
+	}{
+		{
+			"own queries only",
+			&opts.Options{},
+			request([]string{"SELECT * FROM authors"}, []string{"SELECT * FROM authors WHERE id = $1"}),
+			request([]string{"SELECT * FROM authors"}, []string{"SELECT * FROM authors WHERE id = $1 LIMIT 1"}),
+			true,
+		},
+		{
+			"nested query",
+			nested,
+			request([]string{"SELECT * FROM authors"}, []string{"SELECT * FROM authors WHERE id = $1"}),
+			request([]string{"SELECT * FROM authors ORDER BY id"}, []string{"SELECT * FROM authors WHERE id = $1"}),
+			false,
+		},
+		{
+			"query of a nested file",
+			&opts.Options{Nested: &opts.NestedConfig{Queries: []*opts.NestedQueryConfig{{Query: "authors.sql"}}}},
+			request([]string{"SELECT * FROM authors"}, []string{"SELECT * FROM authors WHERE id = $1"}),
+			request([]string{"SELECT * FROM authors"}, []string{"SELECT * FROM authors WHERE id = $1 LIMIT 1"}),
+			false,
+		},
+		{
+			"db.go helpers",
+			&opts.Options{},
+			request([]string{"SELECT * FROM authors", " @paginate id"}, []string{"SELECT * FROM authors WHERE id = $1"}),
+			request([]string{"SELECT * FROM authors", " @paginate id DESC"}, []string{"SELECT * FROM authors WHERE id = $1"}),
+			false,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			before, err := queryFileInputs(test.before, test.options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			after, err := queryFileInputs(test.after, test.options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if before["authors.sql.go"] == after["authors.sql.go"] {
+				t.Error("the inputs of authors.sql.go did not change with its queries")
+			}
+			if unchanged := before["books.sql.go"] == after["books.sql.go"]; unchanged != test.booksUnchanged {
+				t.Errorf("the inputs of books.sql.go are unchanged: %t, want %t", unchanged, test.booksUnchanged)
+			}
+		})
+	}
+}
+
+// TestIncremental generates twice with incremental, the second run reads
+// the manifest the first one wrote to out
+func TestIncremental(t *testing.T) {
+	out := t.TempDir()
+	generate := func(req *plugin.GenerateRequest) map[string]*plugin.File {
+		t.Helper()
+		var options map[string]any
+		if err := json.Unmarshal(req.PluginOptions, &options); err != nil {
+			t.Fatal(err)
+		}
+		options["emit_manifest"] = true
+		options["incremental"] = true
+		req.PluginOptions, _ = json.Marshal(options)
+		req.Settings.Codegen = &plugin.Codegen{Out: out, Plugin: "golang", Options: req.PluginOptions}
+		resp, err := Generate(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files := map[string]*plugin.File{}
+		for _, f := range resp.Files {
+			files[f.Name] = f
+			// sqlc writes the files of the response to out
+			name := filepath.Join(out, filepath.FromSlash(f.Name))
+			if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(name, f.Contents, 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return files
+	}
+
+	first := generate(syntheticRequest(2, 4, 0))
+	for _, name := range []string{"table0.sql.go", "table1.sql.go", "manifest.json"} {
+		if _, ok := first[name]; !ok {
+			t.Fatalf("the first run does not generate %s", name)
+		}
+	}
+
+	req := syntheticRequest(2, 4, 0)
+	for _, q := range req.Queries {
+		if q.Filename == "table1.sql" {
+			q.Text += " LIMIT 10"
+		}
+	}
+	second := generate(req)
+	if _, ok := second["table0.sql.go"]; ok {
+		t.Errorf("table0.sql.go is generated again although its inputs did not change")
+	}
+	if _, ok := second["table1.sql.go"]; !ok {
+		t.Errorf("table1.sql.go is not generated again although its queries changed")
+	}
+}
@@ -0,0 +1,549 @@
+package inflection
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Pluralize and Singularize name the fields of nested composites. They follow the
+// rules of github.com/gobuffalo/flect (MIT License, Copyright (c) 2019 Mark
+// Bates), so that field names are unchanged, but build the rule tables on
+// first use: flect builds them when the plugin starts, which was most of its
+// start-up time even when there are no nested queries.
+
+type word struct {
+	singular       string
+	plural         string
+	alternative    string
+	unidirectional bool // plural to singular is not possible (or bad)
+	uncountable    bool
+	exact          bool
+}
+
+// dictionary is the main table for Singular and Plural, its words are also
+// suffix rules for compound words unless they are exact
+var dictionary = []word{
+	// identicals https://en.wikipedia.org/wiki/English_plurals#Nouns_with_identical_singular_and_plural
+	{singular: "aircraft", plural: "aircraft"},
+	{singular: "beef", plural: "beef", alternative: "beefs"},
+	{singular: "bison", plural: "bison"},
+	{singular: "blues", plural: "blues", unidirectional: true},
+	{singular: "chassis", plural: "chassis"},
+	{singular: "deer", plural: "deer"},
+	{singular: "fish", plural: "fish", alternative: "fishes"},
+	{singular: "moose", plural: "moose"},
+	{singular: "police", plural: "police"},
+	{singular: "salmon", plural: "salmon", alternative: "salmons"},
+	{singular: "series", plural: "series"},
+	{singular: "sheep", plural: "sheep"},
+	{singular: "shrimp", plural: "shrimp", alternative: "shrimps"},
+	{singular: "species", plural: "species"},
+	{singular: "swine", plural: "swine", alternative: "swines"},
+	{singular: "trout", plural: "trout", alternative: "trouts"},
+	{singular: "tuna", plural: "tuna", alternative: "tunas"},
+	{singular: "you", plural: "you"},
+	// -en https://en.wikipedia.org/wiki/English_plurals#Plurals_in_-(e)n
+	{singular: "child", plural: "children"},
+	{singular: "ox", plural: "oxen", exact: true},
+	// apophonic https://en.wikipedia.org/wiki/English_plurals#Apophonic_plurals
+	{singular: "foot", plural: "feet"},
+	{singular: "goose", plural: "geese"},
+	{singular: "man", plural: "men"},
+	{singular: "human", plural: "humans"}, // not humen
+	{singular: "louse", plural: "lice", exact: true},
+	{singular: "mouse", plural: "mice"},
+	{singular: "tooth", plural: "teeth"},
+	{singular: "woman", plural: "women"},
+	// misc https://en.wikipedia.org/wiki/English_plurals#Miscellaneous_irregular_plurals
+	{singular: "die", plural: "dice", exact: true},
+	{singular: "person", plural: "people"},
+
+	// Words from French that end in -u add an x; in addition to eau to eaux rule
+	{singular: "adieu", plural: "adieux", alternative: "adieus"},
+	{singular: "fabliau", plural: "fabliaux"},
+	{singular: "bureau", plural: "bureaus", alternative: "bureaux"}, // popular
+
+	// Words from Greek that end in -on change -on to -a; in addition to hedron rule
+	{singular: "criterion", plural: "criteria"},
+	{singular: "ganglion", plural: "ganglia", alternative: "ganglions"},
+	{singular: "lexicon", plural: "lexica", alternative: "lexicons"},
+	{singular: "mitochondrion", plural: "mitochondria", alternative: "mitochondrions"},
+	{singular: "noumenon", plural: "noumena"},
+	{singular: "phenomenon", plural: "phenomena"},
+	{singular: "taxon", plural: "taxa"},
+
+	// Words from Latin that end in -um change -um to -a; in addition to some rules
+	{singular: "media", plural: "media"}, // popular case: media -> media
+	{singular: "medium", plural: "media", alternative: "mediums", unidirectional: true},
+	{singular: "stadium", plural: "stadiums", alternative: "stadia"},
+	{singular: "aquarium", plural: "aquaria", alternative: "aquariums"},
+	{singular: "auditorium", plural: "auditoria", alternative: "auditoriums"},
+	{singular: "symposium", plural: "symposia", alternative: "symposiums"},
+	{singular: "curriculum", plural: "curriculums", alternative: "curricula"}, // ulum
+	{singular: "quota", plural: "quotas"},
+
+	// Words from Latin that end in -us change -us to -i or -era
+	{singular: "alumnus", plural: "alumni", alternative: "alumnuses"}, // -i
+	{singular: "bacillus", plural: "bacilli"},
+	{singular: "cactus", plural: "cacti", alternative: "cactuses"},
+	{singular: "coccus", plural: "cocci"},
+	{singular: "focus", plural: "foci", alternative: "focuses"},
+	{singular: "locus", plural: "loci", alternative: "locuses"},
+	{singular: "nucleus", plural: "nuclei", alternative: "nucleuses"},
+	{singular: "octopus", plural: "octupuses", alternative: "octopi"},
+	{singular: "radius", plural: "radii", alternative: "radiuses"},
+	{singular: "syllabus", plural: "syllabi"},
+	{singular: "corpus", plural: "corpora", alternative: "corpuses"}, // -ra
+	{singular: "genus", plural: "genera"},
+
+	// Words from Latin that end in -a change -a to -ae
+	{singular: "alumna", plural: "alumnae"},
+	{singular: "vertebra", plural: "vertebrae"},
+	{singular: "differentia", plural: "differentiae"}, // -tia
+	{singular: "minutia", plural: "minutiae"},
+	{singular: "vita", plural: "vitae"},   // -ita
+	{singular: "larva", plural: "larvae"}, // -va
+	{singular: "postcava", plural: "postcavae"},
+	{singular: "praecava", plural: "praecavae"},
+	{singular: "uva", plural: "uvae"},
+
+	// Words from Latin that end in -ex change -ex to -ices
+	{singular: "apex", plural: "apices", alternative: "apexes"},
+	{singular: "codex", plural: "codices", alternative: "codexes"},
+	{singular: "index", plural: "indices", alternative: "indexes"},
+	{singular: "latex", plural: "latices", alternative: "latexes"},
+	{singular: "vertex", plural: "vertices", alternative: "vertexes"},
+	{singular: "vortex", plural: "vortices", alternative: "vortexes"},
+
+	// Words from Latin that end in -ix change -ix to -ices (eg, matrix becomes matrices)
+	{singular: "appendix", plural: "appendices", alternative: "appendixes"},
+	{singular: "radix", plural: "radices", alternative: "radixes"},
+	{singular: "helix", plural: "helices", alternative: "helixes"},
+
+	// Words from Latin that end in -is change -is to -es
+	{singular: "axis", plural: "axes", exact: true},
+	{singular: "crisis", plural: "crises"},
+	{singular: "ellipsis", plural: "ellipses", unidirectional: true}, // ellipse
+	{singular: "genesis", plural: "geneses"},
+	{singular: "oasis", plural: "oases"},
+	{singular: "thesis", plural: "theses"},
+	{singular: "testis", plural: "testes"},
+	{singular: "base", plural: "bases"}, // popular case
+	{singular: "basis", plural: "bases", unidirectional: true},
+
+	{singular: "alias", plural: "aliases", exact: true}, // no alia, no aliasis
+	{singular: "vedalia", plural: "vedalias"},           // no vedalium, no vedaliases
+
+	// Words that end in -ch, -o, -s, -sh, -x, -z (can be conflict with the others)
+	{singular: "use", plural: "uses", exact: true}, // us vs use
+	{singular: "abuse", plural: "abuses"},
+	{singular: "cause", plural: "causes"},
+	{singular: "clause", plural: "clauses"},
+	{singular: "cruse", plural: "cruses"},
+	{singular: "excuse", plural: "excuses"},
+	{singular: "fuse", plural: "fuses"},
+	{singular: "house", plural: "houses"},
+	{singular: "misuse", plural: "misuses"},
+	{singular: "muse", plural: "muses"},
+	{singular: "pause", plural: "pauses"},
+	{singular: "ache", plural: "aches"},
+	{singular: "topaz", plural: "topazes"},
+	{singular: "buffalo", plural: "buffaloes", alternative: "buffalos"},
+	{singular: "potato", plural: "potatoes"},
+	{singular: "tomato", plural: "tomatoes"},
+
+	// uncountables
+	{singular: "equipment", uncountable: true},
+	{singular: "information", uncountable: true},
+	{singular: "jeans", uncountable: true},
+	{singular: "money", uncountable: true},
+	{singular: "news", uncountable: true},
+	{singular: "rice", uncountable: true},
+
+	// exceptions: -f to -ves, not -fe
+	{singular: "dwarf", plural: "dwarfs", alternative: "dwarves"},
+	{singular: "hoof", plural: "hoofs", alternative: "hooves"},
+	{singular: "thief", plural: "thieves"},
+	// exceptions: instead of -f(e) to -ves
+	{singular: "chive", plural: "chives"},
+	{singular: "hive", plural: "hives"},
+	{singular: "move", plural: "moves"},
+
+	// exceptions: instead of -y to -ies
+	{singular: "movie", plural: "movies"},
+	{singular: "cookie", plural: "cookies"},
+
+	// exceptions: instead of -um to -a
+	{singular: "pretorium", plural: "pretoriums"},
+	{singular: "agenda", plural: "agendas"}, // instead of plural of agendum
+	// exceptions: instead of -um to -a (chemical element names)
+
+	// Words from Latin that end in -a change -a to -ae
+	{singular: "formula", plural: "formulas", alternative: "formulae"}, // also -um/-a
+
+	// exceptions: instead of -o to -oes
+	{singular: "shoe", plural: "shoes"},
+	{singular: "toe", plural: "toes", exact: true},
+	{singular: "graffiti", plural: "graffiti"},
+
+	// abbreviations
+	{singular: "ID", plural: "IDs", exact: true},
+}
+
+// suffixes are the suffix rules of irregular plurals. The first match is
+// used, so the order is the priority of the rules.
+var suffixes = []struct{ singular, plural string }{
+	// https://en.wiktionary.org/wiki/Appendix:English_irregular_nouns#Rules
+	// Words that end in -f or -fe change -f or -fe to -ves
+	{"tive", "tives"}, // exception
+	{"eaf", "eaves"},
+	{"oaf", "oaves"},
+	{"afe", "aves"},
+	{"arf", "arves"},
+	{"rfe", "rves"},
+	{"rf", "rves"},
+	{"lf", "lves"},
+	{"fe", "ves"}, // previously '[a-eg-km-z]fe' TODO: regex support
+
+	// Words that end in -y preceded by a consonant change -y to -ies
+	{"ay", "ays"},
+	{"ey", "eys"},
+	{"oy", "oys"},
+	{"quy", "quies"},
+	{"uy", "uys"},
+	{"y", "ies"}, // '[^aeiou]y'
+
+	// Words from French that end in -u add an x (eg, château becomes châteaux)
+	{"eau", "eaux"}, // it seems like 'eau' is the most popular form of this rule
+
+	// Words from Latin that end in -a change -a to -ae; before -on to -a and -um to -a
+	{"bula", "bulae"},
+	{"dula", "bulae"},
+	{"lula", "bulae"},
+	{"nula", "bulae"},
+	{"vula", "bulae"},
+
+	// Words from Greek that end in -on change -on to -a (eg, polyhedron becomes polyhedra)
+	// https://en.wiktionary.org/wiki/Category:English_irregular_plurals_ending_in_"-a"
+	{"hedron", "hedra"},
+
+	// Words from Latin that end in -um change -um to -a (eg, minimum becomes minima)
+	// https://en.wiktionary.org/wiki/Category:English_irregular_plurals_ending_in_"-a"
+	{"ium", "ia"}, // some exceptions especially chemical element names
+	{"seum", "seums"},
+	{"eum", "ea"},
+	{"oum", "oa"},
+	{"stracum", "straca"},
+	{"dum", "da"},
+	{"elum", "ela"},
+	{"ilum", "ila"},
+	{"olum", "ola"},
+	{"ulum", "ula"},
+	{"llum", "lla"},
+	{"ylum", "yla"},
+	{"imum", "ima"},
+	{"ernum", "erna"},
+	{"gnum", "gna"},
+	{"brum", "bra"},
+	{"crum", "cra"},
+	{"terum", "tera"},
+	{"serum", "sera"},
+	{"trum", "tra"},
+	{"antum", "anta"},
+	{"atum", "ata"},
+	{"entum", "enta"},
+	{"etum", "eta"},
+	{"itum", "ita"},
+	{"otum", "ota"},
+	{"utum", "uta"},
+	{"ctum", "cta"},
+	{"ovum", "ova"},
+
+	// Words from Latin that end in -us change -us to -i or -era
+	// not easy to make a simple rule. just add them all to the dictionary
+
+	// Words from Latin that end in -ex change -ex to -ices (eg, vortex becomes vortices)
+	// Words from Latin that end in -ix change -ix to -ices (eg, matrix becomes matrices)
+	//    for example, -dix, -dex, and -dice will have the same plural form so
+	//    making a simple rule is not possible for them
+	{"trix", "trices"}, // ignore a few words end in trice
+
+	// Words from Latin that end in -is change -is to -es (eg, thesis becomes theses)
+	// -sis and -se has the same plural -ses so making a rule is not easy too.
+	{"iasis", "iases"},
+	{"mesis", "meses"},
+	{"kinesis", "kineses"},
+	{"resis", "reses"},
+	{"gnosis", "gnoses"}, // e.g. diagnosis
+	{"opsis", "opses"},   // e.g. synopsis
+	{"ysis", "yses"},     // e.g. analysis
+
+	// Words that end in -ch, -o, -s, -sh, -x, -z
+	{"ouse", "ouses"},
+	{"lause", "lauses"},
+	{"us", "uses"}, // use/uses is in the dictionary
+
+	{"ch", "ches"},
+	{"io", "ios"},
+	{"sh", "shes"},
+	{"ss", "sses"},
+	{"ez", "ezzes"},
+	{"iz", "izzes"},
+	{"tz", "tzes"},
+	{"zz", "zzes"},
+	{"ano", "anos"},
+	{"lo", "los"},
+	{"to", "tos"},
+	{"oo", "oos"},
+	{"o", "oes"},
+	{"x", "xes"},
+
+	// for abbreviations
+	{"S", "Ses"},
+
+	// excluded rules: seems rare
+	// Words from Hebrew that add -im or -ot (eg, cherub becomes cherubim)
+	// - cherub (cherubs or cherubim), seraph (seraphs or seraphim)
+	// Words from Greek that end in -ma change -ma to -mata
+	// - The most of words end in -ma are in this category but it looks like
+	//   just adding -s is more popular.
+	// Words from Latin that end in -nx change -nx to -nges
+	// - The most of words end in -nx are in this category but it looks like
+	//   just adding -es is more popular. (sphinxes)
+
+	// excluded rules: don't care at least for now:
+	// Words that end in -ful that add an s after the -ful
+	// Words that end in -s or -ese denoting a national of a particular country
+	// Symbols or letters, which often add -'s
+}
+
+// acronyms are words kept in upper case when a name is split into words
+var acronyms = map[string]struct{}{}
+
+var acronymList = []string{
+	"OK", "UTF8", "HTML", "JSON", "JWT", "ID", "UUID", "SQL", "ACK", "ACL",
+	"ADSL", "AES", "ANSI", "API", "ARP", "ATM", "BGP", "BSS", "CCITT", "CHAP",
+	"CIDR", "CIR", "CLI", "CPE", "CPU", "CRC", "CRT", "CSMA", "CMOS", "DCE",
+	"DEC", "DES", "DHCP", "DNS", "DRAM", "DSL", "DSLAM", "DTE", "DMI", "EHA",
+	"EIA", "EIGRP", "EOF", "ESS", "FCC", "FCS", "FDDI", "FTP", "GBIC", "GEPOF",
+	"HDLC", "HTTP", "HTTPS", "IANA", "ICMP", "IDF", "IDS", "IEEE", "IETF", "IMAP",
+	"IP", "IPS", "ISDN", "ISP", "LACP", "LAN", "LAPB", "LAPF", "LLC", "MAC",
+	"MC", "MDF", "MIB", "MPLS", "MTU", "NAC", "NAT", "NBMA", "NIC", "NRZ",
+	"NRZI", "NVRAM", "OSI", "OSPF", "OUI", "PAP", "PAT", "PC", "PIM", "PCM",
+	"PDU", "POP3", "POTS", "PPP", "PPTP", "PTT", "PVST", "RAM", "RARP", "RFC",
+	"RIP", "RLL", "ROM", "RSTP", "RTP", "RCP", "SDLC", "SFD", "SFP", "SLARP",
+	"SLIP", "SMTP", "SNA", "SNAP", "SNMP", "SOF", "SRAM", "SSH", "SSID", "STP",
+	"SYN", "TDM", "TFTP", "TIA", "TOFU", "UDP", "URL", "URI", "USB", "UTP",
+	"VC", "VLAN", "VLSM", "VPN", "W3C", "WAN", "WEP", "WPA", "WWW",
+}
+
+// rule replaces the suffix of a word by repl, unless keep is set: words that
+// already have the inflected suffix are left untouched
+type rule struct {
+	suffix string
+	repl   string
+	keep   bool
+}
+
+var (
+	rulesOnce      sync.Once
+	singleToPlural map[string]string
+	pluralToSingle map[string]string
+	pluralRules    []rule
+	singularRules  []rule
+)
+
+func loadRules() {
+	singleToPlural = map[string]string{}
+	pluralToSingle = map[string]string{}
+	for _, a := range acronymList {
+		acronyms[a] = struct{}{}
+	}
+	for _, wd := range dictionary {
+		if wd.uncountable && wd.plural == "" {
+			wd.plural = wd.singular
+		}
+		singleToPlural[wd.singular] = wd.plural
+		if !wd.unidirectional {
+			pluralToSingle[wd.plural] = wd.singular
+			if wd.alternative != "" {
+				pluralToSingle[wd.alternative] = wd.singular
+			}
+		}
+	}
+
+	// The words of the dictionary take precedence over the suffixes, the
+	// last ones first
+	for i := len(dictionary) - 1; i >= 0; i-- {
+		wd := dictionary[i]
+		if wd.exact {
+			continue
+		}
+		if wd.uncountable && wd.plural == "" {
+			wd.plural = wd.singular
+		}
+		pluralRules = append(pluralRules, rule{suffix: wd.plural, keep: true}, rule{suffix: wd.singular, repl: wd.plural})
+		if wd.unidirectional {
+			continue
+		}
+		if wd.alternative != "" {
+			singularRules = append(singularRules, rule{suffix: wd.singular, keep: true}, rule{suffix: wd.alternative, repl: wd.singular})
+		}
+		singularRules = append(singularRules, rule{suffix: wd.singular, keep: true}, rule{suffix: wd.plural, repl: wd.singular})
+	}
+	for _, s := range suffixes {
+		pluralRules = append(pluralRules, rule{suffix: s.plural, keep: true}, rule{suffix: s.singular, repl: s.plural})
+		singularRules = append(singularRules, rule{suffix: s.singular, keep: true}, rule{suffix: s.plural, repl: s.singular})
+	}
+}
+
+// Pluralize returns the plural of the last word of s
+func Pluralize(s string) string {
+	rulesOnce.Do(loadRules)
+	last := lastPart(s)
+	if last == "" {
+		return ""
+	}
+	if p, ok := singleToPlural[s]; ok {
+		return replaceSuffix(s, s, p)
+	}
+	if _, ok := pluralToSingle[s]; ok {
+		return s
+	}
+	ls := strings.ToLower(last)
+	if _, ok := pluralToSingle[ls]; ok {
+		return s
+	}
+	if p, ok := singleToPlural[ls]; ok {
+		if last == capitalize(last) {
+			p = capitalize(p)
+		}
+		return replaceSuffix(s, last, p)
+	}
+	if r, ok := match(pluralRules, last); ok {
+		return replaceSuffix(s, last, r)
+	}
+	if strings.HasSuffix(ls, "s") {
+		return s
+	}
+	return s + "s"
+}
+
+// Singularize returns the singular of the last word of s
+func Singularize(s string) string {
+	rulesOnce.Do(loadRules)
+	last := lastPart(s)
+	if last == "" {
+		return s
+	}
+	if p, ok := pluralToSingle[s]; ok {
+		return replaceSuffix(s, s, p)
+	}
+	if _, ok := singleToPlural[s]; ok {
+		return s
+	}
+	ls := strings.ToLower(last)
+	if p, ok := pluralToSingle[ls]; ok {
+		if last == capitalize(last) {
+			p = capitalize(p)
+		}
+		return replaceSuffix(s, last, p)
+	}
+	if _, ok := singleToPlural[ls]; ok {
+		return s
+	}
+	if r, ok := match(singularRules, last); ok {
+		return replaceSuffix(s, last, r)
+	}
+	if strings.HasSuffix(last, "s") {
+		return replaceSuffix(s, "s", "")
+	}
+	return s
+}
+
+// match applies the first rule whose suffix ends word
+func match(rules []rule, word string) (string, bool) {
+	for _, r := range rules {
+		if strings.HasSuffix(word, r.suffix) {
+			if r.keep {
+				return word, true
+			}
+			return word[:len(word)-len(r.suffix)] + r.repl, true
+		}
+	}
+	return "", false
+}
+
+func replaceSuffix(s, suffix, repl string) string {
+	return strings.TrimSuffix(s, suffix) + repl
+}
+
+func capitalize(s string) string {
+	if lastPart(s) == "" {
+		return ""
+	}
+	runes := []rune(s)
+	runes[0] = unicode.ToTitle(runes[0])
+	return string(runes)
+}
+
+func isSeparator(c rune) bool {
+	switch c {
+	case '_', ' ', ':', '-', '/':
+		return true
+	}
+	return unicode.IsSpace(c)
+}
+
+// lastPart returns the last word of s, words are separated by separators and
+// by upper case letters that follow lower case ones or acronyms
+func lastPart(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return ""
+	}
+	if _, ok := acronyms[strings.ToUpper(s)]; ok {
+		return strings.ToUpper(s)
+	}
+	var last string
+	var prev rune
+	var x strings.Builder
+	flush := func() {
+		if p := trimPart(x.String()); p != "" {
+			last = p
+		}
+		x.Reset()
+	}
+	for _, c := range s {
+		if !utf8.ValidRune(c) {
+			continue
+		}
+		_, acronym := acronyms[strings.ToUpper(x.String())]
+		switch {
+		case isSeparator(c), unicode.IsUpper(c) && (!unicode.IsUpper(prev) || acronym):
+			flush()
+			x.WriteRune(c)
+		case unicode.IsLetter(c) || unicode.IsDigit(c) || unicode.IsPunct(c) || c == '`':
+			x.WriteRune(c)
+		default:
+			flush()
+		}
+		prev = c
+	}
+	flush()
+	return last
+}
+
+// trimPart trims the separators of a word, acronyms are in upper case
+func trimPart(s string) string {
+	s = strings.TrimSpace(s)
+	for _, sep := range []string{"_", " ", ":", "-", "/"} {
+		s = strings.Trim(s, sep)
+	}
+	if _, ok := acronyms[strings.ToUpper(s)]; ok {
+		s = strings.ToUpper(s)
+	}
+	return s
+}
@@ -0,0 +1,47 @@
+package inflection
+
+import "testing"
+
+func TestPluralize(t *testing.T) {
+	for _, tc := range []struct {
+		singular, plural string
+	}{
+		{"book", "books"},
+		{"category", "categories"},
+		{"person", "people"},
+		{"leaf", "leaves"},
+		{"cache", "caches"},
+		{"status", "statuses"},
+		{"index", "indices"},
+		{"criterion", "criteria"},
+		{"sheep", "sheep"},
+		{"ID", "IDs"},
+		{"book_tag", "book_tags"},
+		{"BookTag", "BookTags"},
+	} {
+		if got := Pluralize(tc.singular); got != tc.plural {
+			t.Errorf("Pluralize(%q) = %q, want %q", tc.singular, got, tc.plural)
+		}
+	}
+}
+
+func TestSingularize(t *testing.T) {
+	for _, tc := range []struct {
+		plural, singular string
+	}{
+		{"books", "book"},
+		{"categories", "category"},
+		{"people", "person"},
+		{"leaves", "leaf"},
+		{"caches", "cache"},
+		{"statuses", "status"},
+		{"media", "media"},
+		{"news", "news"},
+		{"book", "book"},
+		{"BookTags", "BookTag"},
+	} {
+		if got := Singularize(tc.plural); got != tc.singular {
+			t.Errorf("Singularize(%q) = %q, want %q", tc.plural, got, tc.singular)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package golang
+
+import (
+	"errors"
+	"path/filepath"
+
+	"github.com/sqlc-dev/plugin-sdk-go/metadata"
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+// IntegrationTest describes the test emit_integration_tests generates, which
+// starts the database in a container, applies the schema and runs each query
+// once with zero value parameters
+type IntegrationTest struct {
+	Engine       string   // "postgresql" or "mysql"
+	Image        string   // The container image of the database
+	DriverName   string   // The database/sql driver, unless pgx is used
+	DriverImport string   // The package registering DriverName
+	Schema       []string // The schema files, relative to the output directory
+	Queries      []string // The query methods to run
+}
+
+// integrationImages are the default images of the engines the test supports
+var integrationImages = map[string]string{
+	"postgresql": "postgres:16-alpine",
+	"mysql":      "mysql:8.0",
+}
+
+// integrationCommands are the commands whose methods take the parameters of
+// the query, the batch and copyfrom methods are not run
+var integrationCommands = map[string]struct{}{
+	metadata.CmdOne:        {},
+	metadata.CmdMany:       {},
+	metadata.CmdExec:       {},
+	metadata.CmdExecRows:   {},
+	metadata.CmdExecResult: {},
+	metadata.CmdExecLastId: {},
+}
+
+func buildIntegrationTest(req *plugin.GenerateRequest, options *opts.Options, queries []Query) (*IntegrationTest, error) {
+	engine := req.Settings.GetEngine()
+	image, ok := integrationImages[engine]
+	if !ok {
+		return nil, errors.New("emit_integration_tests only supports the postgresql and mysql engines")
+	}
+	if options.IntegrationTestImage != "" {
+		image = options.IntegrationTestImage
+	}
+	test := &IntegrationTest{Engine: engine, Image: image}
+
+	switch {
+	case engine == "mysql":
+		test.DriverName, test.DriverImport = "mysql", opts.SQLDriverGoSQLDriverMySQL
+	case parseDriver(options.SqlPackage).IsPGX():
+	case options.SqlDriver == opts.SQLDriverPGXV5:
+		test.DriverName, test.DriverImport = "pgx", "github.com/jackc/pgx/v5/stdlib"
+	case options.SqlDriver == string(opts.SQLDriverPGXV4):
+		test.DriverName, test.DriverImport = "pgx", "github.com/jackc/pgx/v4/stdlib"
+	default:
+		test.DriverName, test.DriverImport = "postgres", opts.SQLDriverLibPQ
+	}
+
+	// The schema paths are relative to the configuration file, as is the
+	// output directory
+	out := req.Settings.GetCodegen().GetOut()
+	for _, path := range req.Settings.GetSchema() {
+		if !filepath.IsAbs(path) {
+			rel, err := filepath.Rel(filepath.FromSlash(out), filepath.FromSlash(path))
+			if err != nil {
+				return nil, err
+			}
+			path = rel
+		}
+		test.Schema = append(test.Schema, filepath.ToSlash(path))
+	}
+
+	seen := map[string]struct{}{}
+	for _, q := range queries {
+		if _, ok := integrationCommands[q.Cmd]; !ok {
+			continue
+		}
+		if _, ok := seen[q.MethodName]; ok {
+			continue
+		}
+		seen[q.MethodName] = struct{}{}
+		test.Queries = append(test.Queries, q.MethodName)
+	}
+	return test, nil
+}
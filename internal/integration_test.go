@@ -0,0 +1,51 @@
+package golang
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+func TestBuildIntegrationTest(t *testing.T) {
+	req := &plugin.GenerateRequest{Settings: &plugin.Settings{
+		Engine:  "postgresql",
+		Schema:  []string{"db/schema.sql", "migrations"},
+		Codegen: &plugin.Codegen{Out: "internal/db"},
+	}}
+	queries := []Query{
+		{MethodName: "GetAuthor", Cmd: ":one"},
+		{MethodName: "CreateAuthors", Cmd: ":copyfrom"},
+		{MethodName: "UpdateAuthors", Cmd: ":batchexec"},
+		{MethodName: "DeleteAuthor", Cmd: ":exec"},
+	}
+
+	test, err := buildIntegrationTest(req, &opts.Options{SqlPackage: "pgx/v5"}, queries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"../../db/schema.sql", "../../migrations"}; !slices.Equal(test.Schema, want) {
+		t.Errorf("Schema = %v, want %v", test.Schema, want)
+	}
+	if want := []string{"GetAuthor", "DeleteAuthor"}; !slices.Equal(test.Queries, want) {
+		t.Errorf("Queries = %v, want %v", test.Queries, want)
+	}
+	if test.Image != "postgres:16-alpine" || test.DriverName != "" {
+		t.Errorf("pgx test uses image %q and driver %q", test.Image, test.DriverName)
+	}
+
+	test, err = buildIntegrationTest(req, &opts.Options{IntegrationTestImage: "postgres:17"}, queries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if test.Image != "postgres:17" || test.DriverName != "postgres" || test.DriverImport != "github.com/lib/pq" {
+		t.Errorf("database/sql test uses image %q and driver %q from %q", test.Image, test.DriverName, test.DriverImport)
+	}
+
+	req.Settings.Engine = "sqlite"
+	if _, err := buildIntegrationTest(req, &opts.Options{}, queries); err == nil {
+		t.Error("expected an error for sqlite")
+	}
+}
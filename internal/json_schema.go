@@ -0,0 +1,274 @@
+package golang
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// jsonSchemaScalars describes how the Go types of columns are encoded by
+// encoding/json. The pgtype and uuid wrappers encode NULL as null.
+var jsonSchemaScalars = map[string]map[string]any{
+	"bool":    {"type": "boolean"},
+	"string":  {"type": "string"},
+	"int":     {"type": "integer"},
+	"int8":    {"type": "integer"},
+	"int16":   {"type": "integer"},
+	"int32":   {"type": "integer"},
+	"int64":   {"type": "integer"},
+	"uint":    {"type": "integer", "minimum": 0},
+	"uint8":   {"type": "integer", "minimum": 0},
+	"uint16":  {"type": "integer", "minimum": 0},
+	"uint32":  {"type": "integer", "minimum": 0},
+	"uint64":  {"type": "integer", "minimum": 0},
+	"float32": {"type": "number"},
+	"float64": {"type": "number"},
+	"[]byte":  {"type": "string", "contentEncoding": "base64"},
+
+	"time.Time":       {"type": "string", "format": "date-time"},
+	"uuid.UUID":       {"type": "string", "format": "uuid"},
+	"uuid.NullUUID":   {"type": []any{"string", "null"}, "format": "uuid"},
+	"json.RawMessage": {},
+	"interface{}":     {},
+	"any":             {},
+
+	"pgtype.Bool":        {"type": []any{"boolean", "null"}},
+	"pgtype.Text":        {"type": []any{"string", "null"}},
+	"pgtype.Int2":        {"type": []any{"integer", "null"}},
+	"pgtype.Int4":        {"type": []any{"integer", "null"}},
+	"pgtype.Int8":        {"type": []any{"integer", "null"}},
+	"pgtype.Float4":      {"type": []any{"number", "null"}},
+	"pgtype.Float8":      {"type": []any{"number", "null"}},
+	"pgtype.Numeric":     {"type": []any{"number", "null"}},
+	"pgtype.UUID":        {"type": []any{"string", "null"}, "format": "uuid"},
+	"pgtype.Date":        {"type": []any{"string", "null"}, "format": "date"},
+	"pgtype.Timestamp":   {"type": []any{"string", "null"}, "format": "date-time"},
+	"pgtype.Timestamptz": {"type": []any{"string", "null"}, "format": "date-time"},
+}
+
+// jsonSchemaSQLNulls are the database/sql null types, which have no JSON
+// encoding of their own and are encoded as objects of the value and Valid
+var jsonSchemaSQLNulls = map[string]string{
+	"sql.NullBool":    "bool",
+	"sql.NullByte":    "uint8",
+	"sql.NullFloat64": "float64",
+	"sql.NullInt16":   "int16",
+	"sql.NullInt32":   "int32",
+	"sql.NullInt64":   "int64",
+	"sql.NullString":  "string",
+	"sql.NullTime":    "time.Time",
+}
+
+// jsonSchemaBuilder describes the generated structs as JSON Schema
+// definitions, which refer to each other through refPrefix
+type jsonSchemaBuilder struct {
+	refPrefix string
+	enums     map[string]Enum
+	structs   map[string]struct{}
+	defs      map[string]any
+}
+
+func newJSONSchemaBuilder(refPrefix string, enums []Enum) *jsonSchemaBuilder {
+	b := &jsonSchemaBuilder{
+		refPrefix: refPrefix,
+		enums:     map[string]Enum{},
+		structs:   map[string]struct{}{},
+		defs:      map[string]any{},
+	}
+	for _, e := range enums {
+		b.enums[e.Name] = e
+	}
+	return b
+}
+
+// addStructs adds the definitions of the models, the result structs of the
+// queries and the structs of the nested composites
+func (b *jsonSchemaBuilder) addStructs(structs []Struct, queries []Query, nested []Nested) {
+	// The names are collected first, as structs refer to the ones that are
+	// defined after them
+	walkOutputStructs(structs, queries, nested, func(s outputStruct) {
+		b.structs[s.Name] = struct{}{}
+	})
+	walkOutputStructs(structs, queries, nested, b.addObject)
+}
+
+// outputStruct is a generated struct that is returned to callers: a model, the
+// result struct of a query or a nested composite
+type outputStruct struct {
+	Name    string
+	Type    string // Qualified with the models package, if any
+	Comment string
+	Fields  []Field
+	Nested  []*NestedStructData
+}
+
+// walkOutputStructs calls f with each of the models, the result structs of
+// the queries and the structs of the nested composites, in that order
+func walkOutputStructs(structs []Struct, queries []Query, nested []Nested, f func(outputStruct)) {
+	for _, s := range structs {
+		f(outputStruct{Name: s.Name, Type: s.Type(), Comment: s.Comment, Fields: s.Fields})
+	}
+	for _, q := range queries {
+		if q.Ret.EmitStruct() && q.VariantOf == "" {
+			f(outputStruct{Name: q.Ret.Type(), Type: q.Ret.Type(), Fields: q.Ret.Struct.Fields})
+		}
+	}
+	var walk func(data *NestedStructData)
+	walk = func(data *NestedStructData) {
+		if !data.IsEntityStruct {
+			f(outputStruct{Name: data.StructOut, Type: data.StructOut, Fields: data.Fields, Nested: data.NestedStructs})
+		}
+		for _, child := range data.NestedStructs {
+			walk(child)
+		}
+	}
+	for _, n := range nested {
+		for _, item := range n.NestedDataItems {
+			if item.RootStructData != nil {
+				walk(item.RootStructData)
+			}
+		}
+	}
+}
+
+// addObject adds the definition of a struct, the first one wins when
+// composites are shared by several queries
+func (b *jsonSchemaBuilder) addObject(s outputStruct) {
+	name := s.Name
+	if _, ok := b.defs[name]; ok {
+		return
+	}
+	props := map[string]any{}
+	required := []string{}
+	add := func(goName, typ string, tags map[string]string) {
+		prop, omitEmpty, ok := jsonProperty(goName, tags)
+		if !ok {
+			return
+		}
+		props[prop] = b.typeSchema(typ)
+		if !omitEmpty {
+			required = append(required, prop)
+		}
+	}
+	for _, f := range s.Fields {
+		add(f.Name, f.Type, f.Tags)
+	}
+	for _, n := range s.Nested {
+		add(n.FieldName, n.FieldType, n.FieldTags)
+	}
+
+	def := map[string]any{
+		"title":                name,
+		"type":                 "object",
+		"properties":           props,
+		"required":             required,
+		"additionalProperties": false,
+	}
+	if s.Comment != "" {
+		def["description"] = strings.TrimSpace(s.Comment)
+	}
+	b.defs[name] = def
+}
+
+// typeSchema describes how a value of the Go type typ is encoded
+func (b *jsonSchemaBuilder) typeSchema(typ string) map[string]any {
+	if s, ok := jsonSchemaScalars[typ]; ok {
+		return s
+	}
+	if valueType, ok := jsonSchemaSQLNulls[typ]; ok {
+		field := strings.TrimPrefix(typ, "sql.Null")
+		return map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				field:   b.typeSchema(valueType),
+				"Valid": map[string]any{"type": "boolean"},
+			},
+			"required": []string{field, "Valid"},
+		}
+	}
+	switch {
+	case strings.HasPrefix(typ, "*"):
+		return nullableSchema(b.typeSchema(typ[1:]))
+	case strings.HasPrefix(typ, "[]"):
+		// nil slices are encoded as null
+		return nullableSchema(map[string]any{"type": "array", "items": b.typeSchema(typ[2:])})
+	case strings.HasPrefix(typ, "map[string]"):
+		return map[string]any{"type": "object", "additionalProperties": b.typeSchema(strings.TrimPrefix(typ, "map[string]"))}
+	}
+
+	name := typ[strings.LastIndex(typ, ".")+1:]
+	if e, ok := b.enums[name]; ok {
+		return b.enumSchema(e)
+	}
+	if _, ok := b.structs[name]; ok {
+		return map[string]any{"$ref": b.refPrefix + name}
+	}
+	if e, ok := b.enums[strings.TrimPrefix(name, "Null")]; ok {
+		return nullableSchema(b.enumSchema(e))
+	}
+	// Unknown types, such as the ones of overrides, accept any value
+	return map[string]any{}
+}
+
+func (b *jsonSchemaBuilder) enumSchema(e Enum) map[string]any {
+	if _, ok := b.defs[e.Name]; !ok {
+		values := make([]string, 0, len(e.Constants))
+		for _, c := range e.Constants {
+			values = append(values, c.Value)
+		}
+		def := map[string]any{"title": e.Name, "type": "string", "enum": values}
+		if e.Comment != "" {
+			def["description"] = strings.TrimSpace(e.Comment)
+		}
+		b.defs[e.Name] = def
+	}
+	return map[string]any{"$ref": b.refPrefix + e.Name}
+}
+
+// nullableSchema allows null besides the values described by s
+func nullableSchema(s map[string]any) map[string]any {
+	if t, ok := s["type"].(string); ok {
+		nullable := make(map[string]any, len(s))
+		for k, v := range s {
+			nullable[k] = v
+		}
+		nullable["type"] = []any{t, "null"}
+		return nullable
+	}
+	if _, ok := s["type"]; ok || len(s) == 0 {
+		return s
+	}
+	return map[string]any{"anyOf": []any{s, map[string]any{"type": "null"}}}
+}
+
+// jsonProperty returns the name encoding/json uses for a field and whether
+// it is omitted when empty. Fields tagged "-" are not encoded.
+func jsonProperty(goName string, tags map[string]string) (string, bool, bool) {
+	tag, ok := tags["json"]
+	if !ok {
+		return goName, false, true
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "-" && opts == "" {
+		return "", false, false
+	}
+	if name == "" {
+		name = goName
+	}
+	return name, strings.Contains(","+opts+",", ",omitempty,"), true
+}
+
+// buildJSONSchema returns a JSON Schema document defining the models, the
+// result structs and the nested composites, so that each can be referenced
+// as <file>#/$defs/<Name>
+func buildJSONSchema(enums []Enum, structs []Struct, queries []Query, nested []Nested) ([]byte, error) {
+	b := newJSONSchemaBuilder("#/$defs/", enums)
+	b.addStructs(structs, queries, nested)
+	schema, err := json.MarshalIndent(map[string]any{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$defs":   b.defs,
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(schema, '\n'), nil
+}
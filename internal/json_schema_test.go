@@ -0,0 +1,62 @@
+package golang
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestJSONSchemaTypes(t *testing.T) {
+	b := newJSONSchemaBuilder("#/$defs/", []Enum{{Name: "Status", Constants: []Constant{{Value: "open"}, {Value: "closed"}}}})
+	b.structs["Book"] = struct{}{}
+	for _, tc := range []struct {
+		typ  string
+		want map[string]any
+	}{
+		{"int32", map[string]any{"type": "integer"}},
+		{"*string", map[string]any{"type": []any{"string", "null"}}},
+		{"pgtype.Text", map[string]any{"type": []any{"string", "null"}}},
+		{"[]int64", map[string]any{"type": []any{"array", "null"}, "items": map[string]any{"type": "integer"}}},
+		{"sql.NullInt32", map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"Int32": map[string]any{"type": "integer"},
+				"Valid": map[string]any{"type": "boolean"},
+			},
+			"required": []string{"Int32", "Valid"},
+		}},
+		{"models.Status", map[string]any{"$ref": "#/$defs/Status"}},
+		{"NullStatus", map[string]any{"anyOf": []any{map[string]any{"$ref": "#/$defs/Status"}, map[string]any{"type": "null"}}}},
+		{"*entity.Book", map[string]any{"anyOf": []any{map[string]any{"$ref": "#/$defs/Book"}, map[string]any{"type": "null"}}}},
+		{"netip.Addr", map[string]any{}},
+	} {
+		t.Run(tc.typ, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, b.typeSchema(tc.typ)); diff != "" {
+				t.Errorf("typeSchema(%q) mismatch (-want +got):\n%s", tc.typ, diff)
+			}
+		})
+	}
+	if _, ok := b.defs["Status"]; !ok {
+		t.Errorf("enum Status is not defined")
+	}
+}
+
+func TestJSONProperty(t *testing.T) {
+	for _, tc := range []struct {
+		tags      map[string]string
+		name      string
+		omitEmpty bool
+		ok        bool
+	}{
+		{nil, "AuthorID", false, true},
+		{map[string]string{"json": "author_id"}, "author_id", false, true},
+		{map[string]string{"json": "author_id,omitempty"}, "author_id", true, true},
+		{map[string]string{"json": ",omitempty"}, "AuthorID", true, true},
+		{map[string]string{"json": "-"}, "", false, false},
+	} {
+		name, omitEmpty, ok := jsonProperty("AuthorID", tc.tags)
+		if name != tc.name || omitEmpty != tc.omitEmpty || ok != tc.ok {
+			t.Errorf("jsonProperty(%v) = %q, %v, %v, want %q, %v, %v", tc.tags, name, omitEmpty, ok, tc.name, tc.omitEmpty, tc.ok)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package golang
+
+import (
+	"strings"
+
+	"github.com/sqlc-dev/plugin-sdk-go/metadata"
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+// lastInsertIDColumn is the name of the column taken as the primary key of
+// a table, the catalog does not mark primary keys
+const lastInsertIDColumn = "id"
+
+// lastInsertIDType returns the Go type of the id column of the table the
+// :execlastid query inserts into, for emit_typed_last_insert_id. It returns
+// an empty string when the method keeps returning an int64: the option is
+// off, the table has no NOT NULL id column, or the type of the column is
+// int64. The type, an override included, must have an integer underlying
+// type, as LastInsertId's int64 is converted to it.
+func lastInsertIDType(req *plugin.GenerateRequest, options *opts.Options, query *plugin.Query) string {
+	if !options.EmitTypedLastInsertId || query.Cmd != metadata.CmdExecLastId || query.InsertIntoTable == nil {
+		return ""
+	}
+	schemaName := query.InsertIntoTable.Schema
+	if schemaName == "" {
+		schemaName = req.Catalog.DefaultSchema
+	}
+	for _, schema := range req.Catalog.Schemas {
+		if schema.Name != schemaName {
+			continue
+		}
+		for _, table := range schema.Tables {
+			if table.Rel.Name != query.InsertIntoTable.Name {
+				continue
+			}
+			for _, column := range table.Columns {
+				if !strings.EqualFold(column.Name, lastInsertIDColumn) || !column.NotNull || column.IsArray {
+					continue
+				}
+				if typ := goType(req, options, column); typ != "int64" {
+					return typ
+				}
+				return ""
+			}
+		}
+	}
+	return ""
+}
+
+// LastInsertIDReturnType is the type returned by the method of an
+// :execlastid query
+func (q Query) LastInsertIDReturnType() string {
+	if q.LastInsertIDType != "" {
+		return q.LastInsertIDType
+	}
+	return "int64"
+}
@@ -0,0 +1,47 @@
+package golang
+
+import (
+	"testing"
+
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+func TestLastInsertIDType(t *testing.T) {
+	table := func(name string, id *plugin.Column) *plugin.Table {
+		return &plugin.Table{Rel: &plugin.Identifier{Name: name}, Columns: []*plugin.Column{id}}
+	}
+	req := &plugin.GenerateRequest{
+		Settings: &plugin.Settings{Engine: "mysql"},
+		Catalog: &plugin.Catalog{DefaultSchema: "app", Schemas: []*plugin.Schema{{Name: "app", Tables: []*plugin.Table{
+			table("authors", &plugin.Column{Name: "id", Type: &plugin.Identifier{Name: "bigint"}, NotNull: true, Unsigned: true}),
+			table("books", &plugin.Column{Name: "id", Type: &plugin.Identifier{Name: "bigint"}, NotNull: true}),
+			table("tags", &plugin.Column{Name: "ID", Type: &plugin.Identifier{Name: "int"}, NotNull: true}),
+			table("notes", &plugin.Column{Name: "id", Type: &plugin.Identifier{Name: "int"}}),
+		}}}},
+	}
+	options := &opts.Options{EmitTypedLastInsertId: true}
+	for _, tc := range []struct {
+		table string
+		cmd   string
+		want  string
+	}{
+		{"authors", ":execlastid", "uint64"},
+		{"books", ":execlastid", ""},
+		{"tags", ":execlastid", "int32"},
+		{"notes", ":execlastid", ""},
+		{"users", ":execlastid", ""},
+		{"authors", ":exec", ""},
+	} {
+		query := &plugin.Query{Cmd: tc.cmd, InsertIntoTable: &plugin.Identifier{Name: tc.table}}
+		if got := lastInsertIDType(req, options, query); got != tc.want {
+			t.Errorf("lastInsertIDType(%s %s) = %q, want %q", tc.cmd, tc.table, got, tc.want)
+		}
+	}
+
+	query := &plugin.Query{Cmd: ":execlastid", InsertIntoTable: &plugin.Identifier{Name: "authors"}}
+	if got := lastInsertIDType(req, &opts.Options{}, query); got != "" {
+		t.Errorf("lastInsertIDType() = %q without emit_typed_last_insert_id", got)
+	}
+}
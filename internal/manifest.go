@@ -0,0 +1,113 @@
+package golang
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"path"
+	"sort"
+
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+// buildManifest returns the manifest file describing files, tracing the query
+// and nested files back to the SQL files and queries of req. Files skipped by
+// incremental generation are carried over from the previous manifest.
+func buildManifest(req *plugin.GenerateRequest, options *opts.Options, files []*plugin.File, previous *opts.Manifest, inputs map[string]string) (*plugin.File, error) {
+	entries := make(map[string]*opts.ManifestFile, len(files))
+	for _, f := range files {
+		sum := sha256.Sum256(f.Contents)
+		entries[f.Name] = &opts.ManifestFile{Name: f.Name, SHA256: hex.EncodeToString(sum[:]), Inputs: inputs[f.Name]}
+	}
+	if previous != nil {
+		for _, f := range previous.Files {
+			if _, ok := options.SkipFiles[f.Name]; ok {
+				entries[f.Name] = &opts.ManifestFile{Name: f.Name, SHA256: f.SHA256, Inputs: f.Inputs}
+			}
+		}
+	}
+
+	nestedQueries := map[string]struct{}{}
+	if options.Nested != nil {
+		for _, config := range options.Nested.Queries {
+			nestedQueries[config.Query] = struct{}{}
+		}
+	}
+
+	for _, q := range req.Queries {
+		filename, dir := q.Filename, ""
+		if p := packageFor(options, q.Filename); p != nil {
+			filename, dir = path.Base(opts.SlashPath(filename)), p.Out
+		}
+		source := querySourceName(options, filename)
+
+		if entry, ok := entries[path.Join(dir, queryFileName(options, source))]; ok {
+			entry.Source = q.Filename
+			entry.Queries = append(entry.Queries, q.Name)
+		}
+		if entry, ok := entries[path.Join(dir, getNestedFileName(options, source))]; ok {
+			entry.Source = q.Filename
+			if _, ok := nestedQueries[QueryName(q.Name, options)]; ok {
+				entry.Queries = append(entry.Queries, q.Name)
+			}
+		}
+	}
+
+	manifest := opts.Manifest{Files: make([]opts.ManifestFile, 0, len(entries))}
+	if !options.OmitSqlcVersion {
+		manifest.SqlcVersion = req.SqlcVersion
+	}
+	for _, entry := range entries {
+		manifest.Files = append(manifest.Files, *entry)
+	}
+	sort.Slice(manifest.Files, func(i, j int) bool {
+		return manifest.Files[i].Name < manifest.Files[j].Name
+	})
+
+	contents, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return &plugin.File{Name: manifestFileName(options), Contents: append(contents, '\n')}, nil
+}
+
+// manifestFileName returns the name of the manifest file, relative to out
+func manifestFileName(options *opts.Options) string {
+	if options.OutputManifestFileName != "" {
+		return options.OutputManifestFileName
+	}
+	return "manifest.json"
+}
+
+// DryRunListing lists the files a generation run would write, returned
+// instead of them by dry_run
+type DryRunListing struct {
+	Files []DryRunFile `json:"files"`
+}
+
+// DryRunFile describes a file a generation run would write
+type DryRunFile struct {
+	Name string `json:"name"`
+	Size int    `json:"size"`
+}
+
+// buildDryRunListing returns the file listing files, which are sorted by name
+func buildDryRunListing(options *opts.Options, files []*plugin.File) (*plugin.File, error) {
+	listing := DryRunListing{Files: []DryRunFile{}}
+	for _, f := range files {
+		listing.Files = append(listing.Files, DryRunFile{Name: f.Name, Size: len(f.Contents)})
+	}
+
+	contents, err := json.MarshalIndent(listing, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	name := "dry_run.json"
+	if options.OutputDryRunFileName != "" {
+		name = options.OutputDryRunFileName
+	}
+	return &plugin.File{Name: name, Contents: append(contents, '\n')}, nil
+}
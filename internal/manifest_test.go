@@ -0,0 +1,118 @@
+package golang
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+func TestBuildManifest(t *testing.T) {
+	req := &plugin.GenerateRequest{
+		SqlcVersion: "v1.29.0",
+		Queries: []*plugin.Query{
+			{Name: "GetAuthor", Filename: "query.sql"},
+			{Name: "ListAuthors", Filename: "query.sql"},
+			{Name: "ListInvoices", Filename: "billing/invoices.sql"},
+		},
+	}
+	options := &opts.Options{
+		Packages:  []*opts.PackageConfig{{Match: "billing/*.sql", Package: "billingdb", Out: "billingdb"}},
+		SkipFiles: map[string]struct{}{"billingdb/invoices.sql.go": {}},
+	}
+	files := []*plugin.File{
+		{Name: "db.go", Contents: []byte("package db\n")},
+		{Name: "query.sql.go", Contents: []byte("package db\n\nfunc GetAuthor() {}\n")},
+	}
+	previous := &opts.Manifest{Files: []opts.ManifestFile{
+		{Name: "billingdb/invoices.sql.go", SHA256: "0123", Source: "billing/invoices.sql", Queries: []string{"ListInvoices"}, Inputs: "4567"},
+		{Name: "stale.sql.go", SHA256: "89ab"},
+	}}
+
+	f, err := buildManifest(req, options, files, previous, map[string]string{"query.sql.go": "cdef"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Name != "manifest.json" {
+		t.Errorf("manifest file name = %s, want manifest.json", f.Name)
+	}
+	var got opts.Manifest
+	if err := json.Unmarshal(f.Contents, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	sum := func(b []byte) string {
+		s := sha256.Sum256(b)
+		return hex.EncodeToString(s[:])
+	}
+	want := opts.Manifest{SqlcVersion: "v1.29.0", Files: []opts.ManifestFile{
+		{Name: "billingdb/invoices.sql.go", SHA256: "0123", Source: "billing/invoices.sql", Queries: []string{"ListInvoices"}, Inputs: "4567"},
+		{Name: "db.go", SHA256: sum(files[0].Contents)},
+		{Name: "query.sql.go", SHA256: sum(files[1].Contents), Source: "query.sql", Queries: []string{"GetAuthor", "ListAuthors"}, Inputs: "cdef"},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildManifest() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestBuildDryRunListing(t *testing.T) {
+	files := []*plugin.File{
+		{Name: "db.go", Contents: []byte("package db\n")},
+		{Name: "query.sql.go", Contents: []byte("package db\n\nfunc GetAuthor() {}\n")},
+	}
+	f, err := buildDryRunListing(&opts.Options{OutputDryRunFileName: "plan.json"}, files)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Name != "plan.json" {
+		t.Errorf("dry run listing file name = %s, want plan.json", f.Name)
+	}
+	var got DryRunListing
+	if err := json.Unmarshal(f.Contents, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := DryRunListing{Files: []DryRunFile{{Name: "db.go", Size: 11}, {Name: "query.sql.go", Size: 32}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildDryRunListing() = %+v, want %+v", got, want)
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	req := syntheticRequest(2, 4, 0)
+	want, err := Generate(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var options map[string]any
+	if err := json.Unmarshal(req.PluginOptions, &options); err != nil {
+		t.Fatal(err)
+	}
+	options["dry_run"] = true
+	req.PluginOptions, _ = json.Marshal(options)
+	resp, err := Generate(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Files) != 1 || resp.Files[0].Name != "dry_run.json" {
+		t.Fatalf("dry run returns %d files, want only dry_run.json", len(resp.Files))
+	}
+	var listing DryRunListing
+	if err := json.Unmarshal(resp.Files[0].Contents, &listing); err != nil {
+		t.Fatal(err)
+	}
+	if len(listing.Files) != len(want.Files) {
+		t.Fatalf("dry_run.json lists %d files, want %d", len(listing.Files), len(want.Files))
+	}
+	for i, f := range want.Files {
+		if got := listing.Files[i]; got.Name != f.Name || got.Size != len(f.Contents) {
+			t.Errorf("dry_run.json lists %+v, want %s of %d bytes", got, f.Name, len(f.Contents))
+		}
+	}
+}
@@ -86,6 +86,9 @@ func mysqlType(req *plugin.GenerateRequest, options *opts.Options, col *plugin.C
 		return "sql.NullFloat64"
 
 	case "decimal", "dec", "fixed":
+		if typ, ok := decimalType(options, columnType, notNull, false); ok {
+			return typ
+		}
 		if notNull {
 			return "string"
 		}
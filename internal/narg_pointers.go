@@ -0,0 +1,44 @@
+package golang
+
+import (
+	"strings"
+
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+// paramType returns the Go type of a query parameter. With emit_narg_pointers
+// the nullable named parameters, such as those of sqlc.narg(), are pointers
+// to the type of their non-null values instead of a nullable wrapper. The
+// drivers pass a nil pointer as NULL and dereference the others.
+func paramType(req *plugin.GenerateRequest, options *opts.Options, col *plugin.Column) string {
+	typ := goType(req, options, col)
+	if !options.EmitNargPointers || !col.IsNamedParam || col.NotNull || col.IsArray || col.IsSqlcSlice {
+		return typ
+	}
+	if strings.HasPrefix(typ, "*") || strings.HasPrefix(typ, "[]") {
+		return typ
+	}
+	valueType := nonNullType(req, options, col)
+	if valueType == "interface{}" || valueType == "any" {
+		return typ
+	}
+	return "*" + valueType
+}
+
+// nonNullType returns the Go type of the non-null values of a column. The
+// pgx/v5 wrappers some non-null values are scanned into too, such as
+// pgtype.Timestamptz, are replaced by the type of their value.
+func nonNullType(req *plugin.GenerateRequest, options *opts.Options, col *plugin.Column) string {
+	notNull := proto.Clone(col).(*plugin.Column)
+	notNull.NotNull = true
+	typ := goType(req, options, notNull)
+	for _, c := range pgtypeConversions {
+		if c.Type == typ && !strings.HasPrefix(c.GoType, "[") {
+			return c.GoType
+		}
+	}
+	return typ
+}
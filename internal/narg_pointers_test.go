@@ -0,0 +1,41 @@
+package golang
+
+import (
+	"testing"
+
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+func TestParamType(t *testing.T) {
+	req := &plugin.GenerateRequest{
+		Settings: &plugin.Settings{Engine: "postgresql"},
+		Catalog:  &plugin.Catalog{DefaultSchema: "public"},
+	}
+	column := func(typ string, named, notNull bool) *plugin.Column {
+		return &plugin.Column{Name: "c", Type: &plugin.Identifier{Name: typ}, IsNamedParam: named, NotNull: notNull}
+	}
+	tests := []struct {
+		name   string
+		sqlPkg string
+		column *plugin.Column
+		narg   bool
+		want   string
+	}{
+		{"narg", "", column("text", true, false), true, "*string"},
+		{"pgtype wrapper", "pgx/v5", column("timestamptz", true, false), true, "*time.Time"},
+		{"pgtype value", "pgx/v5", column("numeric", true, false), true, "*pgtype.Numeric"},
+		{"option unset", "", column("text", true, false), false, "sql.NullString"},
+		{"positional", "", column("text", false, false), true, "sql.NullString"},
+		{"not null", "", column("text", true, true), true, "string"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			options := &opts.Options{SqlPackage: tc.sqlPkg, EmitNargPointers: tc.narg}
+			if got := paramType(req, options, tc.column); got != tc.want {
+				t.Errorf("paramType() = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
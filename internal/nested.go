@@ -31,6 +31,7 @@ type NestedStructData struct {
 	RowFieldName            string                    // Field name in row struct (e.g., "Book")
 	RowFieldType            string                    // Field type in row struct (e.g., "BookGroup")
 	IsRowFieldExistsInQuery bool                      // Whether the corresponding row field to the StructIn exists in the query return struct
+	RowFieldValid           bool                      // Whether the row field is followed by a Valid flag (emit_embed_valid_flags)
 	FieldTags               map[string]string         // Field tag in parent struct (e.g., "json:books" or "json:book")
 	KeyType                 string                    // Key type for the map
 	IsSlice                 bool                      // Whether this is a slice/array field
@@ -120,7 +121,7 @@ func (b *NestedQueryTemplateDataBuilder) buildNestedDataItems(
 		}
 
 		if targetQuery == nil {
-			debug.Warnf("Query '%s' not found for nested struct", config.Query)
+			debug.NestedBuilder.Warnf("Query '%s' not found for nested struct", config.Query)
 			continue // Skip if query not found
 		}
 
@@ -287,7 +288,7 @@ func (b *NestedQueryTemplateDataBuilder) buildNestedStructData(
 		FieldGroupBy:            config.FieldGroupBy,
 		IsSlice:                 config.GetIsSlice(),
 		IsPointer:               config.GetIsPointer(),
-		KeyType:                 determineKeyType(config.FieldGroupBy),
+		KeyType:                 determineKeyType(b.options, config.FieldGroupBy),
 		FieldName:               fieldName,
 		FieldType:               fieldType,
 		RowFieldName:            config.StructIn,
@@ -297,6 +298,7 @@ func (b *NestedQueryTemplateDataBuilder) buildNestedStructData(
 		IsEntityStruct:          isEntity,
 		IsComposite:             config.GetIsComposite(),
 		IsRowFieldExistsInQuery: b.isRowFieldExistsInQuery(queryName, config),
+		RowFieldValid:           b.options.EmitEmbedValidFlags && parseDriver(b.options.SqlPackage).IsPGX(),
 		SkipStructGeneration:    skipStructGeneration,
 		IsRoot:                  isRootConfig,
 		Match:                   config.Match,
@@ -544,12 +546,23 @@ func (b *NestedQueryTemplateDataBuilder) getNonNestedStructFields(fields []Field
 }
 
 // determineKeyType determines the key type for the map based on the field
-func determineKeyType(field string) string {
+func determineKeyType(options *opts.Options, field string) string {
 	// For now, default to UUID for all ID fields
 	// This could be enhanced to analyze the actual field type from SQLC catalog
+	if options.UuidType == opts.UUIDTypeGoogle {
+		return "uuid.UUID"
+	}
 	return "pgtype.UUID"
 }
 
+// KeyIsSet returns the expression checking that the key held by expr is not NULL
+func (n *NestedStructData) KeyIsSet(expr string) string {
+	if n.KeyType == "uuid.UUID" {
+		return expr + " != uuid.Nil"
+	}
+	return expr + ".Valid"
+}
+
 // getFieldNameFromNestedConfig determines the field name for a nested configuration
 // Uses field_out if specified, otherwise generates from struct_in based on slice configuration
 func getFieldNameFromNestedConfig(nested *opts.NestedGroupConfig) string {
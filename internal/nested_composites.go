@@ -2,6 +2,8 @@ package golang
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 
 	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
 )
@@ -41,6 +43,10 @@ type NestedCompositesDataBuilder struct {
 // buildCompositeStructRegistry analyzes all configurations to pre-populate the composite struct registry
 // This allows us to know what fields composite structs have before generating parent structs
 func (b *NestedCompositesDataBuilder) buildCompositeStructRegistry() error {
+	// Start from an empty registry so that state of a previous generation,
+	// such as which structs were already generated, does not leak
+	compositeStructRegistry = make(map[string]*CompositeStructData)
+
 	// Get composites config (if any)
 	var compositesConfigItems []*opts.NestedCompositeConfig
 	if b.options.Nested != nil && b.options.Nested.Composites != nil {
@@ -113,7 +119,8 @@ func (b *NestedCompositesDataBuilder) resolveAllTreeCompositeFields(compositeNam
 	}
 	entityFields = append(entityFields, compositeInfo.DirectNestedFields...)
 
-	for _, nestedCompositeName := range compositeInfo.NestedFieldToCompositeNameMap {
+	for _, field := range slices.Sorted(maps.Keys(compositeInfo.NestedFieldToCompositeNameMap)) {
+		nestedCompositeName := compositeInfo.NestedFieldToCompositeNameMap[field]
 		allNestedCompositeFields, err := b.resolveAllTreeCompositeFields(nestedCompositeName)
 		if err != nil {
 			return nil, err
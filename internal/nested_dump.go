@@ -0,0 +1,173 @@
+package golang
+
+import (
+	"encoding/json"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+// nestedDump is the intermediate model of the nested templates written by
+// dump_nested_data. Queries and fields are summarized, as the plugin
+// columns they refer to are not needed to understand the grouping.
+type nestedDump struct {
+	Context nestedDumpContext  `json:"context"`
+	Sources []nestedDumpSource `json:"sources"`
+}
+
+type nestedDumpContext struct {
+	Package      string   `json:"package"`
+	SQLDriver    string   `json:"sql_driver"`
+	EmitJSONTags bool     `json:"emit_json_tags"`
+	EmitDBTags   bool     `json:"emit_db_tags"`
+	UsesGenerics bool     `json:"uses_generics"`
+	Enums        int      `json:"enums"`
+	Structs      int      `json:"structs"`
+	Queries      int      `json:"queries"`
+	Composites   []string `json:"composite_types,omitempty"`
+}
+
+type nestedDumpSource struct {
+	SourceFileName string                    `json:"source_file_name"`
+	Configs        []*opts.NestedQueryConfig `json:"configs"`
+	Items          []nestedDumpItem          `json:"items"`
+}
+
+type nestedDumpItem struct {
+	FunctionName    string            `json:"function_name"`
+	Query           *nestedDumpQuery  `json:"query,omitempty"`
+	RootStructName  string            `json:"root_struct_name"`
+	RootStructData  *nestedDumpStruct `json:"root_struct_data,omitempty"`
+	EmitPointers    bool              `json:"emit_pointers"`
+	EmitJSONTags    bool              `json:"emit_json_tags"`
+	CastToQueryName string            `json:"cast_to_query_name,omitempty"`
+}
+
+type nestedDumpQuery struct {
+	MethodName string `json:"method_name"`
+	Cmd        string `json:"cmd"`
+	SourceName string `json:"source_name"`
+	Ret        string `json:"ret"`
+}
+
+type nestedDumpStruct struct {
+	StructIn                                string                    `json:"struct_in"`
+	StructOut                               string                    `json:"struct_out"`
+	FieldGroupBy                            string                    `json:"field_group_by,omitempty"`
+	FieldName                               string                    `json:"field_name,omitempty"`
+	FieldType                               string                    `json:"field_type,omitempty"`
+	RowFieldName                            string                    `json:"row_field_name,omitempty"`
+	RowFieldType                            string                    `json:"row_field_type,omitempty"`
+	IsRowFieldExistsInQuery                 bool                      `json:"is_row_field_exists_in_query"`
+	RowFieldValid                           bool                      `json:"row_field_valid,omitempty"`
+	FieldTags                               map[string]string         `json:"field_tags,omitempty"`
+	KeyType                                 string                    `json:"key_type,omitempty"`
+	IsSlice                                 bool                      `json:"is_slice"`
+	IsPointer                               bool                      `json:"is_pointer"`
+	IsComposite                             bool                      `json:"is_composite"`
+	IsEntityStruct                          bool                      `json:"is_entity_struct"`
+	IsRoot                                  bool                      `json:"is_root"`
+	Match                                   []*opts.NestedMatchConfig `json:"match,omitempty"`
+	DuplicatedRelativeToParents             map[int]bool              `json:"duplicated_relative_to_parents,omitempty"`
+	SkipStructGeneration                    bool                      `json:"skip_struct_generation"`
+	ShouldGenerateEntityToCompositeFunction bool                      `json:"should_generate_entity_to_composite_function"`
+	Fields                                  []nestedDumpField         `json:"fields"`
+	NestedStructs                           []*nestedDumpStruct       `json:"nested_structs,omitempty"`
+}
+
+type nestedDumpField struct {
+	Name   string            `json:"name"`
+	DBName string            `json:"db_name"`
+	Type   string            `json:"type"`
+	Tags   map[string]string `json:"tags,omitempty"`
+}
+
+// buildNestedDump returns the nested template data of the generation
+// described by tctx as indented JSON
+func buildNestedDump(tctx *tmplCtx, queries []Query) ([]byte, error) {
+	dump := nestedDump{
+		Context: nestedDumpContext{
+			Package:      tctx.Package,
+			SQLDriver:    string(tctx.SQLDriver),
+			EmitJSONTags: tctx.EmitJSONTags,
+			EmitDBTags:   tctx.EmitDBTags,
+			UsesGenerics: tctx.UsesGenerics,
+			Enums:        len(tctx.Enums),
+			Structs:      len(tctx.Structs),
+			Queries:      len(queries),
+			Composites:   tctx.CompositeTypes,
+		},
+		Sources: []nestedDumpSource{},
+	}
+	for _, n := range tctx.Nested {
+		source := nestedDumpSource{
+			SourceFileName: n.SourceFileName,
+			Configs:        n.Configs,
+			Items:          []nestedDumpItem{},
+		}
+		for _, item := range n.NestedDataItems {
+			source.Items = append(source.Items, nestedDumpItem{
+				FunctionName:    item.FunctionName,
+				Query:           dumpNestedQuery(item.Query),
+				RootStructName:  item.RootStructName,
+				RootStructData:  dumpNestedStruct(item.RootStructData),
+				EmitPointers:    item.EmitPointers,
+				EmitJSONTags:    item.EmitJSONTags,
+				CastToQueryName: item.CastToQueryName,
+			})
+		}
+		dump.Sources = append(dump.Sources, source)
+	}
+	b, err := json.MarshalIndent(dump, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+func dumpNestedQuery(q *Query) *nestedDumpQuery {
+	if q == nil {
+		return nil
+	}
+	return &nestedDumpQuery{
+		MethodName: q.MethodName,
+		Cmd:        q.Cmd,
+		SourceName: q.SourceName,
+		Ret:        q.Ret.Type(),
+	}
+}
+
+func dumpNestedStruct(s *NestedStructData) *nestedDumpStruct {
+	if s == nil {
+		return nil
+	}
+	d := &nestedDumpStruct{
+		StructIn:                                s.StructIn,
+		StructOut:                               s.StructOut,
+		FieldGroupBy:                            s.FieldGroupBy,
+		FieldName:                               s.FieldName,
+		FieldType:                               s.FieldType,
+		RowFieldName:                            s.RowFieldName,
+		RowFieldType:                            s.RowFieldType,
+		IsRowFieldExistsInQuery:                 s.IsRowFieldExistsInQuery,
+		RowFieldValid:                           s.RowFieldValid,
+		FieldTags:                               s.FieldTags,
+		KeyType:                                 s.KeyType,
+		IsSlice:                                 s.IsSlice,
+		IsPointer:                               s.IsPointer,
+		IsComposite:                             s.IsComposite,
+		IsEntityStruct:                          s.IsEntityStruct,
+		IsRoot:                                  s.IsRoot,
+		Match:                                   s.Match,
+		DuplicatedRelativeToParents:             s.DuplicatedRelativeToParents,
+		SkipStructGeneration:                    s.SkipStructGeneration,
+		ShouldGenerateEntityToCompositeFunction: s.ShouldGenerateEntityToCompositeFunction,
+		Fields:                                  []nestedDumpField{},
+	}
+	for _, f := range s.Fields {
+		d.Fields = append(d.Fields, nestedDumpField{Name: f.Name, DBName: f.DBName, Type: f.Type, Tags: f.Tags})
+	}
+	for _, child := range s.NestedStructs {
+		d.NestedStructs = append(d.NestedStructs, dumpNestedStruct(child))
+	}
+	return d
+}
@@ -0,0 +1,48 @@
+package golang
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestDumpNestedData(t *testing.T) {
+	req := syntheticRequest(4, 6, 3)
+	var options map[string]any
+	if err := json.Unmarshal(req.PluginOptions, &options); err != nil {
+		t.Fatal(err)
+	}
+	options["dump_nested_data"] = true
+	req.PluginOptions, _ = json.Marshal(options)
+
+	resp, err := Generate(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dump nestedDump
+	for _, f := range resp.Files {
+		if f.Name == "nested_data.json" {
+			if err := json.Unmarshal(f.Contents, &dump); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	if dump.Context.Package != "db" || dump.Context.SQLDriver != "github.com/jackc/pgx/v5" {
+		t.Errorf("unexpected context %+v", dump.Context)
+	}
+	if len(dump.Sources) != 1 || len(dump.Sources[0].Items) != 1 {
+		t.Fatalf("got sources %+v, want one nested query", dump.Sources)
+	}
+	item := dump.Sources[0].Items[0]
+	if item.Query == nil || item.Query.MethodName != "ListNested" || item.Query.Cmd != ":many" {
+		t.Errorf("unexpected query %+v", item.Query)
+	}
+	root := item.RootStructData
+	if root == nil || !root.IsRoot || len(root.NestedStructs) != 3 {
+		t.Fatalf("unexpected root struct %+v", root)
+	}
+	if got := root.NestedStructs[0].StructIn; got != "Table1" {
+		t.Errorf("first nested struct is %s, want Table1", got)
+	}
+}
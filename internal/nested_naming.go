@@ -4,7 +4,7 @@ import (
 	"regexp"
 	"strings"
 
-	"github.com/gobuffalo/flect"
+	"github.com/sqlc-dev/sqlc-gen-go/internal/inflection"
 )
 
 // namingCamelPattern regex for identifying camelCase word boundaries
@@ -141,12 +141,12 @@ func inflectCasePreserving(word string, inflectFunc func(string) string) string
 
 // PluralizeCasePreserving properly pluralizes a word while preserving its case
 func PluralizeCasePreserving(word string) string {
-	return inflectCasePreserving(word, flect.Pluralize)
+	return inflectCasePreserving(word, inflection.Pluralize)
 }
 
 // SingularizeCasePreserving properly singularizes a word while preserving its case
 func SingularizeCasePreserving(word string) string {
-	return inflectCasePreserving(word, flect.Singularize)
+	return inflectCasePreserving(word, inflection.Singularize)
 }
 
 // ToCamelCase converts a string to camelCase with proper initialism handling
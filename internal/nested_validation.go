@@ -29,8 +29,8 @@ func validateNestedInterfaceCompatibility(rootStruct *NestedStructData, rootStru
 	// Collect all methods required by the root interface
 	rootMethods := collectRequiredMethods(rootStruct)
 
-	debug.Printf("Validating interface compatibility for root struct: %s", rootStructName)
-	debug.Printf("Root interface requires %d methods", len(rootMethods))
+	debug.NestedBuilder.Printf("Validating interface compatibility for root struct: %s", rootStructName)
+	debug.NestedBuilder.Printf("Root interface requires %d methods", len(rootMethods))
 
 	// Recursively validate nested composites
 	return validateNestedCompositeInterfaces(rootStruct, rootMethods, rootStructName)
@@ -46,14 +46,14 @@ func validateNestedCompositeInterfaces(
 	for _, nestedStruct := range parentStruct.NestedStructs {
 		// Only validate composites that will call populate functions
 		if nestedStruct.IsComposite {
-			debug.Printf("Validating composite: %s (nested in %s)", nestedStruct.StructOut, parentStruct.StructOut)
+			debug.NestedBuilder.Printf("Validating composite: %s (nested in %s)", nestedStruct.StructOut, parentStruct.StructOut)
 
 			// Collect methods required by this nested composite
 			// For nested composites, we need to collect ALL methods they need,
 			// regardless of whether they exist in the parent query
 			nestedMethods := collectCompositeRequiredMethods(nestedStruct)
 
-			debug.Printf("Nested composite %s requires %d methods", nestedStruct.StructOut, len(nestedMethods))
+			debug.NestedBuilder.Printf("Nested composite %s requires %d methods", nestedStruct.StructOut, len(nestedMethods))
 
 			// Validate that parent interface has all methods required by nested composite
 			missingMethods := findMissingMethods(parentMethods, nestedMethods)
@@ -76,7 +76,7 @@ func validateNestedCompositeInterfaces(
 				)
 			}
 
-			debug.Printf("✓ Composite %s is compatible", nestedStruct.StructOut)
+			debug.NestedBuilder.Printf("✓ Composite %s is compatible", nestedStruct.StructOut)
 
 			// Recursively validate nested composites of this composite
 			// They inherit the parent's available methods
@@ -167,11 +167,11 @@ func validateCompositeFieldsAgainstEntity(
 	if entityStruct == nil {
 		// Entity struct not found - this could be a composite referencing another composite
 		// Skip validation in this case as it's handled by interface validation
-		debug.Printf("Skipping validation for composite '%s' - entity struct '%s' not found", compositeStructName, entityStructName)
+		debug.NestedBuilder.Printf("Skipping validation for composite '%s' - entity struct '%s' not found", compositeStructName, entityStructName)
 		return nil
 	}
 
-	debug.Printf("Validating %s composite '%s' fields against entity '%s'",
+	debug.NestedBuilder.Printf("Validating %s composite '%s' fields against entity '%s'",
 		contextType, compositeStructName, entityStructName)
 
 	// Check that all fields in the composite exist in the entity struct
@@ -0,0 +1,93 @@
+package golang
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/sqlc-dev/plugin-sdk-go/metadata"
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+// nullableEmbedCommands are the commands of the queries whose embeds are
+// scanned through the nullable variables of the pgx templates
+var nullableEmbedCommands = map[string]struct{}{
+	metadata.CmdOne:  {},
+	metadata.CmdMany: {},
+}
+
+// outerJoinPattern matches a LEFT or FULL JOIN of the table, whose columns
+// are all NULL when no row matches
+func outerJoinPattern(table *plugin.Identifier) *regexp.Regexp {
+	name := `"?` + regexp.QuoteMeta(table.Name) + `"?`
+	return regexp.MustCompile(`(?i)\b(?:LEFT|FULL)\s+(?:OUTER\s+)?JOIN\s+(?:"?\w+"?\.)?` + name + `(?:\s|$)`)
+}
+
+// outerJoinedEmbed reports whether the sqlc.embed of the table is on the
+// nullable side of a LEFT or FULL JOIN of a :one or :many query of a pgx
+// package
+func outerJoinedEmbed(driver opts.SQLDriver, query *plugin.Query, table *plugin.Identifier) bool {
+	if !driver.IsPGX() {
+		return false
+	}
+	if _, ok := nullableEmbedCommands[query.Cmd]; !ok {
+		return false
+	}
+	return outerJoinPattern(table).MatchString(query.Text)
+}
+
+// isNestedQuery reports whether the rows of the query are grouped by a
+// nested config
+func isNestedQuery(options *opts.Options, query *plugin.Query) bool {
+	if options.Nested == nil {
+		return false
+	}
+	for _, config := range options.Nested.Queries {
+		if config.Query == QueryName(query.Name, options) {
+			return true
+		}
+	}
+	return false
+}
+
+// nullableEmbed reports whether the sqlc.embed of the table is emitted as a
+// pointer with emit_nullable_embed_pointers. The rows of nested queries are
+// grouped by their embeds, which are kept as values.
+func nullableEmbed(options *opts.Options, driver opts.SQLDriver, query *plugin.Query, table *plugin.Identifier) bool {
+	if !options.EmitNullableEmbedPointers || isNestedQuery(options, query) {
+		return false
+	}
+	return outerJoinedEmbed(driver, query, table)
+}
+
+// validFlagEmbed reports whether the sqlc.embed of the table gets a Valid
+// flag with emit_embed_valid_flags. Every embed of a nested query gets one,
+// so that the grouping code, which is shared by the queries returning the
+// same composite, can consult the flags of all of them.
+func validFlagEmbed(options *opts.Options, driver opts.SQLDriver, query *plugin.Query, table *plugin.Identifier) bool {
+	if !options.EmitEmbedValidFlags || !driver.IsPGX() {
+		return false
+	}
+	if _, ok := nullableEmbedCommands[query.Cmd]; ok && isNestedQuery(options, query) {
+		return true
+	}
+	return outerJoinedEmbed(driver, query, table)
+}
+
+// getEmbedNotNullCheck returns the condition under which a nullable embed is
+// set or valid: one of the variables its columns are scanned into holds a
+// value. Columns whose scan type has no NULL state are left out.
+func getEmbedNotNullCheck(varPrefix string, field Field, modelsPackage string) string {
+	var checks []string
+	for _, embed := range field.EmbedFields {
+		name := varPrefix + embed.Name
+		scanType := getEmbedScanType(embed, modelsPackage)
+		switch {
+		case isNilableGoType(scanType):
+			checks = append(checks, name+" != nil")
+		case scanType != embed.Type || isNullWrapperType(scanType):
+			checks = append(checks, name+".Valid")
+		}
+	}
+	return strings.Join(checks, " || ")
+}
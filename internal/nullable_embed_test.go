@@ -0,0 +1,55 @@
+package golang
+
+import (
+	"testing"
+
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+func TestOuterJoinedEmbed(t *testing.T) {
+	books := &plugin.Identifier{Schema: "public", Name: "books"}
+	for _, test := range []struct {
+		driver opts.SQLDriver
+		cmd    string
+		text   string
+		want   bool
+	}{
+		{opts.SQLDriverPGXV5, ":many", "SELECT sqlc.embed(authors), sqlc.embed(books) FROM authors LEFT JOIN books ON books.author_id = authors.id", true},
+		{opts.SQLDriverPGXV4, ":one", "SELECT * FROM authors left outer join public.books ON true", true},
+		{opts.SQLDriverPGXV5, ":many", `SELECT * FROM authors FULL JOIN "books" ON true`, true},
+		{opts.SQLDriverPGXV5, ":many", "SELECT * FROM authors JOIN books ON true", false},
+		{opts.SQLDriverPGXV5, ":many", "SELECT * FROM authors LEFT JOIN books_archive ON true", false},
+		{opts.SQLDriverPGXV5, ":exec", "SELECT * FROM authors LEFT JOIN books ON true", false},
+		{opts.SQLDriverLibPQ, ":many", "SELECT * FROM authors LEFT JOIN books ON true", false},
+	} {
+		query := &plugin.Query{Cmd: test.cmd, Text: test.text}
+		if got := outerJoinedEmbed(test.driver, query, books); got != test.want {
+			t.Errorf("outerJoinedEmbed(%s, %s %q) = %v, want %v", test.driver, test.cmd, test.text, got, test.want)
+		}
+	}
+}
+
+func TestValidFlagEmbed(t *testing.T) {
+	books := &plugin.Identifier{Schema: "public", Name: "books"}
+	joined := &plugin.Query{Name: "ListAuthorsWithBooks", Cmd: ":many", Text: "SELECT * FROM authors JOIN books ON true"}
+	outer := &plugin.Query{Name: "ListAuthorsWithBooks", Cmd: ":many", Text: "SELECT * FROM authors LEFT JOIN books ON true"}
+	nested := &opts.Options{EmitEmbedValidFlags: true, Nested: &opts.NestedConfig{Queries: []*opts.NestedQueryConfig{{Query: "ListAuthorsWithBooks"}}}}
+	for _, test := range []struct {
+		options *opts.Options
+		driver  opts.SQLDriver
+		query   *plugin.Query
+		want    bool
+	}{
+		{&opts.Options{EmitEmbedValidFlags: true}, opts.SQLDriverPGXV5, outer, true},
+		{&opts.Options{EmitEmbedValidFlags: true}, opts.SQLDriverPGXV5, joined, false},
+		{&opts.Options{}, opts.SQLDriverPGXV5, outer, false},
+		{&opts.Options{EmitEmbedValidFlags: true}, opts.SQLDriverLibPQ, outer, false},
+		// Every embed of a nested query gets a flag
+		{nested, opts.SQLDriverPGXV5, joined, true},
+	} {
+		if got := validFlagEmbed(test.options, test.driver, test.query, books); got != test.want {
+			t.Errorf("validFlagEmbed(%+v, %s, %q) = %v, want %v", test.options, test.driver, test.query.Text, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package golang
+
+import (
+	"strings"
+)
+
+// NullConversion describes a pair of helpers converting between a pgtype
+// wrapper and a pointer to its Go value, e.g. TextToPtr and PtrToText
+type NullConversion struct {
+	Name       string // Helper name stem, e.g. "Text"
+	Type       string // The pgtype wrapper, e.g. "pgtype.Text"
+	GoType     string // The wrapped Go type, e.g. "string"
+	ValueField string // The wrapper field holding the value, e.g. "String"
+}
+
+// pgtypeConversions lists the pgx v5 wrappers that have a plain Go value and a
+// Valid flag, in the order their helpers are emitted
+var pgtypeConversions = []NullConversion{
+	{Name: "Text", Type: "pgtype.Text", GoType: "string", ValueField: "String"},
+	{Name: "Bool", Type: "pgtype.Bool", GoType: "bool", ValueField: "Bool"},
+	{Name: "Int2", Type: "pgtype.Int2", GoType: "int16", ValueField: "Int16"},
+	{Name: "Int4", Type: "pgtype.Int4", GoType: "int32", ValueField: "Int32"},
+	{Name: "Int8", Type: "pgtype.Int8", GoType: "int64", ValueField: "Int64"},
+	{Name: "Float4", Type: "pgtype.Float4", GoType: "float32", ValueField: "Float32"},
+	{Name: "Float8", Type: "pgtype.Float8", GoType: "float64", ValueField: "Float64"},
+	{Name: "UUID", Type: "pgtype.UUID", GoType: "[16]byte", ValueField: "Bytes"},
+	{Name: "Date", Type: "pgtype.Date", GoType: "time.Time", ValueField: "Time"},
+	{Name: "Timestamp", Type: "pgtype.Timestamp", GoType: "time.Time", ValueField: "Time"},
+	{Name: "Timestamptz", Type: "pgtype.Timestamptz", GoType: "time.Time", ValueField: "Time"},
+}
+
+// buildNullConversions returns the conversions for the pgtype wrappers that
+// are actually used by the generated models and queries
+func buildNullConversions(structs []Struct, queries []Query) []NullConversion {
+	used := usedTypes(structs, queries)
+	var conversions []NullConversion
+	for _, c := range pgtypeConversions {
+		if _, ok := used[c.Type]; ok {
+			conversions = append(conversions, c)
+		}
+	}
+	return conversions
+}
+
+// usedTypes returns the set of field types referenced by the models and
+// queries, with slice and pointer markers removed
+func usedTypes(structs []Struct, queries []Query) map[string]struct{} {
+	used := map[string]struct{}{}
+	addFields := func(fields []Field) {
+		for _, f := range fields {
+			used[trimSliceAndPointerPrefix(f.Type)] = struct{}{}
+			for _, ef := range f.EmbedFields {
+				used[trimSliceAndPointerPrefix(ef.Type)] = struct{}{}
+			}
+		}
+	}
+	addValue := func(v QueryValue) {
+		if v.isEmpty() {
+			return
+		}
+		if v.IsStruct() {
+			addFields(v.Struct.Fields)
+			return
+		}
+		used[trimSliceAndPointerPrefix(v.Typ)] = struct{}{}
+	}
+
+	for _, s := range structs {
+		addFields(s.Fields)
+	}
+	for _, q := range queries {
+		addValue(q.Arg)
+		addValue(q.Ret)
+	}
+	return used
+}
+
+// usesTimeConversion reports whether any of the conversions wraps time.Time
+func usesTimeConversion(conversions []NullConversion) bool {
+	for _, c := range conversions {
+		if strings.HasPrefix(c.GoType, "time.") {
+			return true
+		}
+	}
+	return false
+}
+
+// nullWrapper is a Go type holding a value that may be NULL besides Valid
+type nullWrapper struct {
+	ValueField string // e.g. "String"
+	ValueType  string // e.g. "string"
+}
+
+// findNullWrapper returns how the Go type typ holds a NULL value, for the
+// pgtype wrappers, the database/sql null types and null enums, which hold the
+// enum in the field of its name
+func findNullWrapper(enums map[string]struct{}, typ string) (nullWrapper, bool) {
+	for _, c := range pgtypeConversions {
+		if c.Type == typ {
+			return nullWrapper{ValueField: c.ValueField, ValueType: c.GoType}, true
+		}
+	}
+	if valueType, ok := jsonSchemaSQLNulls[typ]; ok {
+		return nullWrapper{ValueField: strings.TrimPrefix(typ, "sql.Null"), ValueType: valueType}, true
+	}
+	pkg, name := "", typ
+	if i := strings.LastIndex(typ, "."); i >= 0 {
+		pkg, name = typ[:i+1], typ[i+1:]
+	}
+	if enum := strings.TrimPrefix(name, "Null"); enum != name {
+		if _, ok := enums[enum]; ok {
+			return nullWrapper{ValueField: enum, ValueType: pkg + enum}, true
+		}
+	}
+	return nullWrapper{}, false
+}
@@ -0,0 +1,36 @@
+package golang
+
+import (
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+// decimalType maps numeric/decimal columns to the decimal library selected by
+// the numeric_type option. Both shopspring/decimal and cockroachdb/apd
+// implement sql.Scanner and driver.Valuer on their Decimal and NullDecimal
+// types, so the same mapping works for pgx and database/sql.
+//
+// The second return value is false when no decimal library is configured or
+// the column is not a decimal column, in which case the driver default is used.
+func decimalType(options *opts.Options, columnType string, notNull, emitPointersForNull bool) (string, bool) {
+	if columnType == "money" {
+		return "", false
+	}
+
+	var pkg string
+	switch options.NumericType {
+	case opts.NumericTypeShopspring:
+		pkg = "decimal"
+	case opts.NumericTypeApd:
+		pkg = "apd"
+	default:
+		return "", false
+	}
+
+	if notNull {
+		return pkg + ".Decimal", true
+	}
+	if emitPointersForNull {
+		return "*" + pkg + ".Decimal", true
+	}
+	return pkg + ".NullDecimal", true
+}
@@ -0,0 +1,33 @@
+package golang
+
+import (
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+// omittedColumns returns the set of columns of table that are excluded from
+// its model by the omit_columns option. Tables are matched by name, with or
+// without their schema.
+func omittedColumns(options *opts.Options, defaultSchema string, table *plugin.Identifier) map[string]struct{} {
+	schema := table.Schema
+	if schema == "" {
+		schema = defaultSchema
+	}
+	omitted := map[string]struct{}{}
+	for name, columns := range options.OmitColumns {
+		rel, err := parseIdentifierString(name)
+		if err != nil {
+			continue
+		}
+		if rel.Schema == "" {
+			rel.Schema = defaultSchema
+		}
+		if rel.Schema != schema || rel.Name != table.Name {
+			continue
+		}
+		for _, c := range columns {
+			omitted[c] = struct{}{}
+		}
+	}
+	return omitted
+}
@@ -0,0 +1,37 @@
+package golang
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+func TestOmittedColumns(t *testing.T) {
+	options := &opts.Options{OmitColumns: map[string][]string{
+		"users":               {"password_hash"},
+		"public.users":        {"totp_secret"},
+		"audit.users":         {"ip"},
+		"invalid.name.of.one": {"id"},
+	}}
+	for _, tc := range []struct {
+		table *plugin.Identifier
+		want  []string
+	}{
+		{&plugin.Identifier{Name: "users"}, []string{"password_hash", "totp_secret"}},
+		{&plugin.Identifier{Schema: "public", Name: "users"}, []string{"password_hash", "totp_secret"}},
+		{&plugin.Identifier{Schema: "audit", Name: "users"}, []string{"ip"}},
+		{&plugin.Identifier{Name: "books"}, nil},
+	} {
+		var got []string
+		for c := range omittedColumns(options, "public", tc.table) {
+			got = append(got, c)
+		}
+		slices.Sort(got)
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("omittedColumns(%s.%s) = %v, want %v", tc.table.Schema, tc.table.Name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package golang
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// openAPIRefPrefix is where component schemas are referenced from, OpenAPI
+// 3.1 schemas are JSON Schema 2020-12 so the definitions are shared with
+// emit_json_schema
+const openAPIRefPrefix = "#/components/schemas/"
+
+// buildOpenAPI returns an OpenAPI 3.1 document whose components are the
+// schemas of the named models, result structs and nested composites, along
+// with the schemas they refer to
+func buildOpenAPI(title string, names []string, enums []Enum, structs []Struct, queries []Query, nested []Nested) ([]byte, error) {
+	b := newJSONSchemaBuilder(openAPIRefPrefix, enums)
+	b.addStructs(structs, queries, nested)
+
+	schemas := map[string]any{}
+	var add func(name string)
+	add = func(name string) {
+		if _, ok := schemas[name]; ok {
+			return
+		}
+		schemas[name] = b.defs[name]
+		walkSchemaRefs(b.defs[name], add)
+	}
+	for _, name := range names {
+		if _, ok := b.defs[name]; !ok {
+			return nil, fmt.Errorf("openapi_schemas: %s is not a generated model, result struct or composite", name)
+		}
+		add(name)
+	}
+
+	doc, err := json.MarshalIndent(map[string]any{
+		"openapi": "3.1.0",
+		"info": map[string]any{
+			"title":   title,
+			"version": "0.0.0",
+		},
+		"components": map[string]any{
+			"schemas": schemas,
+		},
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(doc, '\n'), nil
+}
+
+// walkSchemaRefs calls f with the name of each component schema s refers to
+func walkSchemaRefs(s any, f func(name string)) {
+	switch s := s.(type) {
+	case map[string]any:
+		if ref, ok := s["$ref"].(string); ok {
+			f(strings.TrimPrefix(ref, openAPIRefPrefix))
+		}
+		for _, v := range s {
+			walkSchemaRefs(v, f)
+		}
+	case []any:
+		for _, v := range s {
+			walkSchemaRefs(v, f)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package golang
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestBuildOpenAPI(t *testing.T) {
+	enums := []Enum{{Name: "Status", Constants: []Constant{{Name: "StatusOpen", Value: "open"}}}}
+	structs := []Struct{
+		{Name: "Author", Fields: []Field{{Name: "Name", Type: "string"}, {Name: "Status", Type: "Status"}}},
+		{Name: "Book", Fields: []Field{{Name: "Title", Type: "string"}, {Name: "Author", Type: "*Author"}}},
+		{Name: "Shelf", Fields: []Field{{Name: "Label", Type: "string"}}},
+	}
+
+	out, err := buildOpenAPI("db", []string{"Book"}, enums, structs, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		OpenAPI    string `json:"openapi"`
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.OpenAPI != "3.1.0" {
+		t.Errorf("openapi = %q, want 3.1.0", doc.OpenAPI)
+	}
+	var names []string
+	for name := range doc.Components.Schemas {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	if want := []string{"Author", "Book", "Status"}; !slices.Equal(names, want) {
+		t.Errorf("schemas = %v, want %v", names, want)
+	}
+
+	if _, err := buildOpenAPI("db", []string{"Missing"}, enums, structs, nil, nil); err == nil {
+		t.Error("expected an error for an unknown schema")
+	}
+}
@@ -23,11 +23,140 @@ func validatePackage(sqlPackage string) error {
 	return nil
 }
 
+const (
+	UUIDTypePgtype string = "pgtype"
+	UUIDTypeGoogle string = "google"
+)
+
+var validUUIDTypes = map[string]struct{}{
+	UUIDTypePgtype: {},
+	UUIDTypeGoogle: {},
+}
+
+func validateUUIDType(uuidType string) error {
+	if _, found := validUUIDTypes[uuidType]; !found {
+		return fmt.Errorf("unknown uuid type: %s", uuidType)
+	}
+	return nil
+}
+
+const (
+	NumericTypePgtype     string = "pgtype"
+	NumericTypeShopspring string = "shopspring"
+	NumericTypeApd        string = "apd"
+)
+
+var validNumericTypes = map[string]struct{}{
+	NumericTypePgtype:     {},
+	NumericTypeShopspring: {},
+	NumericTypeApd:        {},
+}
+
+func validateNumericType(numericType string) error {
+	if _, found := validNumericTypes[numericType]; !found {
+		return fmt.Errorf("unknown numeric type: %s", numericType)
+	}
+	return nil
+}
+
+const (
+	GeometryTypeGoGeom string = "go-geom"
+	GeometryTypeOrb    string = "orb"
+)
+
+var validGeometryTypes = map[string]struct{}{
+	GeometryTypeGoGeom: {},
+	GeometryTypeOrb:    {},
+}
+
+func validateGeometryType(geometryType string) error {
+	if _, found := validGeometryTypes[geometryType]; !found {
+		return fmt.Errorf("unknown geometry type: %s", geometryType)
+	}
+	return nil
+}
+
+const (
+	CopyfromLocationUTC   string = "UTC"
+	CopyfromLocationLocal string = "Local"
+)
+
+var validCopyfromLocations = map[string]struct{}{
+	CopyfromLocationUTC:   {},
+	CopyfromLocationLocal: {},
+}
+
+func validateCopyfromLocation(location string) error {
+	if _, found := validCopyfromLocations[location]; !found {
+		return fmt.Errorf("unknown copyfrom location: %s", location)
+	}
+	return nil
+}
+
+const (
+	ArrayNullsElements string = "elements"
+	ArrayNullsArray    string = "array"
+	ArrayNullsBoth     string = "both"
+)
+
+var validArrayNulls = map[string]struct{}{
+	ArrayNullsElements: {},
+	ArrayNullsArray:    {},
+	ArrayNullsBoth:     {},
+}
+
+func validateArrayNulls(arrayNulls string) error {
+	if _, found := validArrayNulls[arrayNulls]; !found {
+		return fmt.Errorf("unknown array nulls mode: %s", arrayNulls)
+	}
+	return nil
+}
+
+const (
+	StructTagsModeReplace    string = "replace"
+	StructTagsModeMerge      string = "merge"
+	StructTagsModeReplaceAll string = "replace_all"
+)
+
+var validStructTagsModes = map[string]struct{}{
+	StructTagsModeReplace:    {},
+	StructTagsModeMerge:      {},
+	StructTagsModeReplaceAll: {},
+}
+
+func validateStructTagsMode(mode string) error {
+	if _, found := validStructTagsModes[mode]; !found {
+		return fmt.Errorf("unknown struct tags mode: %s", mode)
+	}
+	return nil
+}
+
+const (
+	FieldOrderColumn       string = "column"
+	FieldOrderAlphabetical string = "alphabetical"
+	FieldOrderCanonical    string = "canonical"
+)
+
+var validFieldOrders = map[string]struct{}{
+	FieldOrderColumn:       {},
+	FieldOrderAlphabetical: {},
+	FieldOrderCanonical:    {},
+}
+
+func validateFieldOrder(fieldOrder string) error {
+	if _, found := validFieldOrders[fieldOrder]; !found {
+		return fmt.Errorf("unknown field order: %s", fieldOrder)
+	}
+	return nil
+}
+
 const (
 	SQLDriverPGXV4            SQLDriver = "github.com/jackc/pgx/v4"
 	SQLDriverPGXV5                      = "github.com/jackc/pgx/v5"
 	SQLDriverLibPQ                      = "github.com/lib/pq"
 	SQLDriverGoSQLDriverMySQL           = "github.com/go-sql-driver/mysql"
+	SQLDriverModerncSQLite              = "modernc.org/sqlite"
+	SQLDriverMattnSQLite3               = "github.com/mattn/go-sqlite3"
 )
 
 var validDrivers = map[string]struct{}{
@@ -35,6 +164,8 @@ var validDrivers = map[string]struct{}{
 	string(SQLDriverPGXV5):            {},
 	string(SQLDriverLibPQ):            {},
 	string(SQLDriverGoSQLDriverMySQL): {},
+	string(SQLDriverModerncSQLite):    {},
+	string(SQLDriverMattnSQLite3):     {},
 }
 
 func validateDriver(sqlDriver string) error {
@@ -52,6 +183,10 @@ func (d SQLDriver) IsGoSQLDriverMySQL() bool {
 	return d == SQLDriverGoSQLDriverMySQL
 }
 
+func (d SQLDriver) IsSQLite() bool {
+	return d == SQLDriverModerncSQLite || d == SQLDriverMattnSQLite3
+}
+
 func (d SQLDriver) Package() string {
 	switch d {
 	case SQLDriverPGXV4:
@@ -62,3 +197,80 @@ func (d SQLDriver) Package() string {
 		return SQLPackageStandard
 	}
 }
+
+const (
+	FormatterGofmt     string = "gofmt"
+	FormatterGofumpt   string = "gofumpt"
+	FormatterGoimports string = "goimports"
+)
+
+var validFormatters = map[string]struct{}{
+	FormatterGofmt:     {},
+	FormatterGofumpt:   {},
+	FormatterGoimports: {},
+}
+
+func validateFormatter(formatter string) error {
+	if _, found := validFormatters[formatter]; !found {
+		return fmt.Errorf("unknown formatter: %s", formatter)
+	}
+	return nil
+}
+
+// File kinds name the generated artifacts that file_build_tags applies to
+const (
+	FileKindDb          string = "db"
+	FileKindModels      string = "models"
+	FileKindQuerier     string = "querier"
+	FileKindQueries     string = "queries"
+	FileKindCopyfrom    string = "copyfrom"
+	FileKindBatch       string = "batch"
+	FileKindNested      string = "nested"
+	FileKindNestedUtils string = "nested_utils"
+	FileKindNullConv    string = "nullconv"
+	FileKindRanges      string = "ranges"
+	FileKindPostgis     string = "postgis"
+	FileKindDoc         string = "doc"
+	FileKindReadWrite   string = "readwrite"
+	FileKindBackground  string = "background"
+	FileKindExplain     string = "explain"
+	FileKindRetry       string = "retry"
+	FileKindCache       string = "cache"
+	FileKindProto       string = "proto"
+	FileKindGraphQL     string = "graphql"
+	FileKindFixtures    string = "fixtures"
+	FileKindIntegration string = "integration_test"
+	FileKindVersion     string = "version"
+)
+
+var validFileKinds = map[string]struct{}{
+	FileKindDb:          {},
+	FileKindModels:      {},
+	FileKindQuerier:     {},
+	FileKindQueries:     {},
+	FileKindCopyfrom:    {},
+	FileKindBatch:       {},
+	FileKindNested:      {},
+	FileKindNestedUtils: {},
+	FileKindNullConv:    {},
+	FileKindRanges:      {},
+	FileKindPostgis:     {},
+	FileKindDoc:         {},
+	FileKindReadWrite:   {},
+	FileKindBackground:  {},
+	FileKindExplain:     {},
+	FileKindRetry:       {},
+	FileKindCache:       {},
+	FileKindProto:       {},
+	FileKindGraphQL:     {},
+	FileKindFixtures:    {},
+	FileKindIntegration: {},
+	FileKindVersion:     {},
+}
+
+func validateFileKind(kind string) error {
+	if _, found := validFileKinds[kind]; !found {
+		return fmt.Errorf("unknown file kind: %s", kind)
+	}
+	return nil
+}
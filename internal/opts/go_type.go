@@ -3,7 +3,6 @@ package opts
 import (
 	"encoding/json"
 	"fmt"
-	"go/types"
 	"regexp"
 	"strings"
 
@@ -44,7 +43,7 @@ func (o *GoType) UnmarshalJSON(data []byte) error {
 	}
 	type alias GoType
 	var a alias
-	if err := json.Unmarshal(data, &a); err != nil {
+	if err := unmarshalStrict(data, &a); err != nil {
 		return err
 	}
 	*o = GoType(a)
@@ -84,6 +83,28 @@ func generatePackageID(importPath string) (string, bool) {
 	return invalidIdentifier.ReplaceAllString(strings.ToLower(name), "_"), true
 }
 
+// basicTypes are the typed basic types of go/types, listed here so that the
+// plugin does not link the type checker
+var basicTypes = map[string]struct{}{
+	"bool":       {},
+	"int":        {},
+	"int8":       {},
+	"int16":      {},
+	"int32":      {},
+	"int64":      {},
+	"uint":       {},
+	"uint8":      {},
+	"uint16":     {},
+	"uint32":     {},
+	"uint64":     {},
+	"uintptr":    {},
+	"float32":    {},
+	"float64":    {},
+	"complex64":  {},
+	"complex128": {},
+	"string":     {},
+}
+
 // validate GoType
 func (gt GoType) parse() (*ParsedGoType, error) {
 	var o ParsedGoType
@@ -127,20 +148,7 @@ func (gt GoType) parse() (*ParsedGoType, error) {
 	typename := input
 	if lastDot == -1 && lastSlash == -1 {
 		// if the type name has no slash and no dot, validate that the type is a basic Go type
-		var found bool
-		for _, typ := range types.Typ {
-			info := typ.Info()
-			if info == 0 {
-				continue
-			}
-			if info&types.IsUntyped != 0 {
-				continue
-			}
-			if typename == typ.Name() {
-				found = true
-			}
-		}
-		if !found {
+		if _, found := basicTypes[typename]; !found {
 			return nil, fmt.Errorf("Package override `go_type` specifier %q is not a Go basic type e.g. 'string'", input)
 		}
 		o.BasicType = true
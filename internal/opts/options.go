@@ -1,12 +1,22 @@
 package opts
 
 import (
+	"bytes"
+	"cmp"
 	"encoding/json"
 	"fmt"
+	"go/token"
 	"maps"
+	"path"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
 
 	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/debug"
 )
 
 // NestedConfig represents the configuration for nested queries with predefined composites
@@ -64,8 +74,101 @@ type NestedQueryConfig struct {
 	IsComposite  *bool                `json:"composite,omitempty" yaml:"composite"`           // Is composite struct
 }
 
+// CompositeTypeConfig declares a composite (row) type to generate a Go struct
+// for. sqlc does not pass the attributes of CREATE TYPE ... AS composites to
+// plugins, so they are declared here, while the row type of a table takes
+// them from its columns.
+type CompositeTypeConfig struct {
+	Name   string                  `json:"name" yaml:"name"`               // Type name, optionally schema qualified (required)
+	Fields []*CompositeFieldConfig `json:"fields,omitempty" yaml:"fields"` // Attributes in declaration order (required unless the type is a table's)
+}
+
+// CompositeFieldConfig represents a single attribute of a composite type
+type CompositeFieldConfig struct {
+	Name    string `json:"name" yaml:"name"`                   // Attribute name (required)
+	DBType  string `json:"db_type" yaml:"db_type"`             // Attribute database type (required)
+	NotNull bool   `json:"not_null,omitempty" yaml:"not_null"` // Whether the attribute is never NULL
+}
+
+// EnumConfig customizes the constants generated for an enum type
+type EnumConfig struct {
+	Name       string            `json:"name" yaml:"name"`                         // Enum name, optionally schema qualified (required)
+	TrimPrefix string            `json:"trim_prefix,omitempty" yaml:"trim_prefix"` // Prefix removed from values before naming constants
+	Rename     map[string]string `json:"rename,omitempty" yaml:"rename"`           // Constant names by enum value
+}
+
+// PackageConfig moves the queries of the SQL files matching Match into a
+// separate package, so that one plugin invocation can generate several
+// packages
+type PackageConfig struct {
+	Match      string `json:"match" yaml:"match"`                       // Glob matched against query file names (required)
+	Package    string `json:"package" yaml:"package"`                   // Package name (required)
+	Out        string `json:"out" yaml:"out"`                           // Output directory, relative to out (required)
+	SqlPackage string `json:"sql_package,omitempty" yaml:"sql_package"` // sql_package of the package, that of the main package if empty
+	SqlDriver  string `json:"sql_driver,omitempty" yaml:"sql_driver"`   // sql_driver of the package, that of the main package if empty
+}
+
+// StdlibPackageConfig generates a database/sql variant of the pgx/v5
+// package, sharing its models package
+type StdlibPackageConfig struct {
+	Package string `json:"package" yaml:"package"` // Package name (required)
+	Out     string `json:"out" yaml:"out"`         // Output directory, relative to out (required)
+}
+
+// Manifest lists the files of a generation run so that tools can detect
+// hand edits and remove generated files that no longer have a source. The
+// manifest of a previous run is read back by incremental, or given back to
+// incremental_manifest.
+type Manifest struct {
+	SqlcVersion string         `json:"sqlc_version,omitempty" yaml:"sqlc_version"`
+	Files       []ManifestFile `json:"files" yaml:"files"`
+}
+
+// ManifestFile describes a generated file. Source and Queries are only set
+// for files generated from the queries of a SQL file.
+type ManifestFile struct {
+	Name    string   `json:"name" yaml:"name"`
+	SHA256  string   `json:"sha256" yaml:"sha256"`
+	Source  string   `json:"source,omitempty" yaml:"source"`
+	Queries []string `json:"queries,omitempty" yaml:"queries"`
+	Inputs  string   `json:"inputs,omitempty" yaml:"inputs"` // Hash of the inputs, set by incremental generation
+}
+
+// ProtoTypeMapping maps a Go type to a protobuf field type for emit_proto,
+// converting values with functions of the generated package
+type ProtoTypeMapping struct {
+	GoType      string `json:"go_type" yaml:"go_type"`                     // Go type, e.g. pgtype.Numeric (required)
+	ProtoType   string `json:"proto_type" yaml:"proto_type"`               // Protobuf type, e.g. string (required)
+	ProtoImport string `json:"proto_import,omitempty" yaml:"proto_import"` // .proto file defining ProtoType, if it is a message
+	ToProto     string `json:"to_proto" yaml:"to_proto"`                   // Function converting the Go value to the protobuf one (required)
+	FromProto   string `json:"from_proto" yaml:"from_proto"`               // Function converting the protobuf value to the Go one (required)
+}
+
+// renameRegexPrefix marks rename keys that are regular expressions
+const renameRegexPrefix = "regex:"
+
+// RenamePattern is a rename entry whose key is a regular expression. Names
+// matching Pattern are rewritten with Replacement, which may refer to
+// submatches as $1.
+type RenamePattern struct {
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+// goVersionPattern matches the Go releases go_version may target, e.g. 1.21
+var goVersionPattern = regexp.MustCompile(`^1\.[0-9]+(\.[0-9]+)?$`)
+
+// sqliteVersionPattern matches the SQLite releases sqlite_min_version may
+// name, e.g. 3.35 or 3.35.5
+var sqliteVersionPattern = regexp.MustCompile(`^3\.[0-9]+(\.[0-9]+)?$`)
+
 type Options struct {
 	EmitInterface               bool              `json:"emit_interface" yaml:"emit_interface"`
+	EmitSplitQuerier            bool              `json:"emit_split_querier,omitempty" yaml:"emit_split_querier"`
+	EmitUnexportedQueries       bool              `json:"emit_unexported_queries,omitempty" yaml:"emit_unexported_queries"`
+	QueriesReceiverName         string            `json:"queries_receiver_name,omitempty" yaml:"queries_receiver_name"`
+	EmitValueReceivers          bool              `json:"emit_value_receivers,omitempty" yaml:"emit_value_receivers"`
+	EmitGrouperInterface        bool              `json:"emit_grouper_interface,omitempty" yaml:"emit_grouper_interface"`
 	EmitJsonTags                bool              `json:"emit_json_tags" yaml:"emit_json_tags"`
 	JsonTagsIdUppercase         bool              `json:"json_tags_id_uppercase" yaml:"json_tags_id_uppercase"`
 	EmitDbTags                  bool              `json:"emit_db_tags" yaml:"emit_db_tags"`
@@ -73,39 +176,151 @@ type Options struct {
 	EmitExactTableNames         bool              `json:"emit_exact_table_names,omitempty" yaml:"emit_exact_table_names"`
 	EmitEmptySlices             bool              `json:"emit_empty_slices,omitempty" yaml:"emit_empty_slices"`
 	EmitExportedQueries         bool              `json:"emit_exported_queries" yaml:"emit_exported_queries"`
+	QueryConstantNames          map[string]string `json:"query_constant_names,omitempty" yaml:"query_constant_names"`
+	QueryConstantSuffix         string            `json:"query_constant_suffix,omitempty" yaml:"query_constant_suffix"`
+	SensitiveStructTag          GoStructTag       `json:"sensitive_struct_tag,omitempty" yaml:"sensitive_struct_tag"`
 	EmitResultStructPointers    bool              `json:"emit_result_struct_pointers" yaml:"emit_result_struct_pointers"`
 	EmitParamsStructPointers    bool              `json:"emit_params_struct_pointers" yaml:"emit_params_struct_pointers"`
 	EmitMethodsWithDbArgument   bool              `json:"emit_methods_with_db_argument,omitempty" yaml:"emit_methods_with_db_argument"`
 	EmitPointersForNullTypes    bool              `json:"emit_pointers_for_null_types" yaml:"emit_pointers_for_null_types"`
+	EmitNullableEmbedPointers   bool              `json:"emit_nullable_embed_pointers,omitempty" yaml:"emit_nullable_embed_pointers"`
+	EmitEmbedValidFlags         bool              `json:"emit_embed_valid_flags,omitempty" yaml:"emit_embed_valid_flags"`
 	EmitEnumValidMethod         bool              `json:"emit_enum_valid_method,omitempty" yaml:"emit_enum_valid_method"`
 	EmitAllEnumValues           bool              `json:"emit_all_enum_values,omitempty" yaml:"emit_all_enum_values"`
+	EmitEnumTextMethods         bool              `json:"emit_enum_text_methods,omitempty" yaml:"emit_enum_text_methods"`
+	StrictEnums                 bool              `json:"strict_enums,omitempty" yaml:"strict_enums"`
 	EmitSqlAsComment            bool              `json:"emit_sql_as_comment,omitempty" yaml:"emit_sql_as_comment"`
+	EmitSqlSourceLocation       bool              `json:"emit_sql_source_location,omitempty" yaml:"emit_sql_source_location"`
+	EmitNullConversions         bool              `json:"emit_null_conversions,omitempty" yaml:"emit_null_conversions"`
+	EmitRangeHelpers            bool              `json:"emit_range_helpers,omitempty" yaml:"emit_range_helpers"`
+	EmitDocFile                 bool              `json:"emit_doc_file,omitempty" yaml:"emit_doc_file"`
+	EmitVersionFile             bool              `json:"emit_version_file,omitempty" yaml:"emit_version_file"`
+	EmitGenerationTimestamp     bool              `json:"emit_generation_timestamp,omitempty" yaml:"emit_generation_timestamp"`
+	EmitManifest                bool              `json:"emit_manifest,omitempty" yaml:"emit_manifest"`
+	DryRun                      bool              `json:"dry_run,omitempty" yaml:"dry_run"`
+	Profile                     string            `json:"profile,omitempty" yaml:"profile"`
+	DescribeOptions             bool              `json:"describe_options,omitempty" yaml:"describe_options"`
+	EmitOptionsSchema           bool              `json:"emit_options_schema,omitempty" yaml:"emit_options_schema"`
+	EmitPoolConstructor         bool              `json:"emit_pool_constructor,omitempty" yaml:"emit_pool_constructor"`
+	EmitHealthCheck             bool              `json:"emit_health_check,omitempty" yaml:"emit_health_check"`
+	EmitReadWriteSplit          bool              `json:"emit_read_write_split,omitempty" yaml:"emit_read_write_split"`
+	EmitContextLessMethods      bool              `json:"emit_context_less_methods,omitempty" yaml:"emit_context_less_methods"`
+	EmitExplainMethods          bool              `json:"emit_explain_methods,omitempty" yaml:"emit_explain_methods"`
+	PrepareLazily               bool              `json:"prepare_lazily,omitempty" yaml:"prepare_lazily"`
+	CopyfromChunkSize           int32             `json:"copyfrom_chunk_size,omitempty" yaml:"copyfrom_chunk_size"`
+	EmitCopyfromChunking        bool              `json:"emit_copyfrom_chunking,omitempty" yaml:"emit_copyfrom_chunking"`
+	MysqlCopyfromLocation       string            `json:"mysql_copyfrom_location,omitempty" yaml:"mysql_copyfrom_location"`
+	EmitTypedLastInsertId       bool              `json:"emit_typed_last_insert_id,omitempty" yaml:"emit_typed_last_insert_id"`
+	EmitBatchResultSlices       bool              `json:"emit_batch_result_slices,omitempty" yaml:"emit_batch_result_slices"`
+	EmitBatchErrors             bool              `json:"emit_batch_errors,omitempty" yaml:"emit_batch_errors"`
+	SoftDeleteColumn            string            `json:"soft_delete_column,omitempty" yaml:"soft_delete_column"`
+	EmitSoftDeleteVariants      bool              `json:"emit_soft_delete_variants,omitempty" yaml:"emit_soft_delete_variants"`
+	EmitNargPointers            bool              `json:"emit_narg_pointers,omitempty" yaml:"emit_narg_pointers"`
+	EmitRowEntityMethods        bool              `json:"emit_row_entity_methods,omitempty" yaml:"emit_row_entity_methods"`
+	EmitModelFromRow            bool              `json:"emit_model_from_row,omitempty" yaml:"emit_model_from_row"`
+	EmitJsonSchema              bool              `json:"emit_json_schema,omitempty" yaml:"emit_json_schema"`
+	EmitProto                   bool              `json:"emit_proto,omitempty" yaml:"emit_proto"`
+	ProtoPackage                string            `json:"proto_package,omitempty" yaml:"proto_package"`
+	ProtoGoPackage              string            `json:"proto_go_package,omitempty" yaml:"proto_go_package"`
+	EmitGraphql                 bool              `json:"emit_graphql,omitempty" yaml:"emit_graphql"`
+	OpenapiSchemas              []string          `json:"openapi_schemas,omitempty" yaml:"openapi_schemas"`
+	EmitTypescript              bool              `json:"emit_typescript,omitempty" yaml:"emit_typescript"`
+	EmitFixtures                bool              `json:"emit_fixtures,omitempty" yaml:"emit_fixtures"`
+	EmitIntegrationTests        bool              `json:"emit_integration_tests,omitempty" yaml:"emit_integration_tests"`
+	IntegrationTestImage        string            `json:"integration_test_image,omitempty" yaml:"integration_test_image"`
+	EmitPprof                   bool              `json:"emit_pprof,omitempty" yaml:"emit_pprof"`
+	DebugLog                    string            `json:"debug_log,omitempty" yaml:"debug_log"`
+	DebugLogFormat              string            `json:"debug_log_format,omitempty" yaml:"debug_log_format"`
+	ReportTimings               bool              `json:"report_timings,omitempty" yaml:"report_timings"`
+	DumpNestedData              bool              `json:"dump_nested_data,omitempty" yaml:"dump_nested_data"`
 	JsonTagsCaseStyle           string            `json:"json_tags_case_style,omitempty" yaml:"json_tags_case_style"`
 	Package                     string            `json:"package" yaml:"package"`
 	Out                         string            `json:"out" yaml:"out"`
 	Overrides                   []Override        `json:"overrides,omitempty" yaml:"overrides"`
 	Rename                      map[string]string `json:"rename,omitempty" yaml:"rename"`
+	Domains                     map[string]string `json:"domains,omitempty" yaml:"domains"`
 	SqlPackage                  string            `json:"sql_package" yaml:"sql_package"`
 	SqlDriver                   string            `json:"sql_driver" yaml:"sql_driver"`
+	UuidType                    string            `json:"uuid_type,omitempty" yaml:"uuid_type"`
+	NumericType                 string            `json:"numeric_type,omitempty" yaml:"numeric_type"`
+	GeometryType                string            `json:"geometry_type,omitempty" yaml:"geometry_type"`
+	ArrayNulls                  string            `json:"array_nulls,omitempty" yaml:"array_nulls"`
+	StructFieldOrder            string            `json:"struct_field_order,omitempty" yaml:"struct_field_order"`
+	Formatter                   string            `json:"formatter,omitempty" yaml:"formatter"`
+	GoVersion                   string            `json:"go_version,omitempty" yaml:"go_version"`
+	SqliteMinVersion            string            `json:"sqlite_min_version,omitempty" yaml:"sqlite_min_version"`
 	OutputBatchFileName         string            `json:"output_batch_file_name,omitempty" yaml:"output_batch_file_name"`
 	OutputDbFileName            string            `json:"output_db_file_name,omitempty" yaml:"output_db_file_name"`
 	OutputModelsFileName        string            `json:"output_models_file_name,omitempty" yaml:"output_models_file_name"`
+	OutputEnumsFileName         string            `json:"output_enums_file_name,omitempty" yaml:"output_enums_file_name"`
 	OutputModelsPackage         string            `json:"output_models_package,omitempty" yaml:"output_models_package"`
 	ModelsPackageImportPath     string            `json:"models_package_import_path,omitempty" yaml:"models_package_import_path"`
 	OutputQuerierFileName       string            `json:"output_querier_file_name,omitempty" yaml:"output_querier_file_name"`
 	OutputCopyfromFileName      string            `json:"output_copyfrom_file_name,omitempty" yaml:"output_copyfrom_file_name"`
 	OutputQueryFilesDirectory   string            `json:"output_query_files_directory,omitempty" yaml:"output_query_files_directory"`
 	OutputNestedUtilsFileName   string            `json:"output_nested_utils_file_name,omitempty" yaml:"output_nested_utils_file_name"`
+	OutputGrouperFileName       string            `json:"output_grouper_file_name,omitempty" yaml:"output_grouper_file_name"`
 	OutputFilesSuffix           string            `json:"output_files_suffix,omitempty" yaml:"output_files_suffix"`
+	MirrorQueryDirectories      bool              `json:"mirror_query_directories,omitempty" yaml:"mirror_query_directories"`
+	SchemaPackages              bool              `json:"schema_packages,omitempty" yaml:"schema_packages"`
+	SchemaPackagesImportPath    string            `json:"schema_packages_import_path,omitempty" yaml:"schema_packages_import_path"`
+	OutputNullConvFileName      string            `json:"output_null_conv_file_name,omitempty" yaml:"output_null_conv_file_name"`
+	OutputRangesFileName        string            `json:"output_ranges_file_name,omitempty" yaml:"output_ranges_file_name"`
+	OutputPostgisFileName       string            `json:"output_postgis_file_name,omitempty" yaml:"output_postgis_file_name"`
+	OutputDocFileName           string            `json:"output_doc_file_name,omitempty" yaml:"output_doc_file_name"`
+	OutputVersionFileName       string            `json:"output_version_file_name,omitempty" yaml:"output_version_file_name"`
+	OutputManifestFileName      string            `json:"output_manifest_file_name,omitempty" yaml:"output_manifest_file_name"`
+	OutputDryRunFileName        string            `json:"output_dry_run_file_name,omitempty" yaml:"output_dry_run_file_name"`
+	OutputReadWriteFileName     string            `json:"output_read_write_file_name,omitempty" yaml:"output_read_write_file_name"`
+	OutputBackgroundFileName    string            `json:"output_background_file_name,omitempty" yaml:"output_background_file_name"`
+	OutputExplainFileName       string            `json:"output_explain_file_name,omitempty" yaml:"output_explain_file_name"`
+	OutputRetryFileName         string            `json:"output_retry_file_name,omitempty" yaml:"output_retry_file_name"`
+	OutputCacheFileName         string            `json:"output_cache_file_name,omitempty" yaml:"output_cache_file_name"`
+	OutputOptionsSchemaFileName string            `json:"output_options_schema_file_name,omitempty" yaml:"output_options_schema_file_name"`
+	OutputJsonSchemaFileName    string            `json:"output_json_schema_file_name,omitempty" yaml:"output_json_schema_file_name"`
+	OutputProtoFileName         string            `json:"output_proto_file_name,omitempty" yaml:"output_proto_file_name"`
+	OutputProtoGoFileName       string            `json:"output_proto_go_file_name,omitempty" yaml:"output_proto_go_file_name"`
+	OutputGraphqlFileName       string            `json:"output_graphql_file_name,omitempty" yaml:"output_graphql_file_name"`
+	OutputGraphqlGoFileName     string            `json:"output_graphql_go_file_name,omitempty" yaml:"output_graphql_go_file_name"`
+	OutputOpenapiFileName       string            `json:"output_openapi_file_name,omitempty" yaml:"output_openapi_file_name"`
+	OutputTypescriptFileName    string            `json:"output_typescript_file_name,omitempty" yaml:"output_typescript_file_name"`
+	OutputFixturesFileName      string            `json:"output_fixtures_file_name,omitempty" yaml:"output_fixtures_file_name"`
+	OutputIntegrationFileName   string            `json:"output_integration_file_name,omitempty" yaml:"output_integration_file_name"`
+	OutputPprofDirectory        string            `json:"output_pprof_directory,omitempty" yaml:"output_pprof_directory"`
+	OutputNestedDataFileName    string            `json:"output_nested_data_file_name,omitempty" yaml:"output_nested_data_file_name"`
+	IncrementalManifest         *Manifest         `json:"incremental_manifest,omitempty" yaml:"incremental_manifest"`
+	Incremental                 bool              `json:"incremental,omitempty" yaml:"incremental"`
 	InflectionExcludeTableNames []string          `json:"inflection_exclude_table_names,omitempty" yaml:"inflection_exclude_table_names"`
 	QueryParameterLimit         *int32            `json:"query_parameter_limit,omitempty" yaml:"query_parameter_limit"`
+	EmitParamsBuilders          bool              `json:"emit_params_builders,omitempty" yaml:"emit_params_builders"`
+	ParamsBuilderMinFields      *int32            `json:"params_builder_min_fields,omitempty" yaml:"params_builder_min_fields"`
 	OmitSqlcVersion             bool              `json:"omit_sqlc_version,omitempty" yaml:"omit_sqlc_version"`
 	OmitUnusedStructs           bool              `json:"omit_unused_structs,omitempty" yaml:"omit_unused_structs"`
 	BuildTags                   string            `json:"build_tags,omitempty" yaml:"build_tags"`
+	FileHeader                  string            `json:"file_header,omitempty" yaml:"file_header"`
+	FileHeaderYear              int               `json:"file_header_year,omitempty" yaml:"file_header_year"`
+	HeaderTemplate              string            `json:"header_template,omitempty" yaml:"header_template"`
 	Initialisms                 []string          `json:"initialisms,omitempty" yaml:"initialisms"`
 	Nested                      *NestedConfig     `json:"nested,omitempty" yaml:"nested"`
 
-	InitialismsMap map[string]struct{} `json:"-" yaml:"-"`
+	CompositeTypes    []*CompositeTypeConfig `json:"composite_types,omitempty" yaml:"composite_types"`
+	Enums             []*EnumConfig          `json:"enums,omitempty" yaml:"enums"`
+	OmitColumns       map[string][]string    `json:"omit_columns,omitempty" yaml:"omit_columns"`
+	Packages          []*PackageConfig       `json:"packages,omitempty" yaml:"packages"`
+	StdlibPackage     *StdlibPackageConfig   `json:"stdlib_package,omitempty" yaml:"stdlib_package"`
+	FileBuildTags     map[string]string      `json:"file_build_tags,omitempty" yaml:"file_build_tags"`
+	ProtoTypeMappings []*ProtoTypeMapping    `json:"proto_type_mappings,omitempty" yaml:"proto_type_mappings"`
+
+	InitialismsMap      map[string]struct{} `json:"-" yaml:"-"`
+	RenamePatterns      []*RenamePattern    `json:"-" yaml:"-"`
+	FileHeaderTmpl      *template.Template  `json:"-" yaml:"-"`
+	HeaderTmpl          *template.Template  `json:"-" yaml:"-"`
+	SkipFiles           map[string]struct{} `json:"-" yaml:"-"`
+	Phases              *debug.Phases       `json:"-" yaml:"-"`
+	SensitiveStructTags map[string]string   `json:"-" yaml:"-"`
+	// sql_package whose Go types are used when they differ from those of the
+	// driver the queries run on, as in the package of stdlib_package
+	TypesSqlPackage string `json:"-" yaml:"-"`
 }
 
 type GlobalOptions struct {
@@ -131,21 +346,90 @@ func Parse(req *plugin.GenerateRequest) (*Options, error) {
 		}
 		maps.Copy(options.Rename, global.Rename)
 	}
+	aliasOverridePackages(options.Overrides)
+	options.RenamePatterns, err = parseRenamePatterns(options.Rename)
+	if err != nil {
+		return nil, err
+	}
+	options.FileHeaderTmpl, err = parseFileHeader(options)
+	if err != nil {
+		return nil, err
+	}
+	if options.HeaderTemplate != "" {
+		if options.FileHeaderYear == 0 && strings.Contains(options.HeaderTemplate, ".Year") {
+			return nil, fmt.Errorf("invalid options: file_header_year must be set when header_template uses {{.Year}}")
+		}
+		options.HeaderTmpl, err = template.New("header_template").Parse(options.HeaderTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid options: header_template: %s", err)
+		}
+	}
 	return options, nil
 }
 
+// parseRenamePatterns compiles the rename entries whose key has the regex:
+// prefix. Patterns are sorted so that the first matching one is picked
+// deterministically.
+func parseRenamePatterns(rename map[string]string) ([]*RenamePattern, error) {
+	var patterns []*RenamePattern
+	for _, key := range slices.Sorted(maps.Keys(rename)) {
+		expr, ok := strings.CutPrefix(key, renameRegexPrefix)
+		if !ok {
+			continue
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid options: rename %s: %s", key, err)
+		}
+		patterns = append(patterns, &RenamePattern{Pattern: re, Replacement: rename[key]})
+	}
+	return patterns, nil
+}
+
+// parseFileHeader parses the file_header template. Templates may refer to
+// {{.Year}}, {{.Package}}, {{.FileName}}, {{.SourceName}} and
+// {{.SqlcVersion}}. The year is file_header_year rather than the current one,
+// so that generating the same code again gives the same files.
+func parseFileHeader(options *Options) (*template.Template, error) {
+	header := options.FileHeader
+	if strings.TrimSpace(header) == "" {
+		return nil, nil
+	}
+	if options.FileHeaderYear == 0 && strings.Contains(header, ".Year") {
+		return nil, fmt.Errorf("invalid options: file_header_year must be set when file_header uses {{.Year}}")
+	}
+	tmpl, err := template.New("file_header").Parse(header)
+	if err != nil {
+		return nil, fmt.Errorf("invalid options: file_header: %s", err)
+	}
+	return tmpl, nil
+}
+
+// unmarshalStrict decodes data into v, rejecting the keys v does not declare
+// so that misspelled options fail instead of being ignored
+func unmarshalStrict(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
 func parseOpts(req *plugin.GenerateRequest) (*Options, error) {
 	var options Options
 	if len(req.PluginOptions) == 0 {
 		return &options, nil
 	}
-	if err := json.Unmarshal(req.PluginOptions, &options); err != nil {
+	options, err := profileOptions(req.PluginOptions)
+	if err != nil {
+		return nil, fmt.Errorf("invalid options: %s", err)
+	}
+	if err := unmarshalStrict(req.PluginOptions, &options); err != nil {
 		return nil, fmt.Errorf("unmarshalling plugin options: %w", err)
 	}
+	normalizePaths(&options)
 
 	if options.Package == "" {
 		if options.Out != "" {
-			options.Package = filepath.Base(options.Out)
+			options.Package = path.Base(options.Out)
 		} else {
 			return nil, fmt.Errorf("invalid options: missing package name")
 		}
@@ -169,11 +453,78 @@ func parseOpts(req *plugin.GenerateRequest) (*Options, error) {
 		}
 	}
 
+	if options.UuidType != "" {
+		if err := validateUUIDType(options.UuidType); err != nil {
+			return nil, fmt.Errorf("invalid options: %s", err)
+		}
+	}
+
+	if options.NumericType != "" {
+		if err := validateNumericType(options.NumericType); err != nil {
+			return nil, fmt.Errorf("invalid options: %s", err)
+		}
+	}
+
+	if options.GeometryType != "" {
+		if err := validateGeometryType(options.GeometryType); err != nil {
+			return nil, fmt.Errorf("invalid options: %s", err)
+		}
+	}
+
+	if options.MysqlCopyfromLocation != "" {
+		if err := validateCopyfromLocation(options.MysqlCopyfromLocation); err != nil {
+			return nil, fmt.Errorf("invalid options: %s", err)
+		}
+	}
+
+	if options.ArrayNulls != "" {
+		if err := validateArrayNulls(options.ArrayNulls); err != nil {
+			return nil, fmt.Errorf("invalid options: %s", err)
+		}
+	}
+
+	if options.StructFieldOrder != "" {
+		if err := validateFieldOrder(options.StructFieldOrder); err != nil {
+			return nil, fmt.Errorf("invalid options: %s", err)
+		}
+	}
+
+	if options.Formatter != "" {
+		if err := validateFormatter(options.Formatter); err != nil {
+			return nil, fmt.Errorf("invalid options: %s", err)
+		}
+	}
+
+	if options.GoVersion != "" && !goVersionPattern.MatchString(options.GoVersion) {
+		return nil, fmt.Errorf("invalid options: go_version %s must look like 1.21", options.GoVersion)
+	}
+
+	if options.SqliteMinVersion != "" && !sqliteVersionPattern.MatchString(options.SqliteMinVersion) {
+		return nil, fmt.Errorf("invalid options: sqlite_min_version %s must look like 3.35", options.SqliteMinVersion)
+	}
+
+	for kind := range options.FileBuildTags {
+		if err := validateFileKind(kind); err != nil {
+			return nil, fmt.Errorf("invalid options: file_build_tags: %s", err)
+		}
+	}
+
+	tags, err := options.SensitiveStructTag.parse()
+	if err != nil {
+		return nil, fmt.Errorf("invalid options: sensitive_struct_tag: %s", err)
+	}
+	options.SensitiveStructTags = tags
+
 	if options.QueryParameterLimit == nil {
 		options.QueryParameterLimit = new(int32)
 		*options.QueryParameterLimit = 1
 	}
 
+	if options.ParamsBuilderMinFields == nil {
+		options.ParamsBuilderMinFields = new(int32)
+		*options.ParamsBuilderMinFields = 5
+	}
+
 	if options.Initialisms == nil {
 		options.Initialisms = []string{"id"}
 	}
@@ -191,7 +542,7 @@ func parseGlobalOpts(req *plugin.GenerateRequest) (*GlobalOptions, error) {
 	if len(req.GlobalOptions) == 0 {
 		return &options, nil
 	}
-	if err := json.Unmarshal(req.GlobalOptions, &options); err != nil {
+	if err := unmarshalStrict(req.GlobalOptions, &options); err != nil {
 		return nil, fmt.Errorf("unmarshalling global options: %w", err)
 	}
 	for i := range options.Overrides {
@@ -202,6 +553,21 @@ func parseGlobalOpts(req *plugin.GenerateRequest) (*GlobalOptions, error) {
 	return &options, nil
 }
 
+// reservedReceiverNames are the variables and packages used in the bodies of
+// the methods of the queries struct, which a receiver of that name would
+// shadow
+var reservedReceiverNames = map[string]struct{}{
+	"arg": {}, "args": {}, "batch": {}, "br": {}, "c": {}, "cerr": {}, "copied": {},
+	"count": {}, "ctx": {}, "cursor": {}, "db": {}, "err": {}, "exists": {}, "i": {},
+	"inserted": {}, "items": {}, "last": {}, "lastInsertID": {}, "limit": {}, "n": {},
+	"name": {}, "next": {}, "offset": {}, "ok": {}, "options": {}, "p": {},
+	"plan": {}, "pr": {}, "pw": {}, "queries": {}, "query": {}, "result": {},
+	"results": {}, "row": {}, "rows": {}, "s": {}, "start": {}, "stmt": {},
+	"stmts": {}, "t": {}, "total": {}, "tx": {}, "vals": {}, "zero": {},
+	"context": {}, "errors": {}, "fmt": {}, "pgconn": {}, "pgx": {}, "sql": {},
+	"strings": {}, "time": {},
+}
+
 func ValidateOpts(opts *Options) error {
 	if opts.EmitMethodsWithDbArgument && opts.EmitPreparedQueries {
 		return fmt.Errorf("invalid options: emit_methods_with_db_argument and emit_prepared_queries options are mutually exclusive")
@@ -209,6 +575,200 @@ func ValidateOpts(opts *Options) error {
 	if *opts.QueryParameterLimit < 0 {
 		return fmt.Errorf("invalid options: query parameter limit must not be negative")
 	}
+	if opts.CopyfromChunkSize < 0 {
+		return fmt.Errorf("invalid options: copyfrom chunk size must not be negative")
+	}
+	if *opts.ParamsBuilderMinFields < 0 {
+		return fmt.Errorf("invalid options: params builder min fields must not be negative")
+	}
+	for domain := range opts.Domains {
+		seen := map[string]bool{}
+		for name, ok := domain, true; ok; name, ok = opts.Domains[name] {
+			if seen[name] {
+				return fmt.Errorf("invalid options: domain %s resolves to itself", domain)
+			}
+			seen[name] = true
+		}
+	}
+	for _, ct := range opts.CompositeTypes {
+		if ct.Name == "" {
+			return fmt.Errorf("invalid options: composite type name must be set")
+		}
+		for _, f := range ct.Fields {
+			if f.Name == "" || f.DBType == "" {
+				return fmt.Errorf("invalid options: composite type %s fields must set name and db_type", ct.Name)
+			}
+		}
+	}
+	for _, e := range opts.Enums {
+		if e.Name == "" {
+			return fmt.Errorf("invalid options: enum name must be set")
+		}
+		seen := map[string]string{}
+		for value, name := range e.Rename {
+			if !token.IsIdentifier(name) {
+				return fmt.Errorf("invalid options: enum %s renames %q to invalid identifier %q", e.Name, value, name)
+			}
+			if other, ok := seen[name]; ok {
+				return fmt.Errorf("invalid options: enum %s renames both %q and %q to %s", e.Name, other, value, name)
+			}
+			seen[name] = value
+		}
+	}
+	for _, p := range opts.Packages {
+		if p.Match == "" || p.Package == "" || p.Out == "" {
+			return fmt.Errorf("invalid options: packages must set match, package and out")
+		}
+		if _, err := filepath.Match(p.Match, ""); err != nil {
+			return fmt.Errorf("invalid options: packages match %s: %s", p.Match, err)
+		}
+		if !token.IsIdentifier(p.Package) {
+			return fmt.Errorf("invalid options: packages package %s is not a valid package name", p.Package)
+		}
+		if SlashPath(p.Out) == "." {
+			return fmt.Errorf("invalid options: packages out %s must not be the output directory", p.Out)
+		}
+		if !IsLocalPath(p.Out) {
+			return fmt.Errorf("invalid options: packages out %s must be relative to out", p.Out)
+		}
+		if p.SqlPackage != "" {
+			if err := validatePackage(p.SqlPackage); err != nil {
+				return fmt.Errorf("invalid options: packages %s: %s", p.Package, err)
+			}
+		}
+		if p.SqlDriver != "" {
+			if err := validateDriver(p.SqlDriver); err != nil {
+				return fmt.Errorf("invalid options: packages %s: %s", p.Package, err)
+			}
+		}
+	}
+	if p := opts.StdlibPackage; p != nil {
+		if opts.SqlPackage != SQLPackagePGXV5 {
+			return fmt.Errorf("invalid options: stdlib_package requires sql_package pgx/v5")
+		}
+		if opts.OutputModelsPackage == "" {
+			return fmt.Errorf("invalid options: output_models_package must be set when stdlib_package is used, the packages share the models")
+		}
+		if len(opts.Packages) > 0 || opts.SchemaPackages {
+			return fmt.Errorf("invalid options: stdlib_package cannot be used with packages or schema_packages")
+		}
+		if !token.IsIdentifier(p.Package) {
+			return fmt.Errorf("invalid options: stdlib_package package %s is not a valid package name", p.Package)
+		}
+		if SlashPath(p.Out) == "." || p.Out == "" {
+			return fmt.Errorf("invalid options: stdlib_package out %s must not be the output directory", p.Out)
+		}
+		if !IsLocalPath(p.Out) {
+			return fmt.Errorf("invalid options: stdlib_package out %s must be relative to out", p.Out)
+		}
+	}
+	if opts.EmitSqlSourceLocation && !opts.EmitSqlAsComment {
+		return fmt.Errorf("invalid options: emit_sql_as_comment must be set when emit_sql_source_location is used")
+	}
+	if opts.SqliteMinVersion != "" && !SQLDriver(opts.SqlDriver).IsSQLite() {
+		return fmt.Errorf("invalid options: sql_driver must be %s or %s when sqlite_min_version is used", SQLDriverModerncSQLite, SQLDriverMattnSQLite3)
+	}
+	if opts.EmitGenerationTimestamp && !opts.EmitVersionFile {
+		return fmt.Errorf("invalid options: emit_version_file must be set when emit_generation_timestamp is used")
+	}
+	if opts.EmitSplitQuerier && !opts.EmitInterface {
+		return fmt.Errorf("invalid options: emit_interface must be set when emit_split_querier is used")
+	}
+	if opts.EmitUnexportedQueries {
+		if !opts.EmitInterface {
+			return fmt.Errorf("invalid options: emit_interface must be set when emit_unexported_queries is used")
+		}
+		switch {
+		case opts.EmitReadWriteSplit:
+			return fmt.Errorf("invalid options: emit_read_write_split cannot be used with emit_unexported_queries")
+		case opts.EmitContextLessMethods:
+			return fmt.Errorf("invalid options: emit_context_less_methods cannot be used with emit_unexported_queries")
+		case opts.EmitGraphql:
+			return fmt.Errorf("invalid options: emit_graphql cannot be used with emit_unexported_queries")
+		}
+	}
+	for query, name := range opts.QueryConstantNames {
+		if !token.IsIdentifier(name) || name == "_" {
+			return fmt.Errorf("invalid options: query_constant_names renames %s to invalid identifier %q", query, name)
+		}
+	}
+	if suffix := opts.QueryConstantSuffix; suffix != "" && !token.IsIdentifier("q"+suffix) {
+		return fmt.Errorf("invalid options: query_constant_suffix %q is not valid in an identifier", suffix)
+	}
+	if name := opts.QueriesReceiverName; name != "" {
+		if !token.IsIdentifier(name) || name == "_" {
+			return fmt.Errorf("invalid options: queries_receiver_name %q is not a valid identifier", name)
+		}
+		if _, ok := reservedReceiverNames[name]; ok {
+			return fmt.Errorf("invalid options: queries_receiver_name %s is used by the generated methods", name)
+		}
+	}
+	if opts.OutputEnumsFileName != "" && opts.OutputEnumsFileName == cmp.Or(opts.OutputModelsFileName, "models.go") {
+		return fmt.Errorf("invalid options: output_enums_file_name %s must differ from the models file", opts.OutputEnumsFileName)
+	}
+	if opts.OutputQueryFilesDirectory != "" && !IsLocalPath(opts.OutputQueryFilesDirectory) {
+		return fmt.Errorf("invalid options: output_query_files_directory %s must be relative to out", opts.OutputQueryFilesDirectory)
+	}
+	if opts.SchemaPackages && opts.SchemaPackagesImportPath == "" {
+		return fmt.Errorf("invalid options: schema_packages_import_path must be set when schema_packages is used")
+	}
+	if (opts.IncrementalManifest != nil || opts.Incremental) && !opts.EmitManifest {
+		return fmt.Errorf("invalid options: emit_manifest must be set when incremental or incremental_manifest is used")
+	}
+	if opts.IncrementalManifest != nil && opts.Incremental {
+		return fmt.Errorf("invalid options: incremental reads the manifest from out, it cannot be used with incremental_manifest")
+	}
+	if (opts.EmitPoolConstructor || opts.EmitHealthCheck) && opts.SqlPackage != SQLPackagePGXV4 && opts.SqlPackage != SQLPackagePGXV5 {
+		return fmt.Errorf("invalid options: emit_pool_constructor and emit_health_check require sql_package pgx/v4 or pgx/v5")
+	}
+	if len(opts.CompositeTypes) > 0 && opts.SqlPackage != SQLPackagePGXV5 {
+		return fmt.Errorf("invalid options: composite_types requires sql_package pgx/v5")
+	}
+	if opts.GeometryType != "" && opts.SqlPackage != SQLPackagePGXV5 {
+		return fmt.Errorf("invalid options: geometry_type requires sql_package pgx/v5")
+	}
+	if opts.UuidType != "" && opts.SqlPackage != SQLPackagePGXV5 {
+		// pgx/v4 and database/sql always map uuid columns to github.com/google/uuid
+		return fmt.Errorf("invalid options: uuid_type requires sql_package pgx/v5")
+	}
+	if opts.EmitNullableEmbedPointers && opts.SqlPackage != SQLPackagePGXV4 && opts.SqlPackage != SQLPackagePGXV5 {
+		return fmt.Errorf("invalid options: emit_nullable_embed_pointers requires sql_package pgx/v4 or pgx/v5")
+	}
+	if opts.EmitEmbedValidFlags && opts.SqlPackage != SQLPackagePGXV4 && opts.SqlPackage != SQLPackagePGXV5 {
+		return fmt.Errorf("invalid options: emit_embed_valid_flags requires sql_package pgx/v4 or pgx/v5")
+	}
+	if opts.EmitEmbedValidFlags && opts.EmitNullableEmbedPointers {
+		return fmt.Errorf("invalid options: emit_embed_valid_flags and emit_nullable_embed_pointers options are mutually exclusive")
+	}
+	if opts.PrepareLazily && !opts.EmitPreparedQueries {
+		return fmt.Errorf("invalid options: emit_prepared_queries must be set when prepare_lazily is used")
+	}
+	if opts.PrepareLazily && (opts.SqlPackage == SQLPackagePGXV4 || opts.SqlPackage == SQLPackagePGXV5) {
+		return fmt.Errorf("invalid options: prepare_lazily requires sql_package database/sql, pgx prepares statements per connection")
+	}
+	if opts.EmitSoftDeleteVariants && opts.SoftDeleteColumn == "" {
+		return fmt.Errorf("invalid options: soft_delete_column must be set when emit_soft_delete_variants is used")
+	}
+	if opts.EmitReadWriteSplit && opts.EmitMethodsWithDbArgument {
+		return fmt.Errorf("invalid options: emit_read_write_split and emit_methods_with_db_argument options are mutually exclusive")
+	}
+	if opts.EmitHealthCheck && opts.EmitMethodsWithDbArgument {
+		return fmt.Errorf("invalid options: emit_health_check and emit_methods_with_db_argument options are mutually exclusive")
+	}
+	if opts.EmitProto && opts.ProtoGoPackage == "" {
+		return fmt.Errorf("invalid options: proto_go_package must be set when emit_proto is used")
+	}
+	for _, m := range opts.ProtoTypeMappings {
+		if m.GoType == "" || m.ProtoType == "" || m.ToProto == "" || m.FromProto == "" {
+			return fmt.Errorf("invalid options: proto_type_mappings must set go_type, proto_type, to_proto and from_proto")
+		}
+	}
+	if opts.OutputIntegrationFileName != "" && !strings.HasSuffix(opts.OutputIntegrationFileName, "_test.go") {
+		return fmt.Errorf("invalid options: output_integration_file_name must end with _test.go")
+	}
+	if opts.DebugLogFormat != "" && opts.DebugLogFormat != "text" && opts.DebugLogFormat != "json" {
+		return fmt.Errorf("invalid options: debug_log_format must be text or json")
+	}
 	if opts.OutputModelsPackage != "" && opts.ModelsPackageImportPath == "" {
 		return fmt.Errorf("invalid options: models_package_import_path must be set when output_models_package is used")
 	}
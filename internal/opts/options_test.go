@@ -0,0 +1,293 @@
+package opts
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+)
+
+// parse parses and validates the plugin options of the JSON object options
+func parse(options string) (*Options, error) {
+	parsed, err := Parse(&plugin.GenerateRequest{PluginOptions: []byte(options)})
+	if err != nil {
+		return nil, err
+	}
+	return parsed, ValidateOpts(parsed)
+}
+
+func TestFileHeaderYear(t *testing.T) {
+	for _, test := range []struct {
+		options string
+		err     string
+	}{
+		{`{"package": "db", "file_header": "Copyright Example Inc."}`, ""},
+		{`{"package": "db", "file_header": "Copyright {{.Year}} Example Inc.", "file_header_year": 2024}`, ""},
+		{`{"package": "db", "file_header": "Copyright {{.Year}} Example Inc."}`, "file_header_year must be set when file_header uses {{.Year}}"},
+		{`{"package": "db", "file_header": "{{.Package"}`, "file_header:"},
+	} {
+		_, err := parse(test.options)
+		if test.err == "" {
+			if err != nil {
+				t.Errorf("%s: %v", test.options, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: error = %v, want %q", test.options, err, test.err)
+		}
+	}
+}
+
+func TestHeaderTemplate(t *testing.T) {
+	for _, test := range []struct {
+		options string
+		err     string
+	}{
+		{`{"package": "db", "header_template": "source: {{.SourceName}}"}`, ""},
+		{`{"package": "db", "header_template": "Copyright {{.Year}}", "file_header_year": 2024}`, ""},
+		{`{"package": "db", "header_template": "Copyright {{.Year}}"}`, "file_header_year must be set when header_template uses {{.Year}}"},
+		{`{"package": "db", "header_template": "{{.SourceName"}`, "header_template:"},
+	} {
+		_, err := parse(test.options)
+		if test.err == "" {
+			if err != nil {
+				t.Errorf("%s: %v", test.options, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: error = %v, want %q", test.options, err, test.err)
+		}
+	}
+}
+
+func TestIncrementalOptions(t *testing.T) {
+	for _, test := range []struct {
+		options string
+		err     string
+	}{
+		{`{"package": "db", "emit_manifest": true, "incremental": true}`, ""},
+		{`{"package": "db", "emit_manifest": true, "incremental_manifest": {"files": []}}`, ""},
+		{`{"package": "db", "incremental": true}`, "emit_manifest must be set"},
+		{`{"package": "db", "emit_manifest": true, "incremental": true, "incremental_manifest": {"files": []}}`, "cannot be used with incremental_manifest"},
+	} {
+		_, err := parse(test.options)
+		if test.err == "" {
+			if err != nil {
+				t.Errorf("%s: %v", test.options, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: error = %v, want %q", test.options, err, test.err)
+		}
+	}
+}
+
+func TestStrictParsing(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		options string
+		err     string
+	}{
+		{"misspelled key", `{"package": "db", "emit_json_tag": true}`, `unknown field "emit_json_tag"`},
+		{"nested unknown key", `{"package": "db", "nested": {"queries": [{"query": "ListAuthors", "group": [{"struct_in": "Book", "field_group_byy": "ID"}]}]}}`, `unknown field "field_group_byy"`},
+		{"override unknown key", `{"package": "db", "overrides": [{"db_type": "uuid", "go_typ": "string"}]}`, `unknown field "go_typ"`},
+		{"type mismatch", `{"package": "db", "emit_json_tags": "yes"}`, "cannot unmarshal string"},
+		{"nested type mismatch", `{"package": "db", "nested": {"queries": [{"query": "ListAuthors", "composite": "true"}]}}`, "cannot unmarshal string"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Parse(&plugin.GenerateRequest{PluginOptions: []byte(test.options)})
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Fatalf("Parse() error = %v, want %q", err, test.err)
+			}
+		})
+	}
+}
+
+func TestPoolConstructorDriver(t *testing.T) {
+	for _, sqlPackage := range []string{"pgx/v4", "pgx/v5"} {
+		if _, err := parse(`{"package": "db", "sql_package": "` + sqlPackage + `", "emit_pool_constructor": true, "emit_health_check": true}`); err != nil {
+			t.Errorf("sql_package %s: %v", sqlPackage, err)
+		}
+	}
+	for _, option := range []string{"emit_pool_constructor", "emit_health_check"} {
+		if _, err := parse(`{"package": "db", "sql_package": "database/sql", "` + option + `": true}`); err == nil {
+			t.Errorf("%s is accepted with sql_package database/sql", option)
+		}
+	}
+}
+
+func TestPrepareLazily(t *testing.T) {
+	if _, err := parse(`{"package": "db", "emit_prepared_queries": true, "prepare_lazily": true}`); err != nil {
+		t.Error(err)
+	}
+	if _, err := parse(`{"package": "db", "prepare_lazily": true}`); err == nil {
+		t.Error("prepare_lazily is accepted without emit_prepared_queries")
+	}
+}
+
+func TestPrepareLazilyDriver(t *testing.T) {
+	for _, sqlPackage := range []string{"pgx/v4", "pgx/v5"} {
+		if _, err := parse(`{"package": "db", "sql_package": "` + sqlPackage + `", "emit_prepared_queries": true, "prepare_lazily": true}`); err == nil {
+			t.Errorf("prepare_lazily is accepted with sql_package %s", sqlPackage)
+		}
+	}
+}
+
+func TestMysqlCopyfromLocation(t *testing.T) {
+	for _, location := range []string{"UTC", "Local"} {
+		if _, err := parse(`{"package": "db", "mysql_copyfrom_location": "` + location + `"}`); err != nil {
+			t.Errorf("mysql_copyfrom_location %s: %v", location, err)
+		}
+	}
+	if _, err := parse(`{"package": "db", "mysql_copyfrom_location": "Europe/Paris"}`); err == nil {
+		t.Error("mysql_copyfrom_location Europe/Paris is accepted")
+	}
+}
+
+func TestUnexportedQueries(t *testing.T) {
+	if _, err := parse(`{"package": "db", "emit_interface": true, "emit_unexported_queries": true}`); err != nil {
+		t.Error(err)
+	}
+	for _, test := range []struct {
+		options string
+		err     string
+	}{
+		{`{"package": "db", "emit_unexported_queries": true}`, "emit_interface must be set"},
+		{`{"package": "db", "emit_interface": true, "emit_unexported_queries": true, "emit_read_write_split": true}`, "emit_read_write_split cannot be used"},
+		{`{"package": "db", "emit_interface": true, "emit_unexported_queries": true, "emit_context_less_methods": true}`, "emit_context_less_methods cannot be used"},
+	} {
+		if _, err := parse(test.options); err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: error = %v, want %q", test.options, err, test.err)
+		}
+	}
+}
+
+func TestQueriesReceiverName(t *testing.T) {
+	for _, name := range []string{"repo", "store"} {
+		if _, err := parse(`{"package": "db", "queries_receiver_name": "` + name + `"}`); err != nil {
+			t.Errorf("queries_receiver_name %s: %v", name, err)
+		}
+	}
+	for name, want := range map[string]string{
+		"2q":  "is not a valid identifier",
+		"_":   "is not a valid identifier",
+		"ctx": "is used by the generated methods",
+		"pgx": "is used by the generated methods",
+	} {
+		if _, err := parse(`{"package": "db", "queries_receiver_name": "` + name + `"}`); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("queries_receiver_name %s: error = %v, want %q", name, err, want)
+		}
+	}
+}
+
+func TestQueryConstantNames(t *testing.T) {
+	if _, err := parse(`{"package": "db", "query_constant_suffix": "Query", "query_constant_names": {"ListUsers": "ListUsersSQL"}}`); err != nil {
+		t.Error(err)
+	}
+	for _, options := range []string{
+		`{"package": "db", "query_constant_names": {"ListUsers": "list-users"}}`,
+		`{"package": "db", "query_constant_names": {"ListUsers": "_"}}`,
+		`{"package": "db", "query_constant_suffix": "-sql"}`,
+	} {
+		if _, err := parse(options); err == nil {
+			t.Errorf("%s is accepted", options)
+		}
+	}
+}
+
+func TestSensitiveStructTag(t *testing.T) {
+	options, err := parse(`{"package": "db", "sensitive_struct_tag": "json:\"-\" log:\"-\""}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := options.SensitiveStructTags; len(got) != 2 || got["json"] != "-" || got["log"] != "-" {
+		t.Errorf("SensitiveStructTags = %v, want json and log set to -", got)
+	}
+	if _, err := parse(`{"package": "db", "sensitive_struct_tag": "json:-"}`); err == nil {
+		t.Error("an invalid sensitive_struct_tag is accepted")
+	}
+}
+
+func TestSqliteMinVersion(t *testing.T) {
+	for _, driver := range []string{"modernc.org/sqlite", "github.com/mattn/go-sqlite3"} {
+		if _, err := parse(`{"package": "db", "sql_driver": "` + driver + `", "sqlite_min_version": "3.35.0"}`); err != nil {
+			t.Errorf("sql_driver %s: %v", driver, err)
+		}
+	}
+	for _, options := range []string{
+		`{"package": "db", "sqlite_min_version": "3.35"}`,
+		`{"package": "db", "sql_driver": "modernc.org/sqlite", "sqlite_min_version": "latest"}`,
+	} {
+		if _, err := parse(options); err == nil {
+			t.Errorf("%s is accepted", options)
+		}
+	}
+}
+
+func TestStdlibPackage(t *testing.T) {
+	const shared = `"package": "db", "output_models_package": "models", "models_package_import_path": "example.com/db/models"`
+	if _, err := parse(`{` + shared + `, "sql_package": "pgx/v5", "stdlib_package": {"package": "dbstd", "out": "std"}}`); err != nil {
+		t.Error(err)
+	}
+	for _, test := range []struct {
+		options string
+		err     string
+	}{
+		{`{` + shared + `, "stdlib_package": {"package": "dbstd", "out": "std"}}`, "requires sql_package pgx/v5"},
+		{`{"package": "db", "sql_package": "pgx/v5", "stdlib_package": {"package": "dbstd", "out": "std"}}`, "output_models_package must be set"},
+		{`{` + shared + `, "sql_package": "pgx/v5", "stdlib_package": {"package": "db-std", "out": "std"}}`, "not a valid package name"},
+		{`{` + shared + `, "sql_package": "pgx/v5", "stdlib_package": {"package": "dbstd", "out": "."}}`, "must not be the output directory"},
+		{`{` + shared + `, "sql_package": "pgx/v5", "stdlib_package": {"package": "dbstd", "out": "../std"}}`, "must be relative to out"},
+	} {
+		if _, err := parse(test.options); err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: error = %v, want %q", test.options, err, test.err)
+		}
+	}
+}
+
+func TestPackageSQLPackage(t *testing.T) {
+	const pkg = `"match": "billing/*.sql", "package": "billingdb", "out": "billing"`
+	if _, err := parse(`{"package": "db", "sql_package": "pgx/v5", "packages": [{` + pkg + `, "sql_package": "database/sql", "sql_driver": "github.com/jackc/pgx/v5"}]}`); err != nil {
+		t.Error(err)
+	}
+	for _, options := range []string{
+		`{"package": "db", "packages": [{` + pkg + `, "sql_package": "pgx/v6"}]}`,
+		`{"package": "db", "packages": [{` + pkg + `, "sql_driver": "github.com/example/driver"}]}`,
+	} {
+		if _, err := parse(options); err == nil || !strings.Contains(err.Error(), "packages billingdb") {
+			t.Errorf("%s: error = %v, want the package named", options, err)
+		}
+	}
+}
+
+func TestNullableEmbedPointers(t *testing.T) {
+	for _, pkg := range []string{"pgx/v4", "pgx/v5"} {
+		if _, err := parse(`{"package": "db", "sql_package": "` + pkg + `", "emit_nullable_embed_pointers": true}`); err != nil {
+			t.Errorf("%s: %v", pkg, err)
+		}
+	}
+	const want = "emit_nullable_embed_pointers requires sql_package pgx/v4 or pgx/v5"
+	if _, err := parse(`{"package": "db", "emit_nullable_embed_pointers": true}`); err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("database/sql: error = %v, want %q", err, want)
+	}
+}
+
+func TestEmbedValidFlags(t *testing.T) {
+	if _, err := parse(`{"package": "db", "sql_package": "pgx/v5", "emit_embed_valid_flags": true}`); err != nil {
+		t.Error(err)
+	}
+	for _, test := range []struct {
+		options string
+		err     string
+	}{
+		{`{"package": "db", "emit_embed_valid_flags": true}`, "emit_embed_valid_flags requires sql_package pgx/v4 or pgx/v5"},
+		{`{"package": "db", "sql_package": "pgx/v5", "emit_embed_valid_flags": true, "emit_nullable_embed_pointers": true}`, "mutually exclusive"},
+	} {
+		if _, err := parse(test.options); err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: error = %v, want %q", test.options, err, test.err)
+		}
+	}
+}
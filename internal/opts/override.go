@@ -3,6 +3,8 @@ package opts
 import (
 	"fmt"
 	"os"
+	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/sqlc-dev/plugin-sdk-go/pattern"
@@ -17,6 +19,16 @@ type Override struct {
 	// see https://github.com/sqlc-dev/sqlc/issues/534
 	GoStructTag GoStructTag `json:"go_struct_tag" yaml:"go_struct_tag"`
 
+	// additional Go struct tags by key, e.g. {"json": "id,string", "validate": "required"}
+	StructTags map[string]string `json:"struct_tags,omitempty" yaml:"struct_tags"`
+
+	// how the tags of the override combine with the generated json and db
+	// tags: "replace" (default) replaces the tags of the same keys, "merge"
+	// keeps the generated names and adds the options of the override, e.g.
+	// json:"id" and json:",omitempty" give json:"id,omitempty", and
+	// "replace_all" drops the tags set before the override
+	StructTagsMode string `json:"struct_tags_mode,omitempty" yaml:"struct_tags_mode"`
+
 	// fully qualified name of the Go type, e.g. `github.com/segmentio/ksuid.KSUID`
 	DBType                  string `json:"db_type" yaml:"db_type"`
 	Deprecated_PostgresType string `json:"postgres_type" yaml:"postgres_type"`
@@ -36,6 +48,15 @@ type Override struct {
 	// fully qualified name of the column, e.g. `accounts.id`
 	Column string `json:"column" yaml:"column"`
 
+	// regular expressions matched against the names and the database types
+	// of the columns of all tables, e.g. `_at$` and `^timestamptz?$`
+	ColumnNamePattern string `json:"column_name_pattern,omitempty" yaml:"column_name_pattern"`
+	DBTypePattern     string `json:"db_type_pattern,omitempty" yaml:"db_type_pattern"`
+
+	// True if the values of the column should be redacted by the String and
+	// LogValue methods of the structs holding them
+	Sensitive bool `json:"sensitive,omitempty" yaml:"sensitive"`
+
 	ColumnName   *pattern.Match `json:"-"`
 	TableCatalog *pattern.Match `json:"-"`
 	TableSchema  *pattern.Match `json:"-"`
@@ -45,6 +66,10 @@ type Override struct {
 	GoTypeName   string         `json:"-"`
 	GoBasicType  bool           `json:"-"`
 
+	// Compiled forms of ColumnNamePattern and DBTypePattern
+	ColumnNameRegexp *regexp.Regexp `json:"-"`
+	DBTypeRegexp     *regexp.Regexp `json:"-"`
+
 	// Parsed form of GoStructTag, e.g. {"validate:", "required"}
 	GoStructTags map[string]string `json:"-"`
 	ShimOverride *ShimOverride     `json:"-"`
@@ -76,6 +101,12 @@ func (o *Override) Matches(n *plugin.Identifier, defaultSchema string) bool {
 	return true
 }
 
+// IsPattern reports whether the override matches the columns of all tables
+// by column_name_pattern or db_type_pattern
+func (o *Override) IsPattern() bool {
+	return o.ColumnNamePattern != "" || o.DBTypePattern != ""
+}
+
 func (o *Override) parse(req *plugin.GenerateRequest) (err error) {
 	// validate deprecated postgres_type field
 	if o.Deprecated_PostgresType != "" {
@@ -101,10 +132,26 @@ func (o *Override) parse(req *plugin.GenerateRequest) (err error) {
 	switch {
 	case o.Column != "" && o.DBType != "":
 		return fmt.Errorf("Override specifying both `column` (%q) and `db_type` (%q) is not valid.", o.Column, o.DBType)
-	case o.Column == "" && o.DBType == "":
+	case o.Column != "" && o.IsPattern():
+		return fmt.Errorf("Override specifying both `column` (%q) and `column_name_pattern` or `db_type_pattern` is not valid.", o.Column)
+	case o.DBType != "" && o.DBTypePattern != "":
+		return fmt.Errorf("Override specifying both `db_type` (%q) and `db_type_pattern` (%q) is not valid.", o.DBType, o.DBTypePattern)
+	case o.Column == "" && o.DBType == "" && !o.IsPattern():
 		return fmt.Errorf("Override must specify one of either `column` or `db_type`")
 	}
 
+	// validate patterns
+	if o.ColumnNamePattern != "" {
+		if o.ColumnNameRegexp, err = regexp.Compile(o.ColumnNamePattern); err != nil {
+			return fmt.Errorf("Override `column_name_pattern` %q is not a valid regular expression: %s", o.ColumnNamePattern, err)
+		}
+	}
+	if o.DBTypePattern != "" {
+		if o.DBTypeRegexp, err = regexp.Compile(o.DBTypePattern); err != nil {
+			return fmt.Errorf("Override `db_type_pattern` %q is not a valid regular expression: %s", o.DBTypePattern, err)
+		}
+	}
+
 	// validate Column
 	if o.Column != "" {
 		colParts := strings.Split(o.Column, ".")
@@ -162,8 +209,93 @@ func (o *Override) parse(req *plugin.GenerateRequest) (err error) {
 	if err != nil {
 		return err
 	}
+	for key, value := range o.StructTags {
+		if _, ok := tags[key]; ok {
+			return fmt.Errorf("Override sets the %q tag in both `go_struct_tag` and `struct_tags`", key)
+		}
+		tags[key] = value
+	}
 	o.GoStructTags = tags
+	if o.StructTagsMode == "" {
+		o.StructTagsMode = StructTagsModeReplace
+	}
+	if err := validateStructTagsMode(o.StructTagsMode); err != nil {
+		return err
+	}
 
 	o.ShimOverride = shimOverride(req, o)
 	return nil
 }
+
+// aliasOverridePackages gives deterministic import aliases to the packages of
+// overrides whose names collide, e.g. example.com/a/types and
+// example.com/b/types become a_types and b_types, and qualifies their go_type
+// with the alias.
+func aliasOverridePackages(overrides []Override) {
+	paths := map[string][]string{}
+	for _, o := range overrides {
+		gt := o.ShimOverride.GoType
+		if gt.BasicType || gt.ImportPath == "" {
+			continue
+		}
+		name := overridePackageName(gt.TypeName)
+		if !slices.Contains(paths[name], gt.ImportPath) {
+			paths[name] = append(paths[name], gt.ImportPath)
+		}
+	}
+
+	aliases := map[string]string{}
+	for _, colliding := range paths {
+		if len(colliding) < 2 {
+			continue
+		}
+		slices.Sort(colliding)
+		for n := 2; ; n++ {
+			seen := map[string]struct{}{}
+			for _, path := range colliding {
+				aliases[path] = packageAlias(path, n)
+				seen[aliases[path]] = struct{}{}
+			}
+			if len(seen) == len(colliding) || n > strings.Count(colliding[0], "/")+1 {
+				break
+			}
+		}
+	}
+
+	for i := range overrides {
+		gt := overrides[i].ShimOverride.GoType
+		alias, ok := aliases[gt.ImportPath]
+		if !ok || gt.BasicType {
+			continue
+		}
+		name := overridePackageName(gt.TypeName)
+		prefix := gt.TypeName[:strings.Index(gt.TypeName, name)]
+		gt.TypeName = prefix + alias + strings.TrimPrefix(gt.TypeName, prefix+name)
+		gt.Package = alias
+		overrides[i].GoTypeName = gt.TypeName
+		overrides[i].GoPackage = gt.Package
+	}
+}
+
+// overridePackageName returns the package qualifier of an override type name
+// such as []*types.ID
+func overridePackageName(typeName string) string {
+	name := strings.TrimLeft(typeName, "[]*")
+	name, _, _ = strings.Cut(name, ".")
+	return name
+}
+
+// packageAlias builds an import alias from the last n elements of path,
+// ignoring major version suffixes
+func packageAlias(path string, n int) string {
+	var parts []string
+	for _, part := range strings.Split(path, "/") {
+		if !versionNumber.MatchString(part) {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) > n {
+		parts = parts[len(parts)-n:]
+	}
+	return invalidIdentifier.ReplaceAllString(strings.ToLower(strings.Join(parts, "_")), "_")
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
 )
 
 func TestTypeOverrides(t *testing.T) {
@@ -86,6 +87,47 @@ func TestTypeOverrides(t *testing.T) {
 			},
 			"Package override `go_type` specifier \"untyped rune\" is not a Go basic type e.g. 'string'",
 		},
+		{
+			Override{
+				ColumnNamePattern: "(",
+				GoType:            GoType{Spec: "string"},
+			},
+			"Override `column_name_pattern` \"(\" is not a valid regular expression: error parsing regexp: missing closing ): `(`",
+		},
+		{
+			Override{
+				DBType:        "text",
+				DBTypePattern: "^text$",
+				GoType:        GoType{Spec: "string"},
+			},
+			"Override specifying both `db_type` (\"text\") and `db_type_pattern` (\"^text$\") is not valid.",
+		},
+		{
+			Override{
+				Column:            "authors.id",
+				ColumnNamePattern: "id$",
+				GoType:            GoType{Spec: "int"},
+			},
+			"Override specifying both `column` (\"authors.id\") and `column_name_pattern` or `db_type_pattern` is not valid.",
+		},
+		{
+			Override{
+				DBType:      "text",
+				GoType:      GoType{Spec: "string"},
+				GoStructTag: `json:"a"`,
+				StructTags:  map[string]string{"json": "b"},
+			},
+			"Override sets the \"json\" tag in both `go_struct_tag` and `struct_tags`",
+		},
+		{
+			Override{
+				DBType:         "text",
+				GoType:         GoType{Spec: "string"},
+				StructTags:     map[string]string{"json": "b"},
+				StructTagsMode: "append",
+			},
+			"unknown struct tags mode: append",
+		},
 	} {
 		tt := test
 		t.Run(tt.override.GoType.Spec, func(t *testing.T) {
@@ -115,3 +157,41 @@ func FuzzOverride(f *testing.F) {
 		o.parse(nil)
 	})
 }
+
+func TestAliasOverridePackages(t *testing.T) {
+	overrides := []Override{
+		{Column: "orders.price", GoType: GoType{Spec: "example.com/billing/types.Money"}},
+		{Column: "orders.weight", GoType: GoType{Spec: "example.com/shipping/types.Weight"}},
+		{Column: "orders.tax", GoType: GoType{Spec: "example.com/billing/types.Money"}},
+		{Column: "orders.id", GoType: GoType{Spec: "github.com/acme/v2/types.ID"}},
+		{Column: "orders.customer", GoType: GoType{Spec: "example.com/a/models/user.ID"}},
+		{Column: "orders.seller", GoType: GoType{Spec: "example.com/b/models/user.ID"}},
+		{Column: "orders.created_at", GoType: GoType{Spec: "time.Time"}},
+		{Column: "orders.note", GoType: GoType{Spec: "string"}},
+	}
+	req := &plugin.GenerateRequest{Catalog: &plugin.Catalog{DefaultSchema: "public"}}
+	for i := range overrides {
+		if err := overrides[i].parse(req); err != nil {
+			t.Fatalf("override %s parsing failed: %s", overrides[i].Column, err)
+		}
+	}
+	aliasOverridePackages(overrides)
+
+	var got [][2]string
+	for _, o := range overrides {
+		got = append(got, [2]string{o.GoPackage, o.GoTypeName})
+	}
+	want := [][2]string{
+		{"billing_types", "billing_types.Money"},
+		{"shipping_types", "shipping_types.Weight"},
+		{"billing_types", "billing_types.Money"},
+		{"acme_types", "acme_types.ID"},
+		{"a_models_user", "a_models_user.ID"},
+		{"b_models_user", "b_models_user.ID"},
+		{"", "time.Time"},
+		{"", "string"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("aliased overrides mismatch (-want +got):\n%s", diff)
+	}
+}
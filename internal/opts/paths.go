@@ -0,0 +1,87 @@
+package opts
+
+import (
+	"path"
+	"strings"
+)
+
+// SlashPath cleans p and converts its backslashes to slashes. The names of
+// generated files are slash separated: sqlc joins them with its output
+// directory, which accepts slashes on Windows, while backslashes are not
+// separators when the plugin runs as WASM.
+func SlashPath(p string) string {
+	if p == "" {
+		return ""
+	}
+	return path.Clean(strings.ReplaceAll(p, `\`, "/"))
+}
+
+// IsAbsPath reports whether the slash separated path p is absolute on Unix
+// or Windows, such as /queries, C:/queries or //server/share/queries
+func IsAbsPath(p string) bool {
+	return strings.HasPrefix(p, "/") || hasDriveLetter(p)
+}
+
+func hasDriveLetter(p string) bool {
+	if len(p) < 2 || p[1] != ':' {
+		return false
+	}
+	c := p[0] | 0x20
+	return 'a' <= c && c <= 'z'
+}
+
+// IsLocalPath reports whether the path p, which may use backslashes, stays
+// below the directory it is relative to
+func IsLocalPath(p string) bool {
+	p = SlashPath(p)
+	return !IsAbsPath(p) && p != ".." && !strings.HasPrefix(p, "../")
+}
+
+// normalizePaths converts the output paths of options to slash separated
+// paths, so that Windows paths in sqlc.yaml name the same files
+func normalizePaths(options *Options) {
+	for _, p := range []*string{
+		&options.Out,
+		&options.OutputBatchFileName,
+		&options.OutputDbFileName,
+		&options.OutputModelsFileName,
+		&options.OutputEnumsFileName,
+		&options.OutputQuerierFileName,
+		&options.OutputCopyfromFileName,
+		&options.OutputQueryFilesDirectory,
+		&options.OutputNestedUtilsFileName,
+		&options.OutputGrouperFileName,
+		&options.OutputNullConvFileName,
+		&options.OutputRangesFileName,
+		&options.OutputPostgisFileName,
+		&options.OutputDocFileName,
+		&options.OutputVersionFileName,
+		&options.OutputManifestFileName,
+		&options.OutputDryRunFileName,
+		&options.OutputReadWriteFileName,
+		&options.OutputBackgroundFileName,
+		&options.OutputExplainFileName,
+		&options.OutputRetryFileName,
+		&options.OutputCacheFileName,
+		&options.OutputOptionsSchemaFileName,
+		&options.OutputJsonSchemaFileName,
+		&options.OutputProtoFileName,
+		&options.OutputProtoGoFileName,
+		&options.OutputGraphqlFileName,
+		&options.OutputGraphqlGoFileName,
+		&options.OutputOpenapiFileName,
+		&options.OutputTypescriptFileName,
+		&options.OutputFixturesFileName,
+		&options.OutputIntegrationFileName,
+		&options.OutputPprofDirectory,
+		&options.OutputNestedDataFileName,
+	} {
+		*p = SlashPath(*p)
+	}
+	for _, p := range options.Packages {
+		p.Out = SlashPath(p.Out)
+	}
+	if options.StdlibPackage != nil {
+		options.StdlibPackage.Out = SlashPath(options.StdlibPackage.Out)
+	}
+}
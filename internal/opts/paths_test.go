@@ -0,0 +1,31 @@
+package opts
+
+import "testing"
+
+func TestSlashPath(t *testing.T) {
+	for _, test := range []struct {
+		path  string
+		slash string
+		local bool
+	}{
+		{"", "", true},
+		{"queries", "queries", true},
+		{`queries\gen\`, "queries/gen", true},
+		{`.\queries`, "queries", true},
+		{`queries\..\..\gen`, "../gen", false},
+		{`C:\project\gen`, "C:/project/gen", false},
+		{"c:/project", "c:/project", false},
+		{`\\server\share`, "/server/share", false},
+		{"/tmp/gen", "/tmp/gen", false},
+	} {
+		if got := SlashPath(test.path); got != test.slash {
+			t.Errorf("SlashPath(%q) = %q, want %q", test.path, got, test.slash)
+		}
+		if test.path == "" {
+			continue
+		}
+		if got := IsLocalPath(test.path); got != test.local {
+			t.Errorf("IsLocalPath(%q) = %v, want %v", test.path, got, test.local)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package opts
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Profiles bundle common option combinations. The options set next to
+// profile are applied on top of it.
+const (
+	ProfilePgxStrict     string = "pgx-strict"
+	ProfileStdlibMinimal string = "stdlib-minimal"
+)
+
+var profiles = map[string]Options{
+	ProfilePgxStrict: {
+		SqlPackage:          SQLPackagePGXV5,
+		EmitInterface:       true,
+		EmitEnumValidMethod: true,
+		EmitAllEnumValues:   true,
+		StrictEnums:         true,
+		OmitUnusedStructs:   true,
+	},
+	ProfileStdlibMinimal: {
+		SqlPackage:        SQLPackageStandard,
+		OmitUnusedStructs: true,
+		OmitSqlcVersion:   true,
+	},
+}
+
+// profileOptions returns the options of the profile named in data, or empty
+// options when there is none, for data to be unmarshalled on top
+func profileOptions(data []byte) (Options, error) {
+	var selected struct {
+		Profile string `json:"profile"`
+	}
+	if err := json.Unmarshal(data, &selected); err != nil || selected.Profile == "" {
+		// Errors are reported by the strict parsing of the options
+		return Options{}, nil
+	}
+	options, ok := profiles[selected.Profile]
+	if !ok {
+		return Options{}, fmt.Errorf("unknown profile: %s", selected.Profile)
+	}
+	return options, nil
+}
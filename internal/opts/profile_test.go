@@ -0,0 +1,34 @@
+package opts
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProfiles(t *testing.T) {
+	for name := range profiles {
+		if _, err := parse(`{"package": "db", "profile": "` + name + `"}`); err != nil {
+			t.Errorf("profile %s: %v", name, err)
+		}
+	}
+}
+
+func TestProfileOverrides(t *testing.T) {
+	options, err := parse(`{"package": "db", "profile": "pgx-strict", "strict_enums": false, "emit_json_tags": true}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if options.SqlPackage != SQLPackagePGXV5 || !options.EmitInterface || !options.OmitUnusedStructs {
+		t.Errorf("the options of the pgx-strict profile are not applied: %+v", options)
+	}
+	if options.StrictEnums {
+		t.Error("strict_enums: false does not override the profile")
+	}
+	if !options.EmitJsonTags {
+		t.Error("emit_json_tags is not applied on top of the profile")
+	}
+
+	if _, err := parse(`{"package": "db", "profile": "pgx-strictt"}`); err == nil || !strings.Contains(err.Error(), "unknown profile: pgx-strictt") {
+		t.Errorf("unknown profile error = %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package opts
+
+import (
+	"encoding/json"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// schemaEnums lists the values accepted by the options parseOpts validates
+// against a fixed set
+var schemaEnums = map[string]map[string]struct{}{
+	"sql_package":        validPackages,
+	"sql_driver":         validDrivers,
+	"uuid_type":          validUUIDTypes,
+	"numeric_type":       validNumericTypes,
+	"geometry_type":      validGeometryTypes,
+	"array_nulls":        validArrayNulls,
+	"struct_field_order": validFieldOrders,
+	"formatter":          validFormatters,
+}
+
+// JSONSchema returns a JSON schema describing the plugin options, including
+// the nested block, for editors and CI to validate sqlc.yaml against
+func JSONSchema() ([]byte, error) {
+	defs := map[string]any{}
+	root := objectSchema(reflect.TypeOf(Options{}), defs)
+
+	props := root["properties"].(map[string]any)
+	for name, values := range schemaEnums {
+		props[name].(map[string]any)["enum"] = slices.Sorted(maps.Keys(values))
+	}
+	props["profile"].(map[string]any)["enum"] = slices.Sorted(maps.Keys(profiles))
+	props["go_version"].(map[string]any)["pattern"] = goVersionPattern.String()
+	props["file_build_tags"].(map[string]any)["propertyNames"] = map[string]any{
+		"enum": slices.Sorted(maps.Keys(validFileKinds)),
+	}
+
+	root["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	root["title"] = "sqlc-gen-go options"
+	root["$defs"] = defs
+	return json.MarshalIndent(root, "", "  ")
+}
+
+// schemaFor describes t, adding the structs it refers to to defs so that the
+// recursive nested configurations are described once
+func schemaFor(t reflect.Type, defs map[string]any) map[string]any {
+	if t == reflect.TypeOf(GoType{}) {
+		// A Go type is either a type spec string or an object
+		return map[string]any{"oneOf": []any{
+			map[string]any{"type": "string"},
+			objectSchema(t, defs),
+		}}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return schemaFor(t.Elem(), defs)
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Slice:
+		return map[string]any{"type": "array", "items": schemaFor(t.Elem(), defs)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaFor(t.Elem(), defs)}
+	case reflect.Struct:
+		if _, ok := defs[t.Name()]; !ok {
+			defs[t.Name()] = nil
+			defs[t.Name()] = objectSchema(t, defs)
+		}
+		return map[string]any{"$ref": "#/$defs/" + t.Name()}
+	default:
+		return map[string]any{}
+	}
+}
+
+// objectSchema describes the JSON fields of the struct t, which must not have
+// any other field, the same as the strict parsing of the options
+func objectSchema(t reflect.Type, defs map[string]any) map[string]any {
+	props := map[string]any{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if !f.IsExported() || name == "-" || name == "" {
+			continue
+		}
+		props[name] = schemaFor(f.Type, defs)
+	}
+	return map[string]any{
+		"type":                 "object",
+		"properties":           props,
+		"additionalProperties": false,
+	}
+}
+
+// Describe returns the resolved options as JSON. Unlike marshalling options
+// directly, options left unset are listed with their zero value too.
+func Describe(options *Options) ([]byte, error) {
+	described := map[string]json.RawMessage{}
+	v := reflect.ValueOf(options).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if !f.IsExported() || name == "-" || name == "" {
+			continue
+		}
+		value, err := json.Marshal(v.Field(i).Addr().Interface())
+		if err != nil {
+			return nil, err
+		}
+		described[name] = value
+	}
+	return json.MarshalIndent(described, "", "  ")
+}
@@ -0,0 +1,86 @@
+package opts
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestJSONSchemaFields checks that the schema describes every field of the
+// options and of the structs they refer to, so that it accepts whatever the
+// strict parsing accepts
+func TestJSONSchemaFields(t *testing.T) {
+	data, err := JSONSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schema struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+		Defs       map[string]struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatal(err)
+	}
+
+	seen := map[reflect.Type]bool{}
+	var check func(typ reflect.Type, props map[string]json.RawMessage)
+	check = func(typ reflect.Type, props map[string]json.RawMessage) {
+		seen[typ] = true
+		for i := 0; i < typ.NumField(); i++ {
+			f := typ.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			tag, ok := f.Tag.Lookup("json")
+			if !ok {
+				t.Errorf("%s.%s has no json tag", typ.Name(), f.Name)
+				continue
+			}
+			name, _, _ := strings.Cut(tag, ",")
+			if name == "-" {
+				continue
+			}
+			if _, ok := props[name]; !ok {
+				t.Errorf("the schema of %s does not list %s", typ.Name(), name)
+			}
+
+			elem := f.Type
+			for elem.Kind() == reflect.Pointer || elem.Kind() == reflect.Slice || elem.Kind() == reflect.Map {
+				elem = elem.Elem()
+			}
+			if elem.Kind() != reflect.Struct || seen[elem] {
+				continue
+			}
+			if elem == reflect.TypeOf(GoType{}) {
+				// A Go type is either a string or an object described inline
+				var goType struct {
+					OneOf []struct {
+						Properties map[string]json.RawMessage `json:"properties"`
+					} `json:"oneOf"`
+				}
+				if err := json.Unmarshal(props[name], &goType); err != nil || len(goType.OneOf) != 2 {
+					t.Errorf("the schema of %s.%s is not a string or an object: %s", typ.Name(), name, props[name])
+					continue
+				}
+				check(elem, goType.OneOf[1].Properties)
+				continue
+			}
+			def, ok := schema.Defs[elem.Name()]
+			if !ok {
+				t.Errorf("the schema does not define %s", elem.Name())
+				continue
+			}
+			check(elem, def.Properties)
+		}
+	}
+	check(reflect.TypeOf(Options{}), schema.Properties)
+
+	for _, typ := range []reflect.Type{reflect.TypeOf(NestedConfig{}), reflect.TypeOf(NestedGroupConfig{}), reflect.TypeOf(Override{})} {
+		if !seen[typ] {
+			t.Errorf("the schema does not describe %s", typ.Name())
+		}
+	}
+}
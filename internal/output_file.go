@@ -1,9 +1,12 @@
 package golang
 
+import "github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+
 type OutputFile string
 
 const (
 	OutputFileModel       OutputFile = "modelFile"
+	OutputFileEnums       OutputFile = "enumsFile"
 	OutputFileQuery       OutputFile = "queryFile"
 	OutputFileDb          OutputFile = "dbFile"
 	OutputFileInterface   OutputFile = "interfaceFile"
@@ -11,4 +14,57 @@ const (
 	OutputFileBatch       OutputFile = "batchFile"
 	OutputFileNestedCore  OutputFile = "nestedCoreFile"
 	OutputFileNestedUtils OutputFile = "nestedUtilsFile"
+	OutputFileGrouper     OutputFile = "grouperFile"
+	OutputFileNullConv    OutputFile = "nullconvFile"
+	OutputFileRanges      OutputFile = "rangesFile"
+	OutputFilePostgis     OutputFile = "postgisFile"
+	OutputFileDoc         OutputFile = "docFile"
+	OutputFileReadWrite   OutputFile = "readWriteFile"
+	OutputFileBackground  OutputFile = "backgroundFile"
+	OutputFileExplain     OutputFile = "explainFile"
+	OutputFileRetry       OutputFile = "retryFile"
+	OutputFileCache       OutputFile = "cacheFile"
+	OutputFileProto       OutputFile = "protoFile"
+	OutputFileGraphQL     OutputFile = "graphqlFile"
+	OutputFileFixtures    OutputFile = "fixturesFile"
+	OutputFileIntegration OutputFile = "integrationTestFile"
+	OutputFileVersion     OutputFile = "versionFile"
 )
+
+// fileKinds maps the templates of generated files to the file kinds that
+// file_build_tags is keyed by
+var fileKinds = map[string]string{
+	"dbFile":              opts.FileKindDb,
+	"modelsFile":          opts.FileKindModels,
+	"enumsFile":           opts.FileKindModels,
+	"interfaceFile":       opts.FileKindQuerier,
+	"queryFile":           opts.FileKindQueries,
+	"copyfromFile":        opts.FileKindCopyfrom,
+	"batchFile":           opts.FileKindBatch,
+	"nestedCoreFile":      opts.FileKindNested,
+	"nestedUtilsFile":     opts.FileKindNestedUtils,
+	"grouperFile":         opts.FileKindNested,
+	"nullconvFile":        opts.FileKindNullConv,
+	"rangesFile":          opts.FileKindRanges,
+	"postgisFile":         opts.FileKindPostgis,
+	"docFile":             opts.FileKindDoc,
+	"readWriteFile":       opts.FileKindReadWrite,
+	"backgroundFile":      opts.FileKindBackground,
+	"explainFile":         opts.FileKindExplain,
+	"retryFile":           opts.FileKindRetry,
+	"cacheFile":           opts.FileKindCache,
+	"protoFile":           opts.FileKindProto,
+	"graphqlFile":         opts.FileKindGraphQL,
+	"fixturesFile":        opts.FileKindFixtures,
+	"integrationTestFile": opts.FileKindIntegration,
+	"versionFile":         opts.FileKindVersion,
+}
+
+// buildTagsFor returns the build constraint of the files generated by
+// templateName, falling back to build_tags
+func buildTagsFor(options *opts.Options, templateName string) string {
+	if tags, ok := options.FileBuildTags[fileKinds[templateName]]; ok {
+		return tags
+	}
+	return options.BuildTags
+}
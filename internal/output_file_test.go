@@ -0,0 +1,51 @@
+package golang
+
+import (
+	"io/fs"
+	"regexp"
+	"testing"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+// TestFileKinds checks that every template of a generated file has a file
+// kind, so that file_build_tags applies to it
+func TestFileKinds(t *testing.T) {
+	define := regexp.MustCompile(`{{define "(\w+File)"}}`)
+	err := fs.WalkDir(templates, "templates", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		contents, err := fs.ReadFile(templates, name)
+		if err != nil {
+			return err
+		}
+		for _, m := range define.FindAllSubmatch(contents, -1) {
+			if _, ok := fileKinds[string(m[1])]; !ok {
+				t.Errorf("%s: template %s has no file kind", name, m[1])
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBuildTagsFor(t *testing.T) {
+	options := &opts.Options{
+		BuildTags:     "integration",
+		FileBuildTags: map[string]string{opts.FileKindNested: "nested", opts.FileKindModels: ""},
+	}
+	for templateName, want := range map[string]string{
+		"queryFile":      "integration",
+		"nestedCoreFile": "nested",
+		"grouperFile":    "nested",
+		"modelsFile":     "",
+		"enumsFile":      "",
+	} {
+		if got := buildTagsFor(options, templateName); got != want {
+			t.Errorf("buildTagsFor(%s) = %q, want %q", templateName, got, want)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package golang
+
+import (
+	"cmp"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+// packageFor returns the packages entry whose pattern matches the SQL file
+// filename, or nil if its queries belong to the main package. Patterns are
+// matched against the whole name first, then against its base name.
+func packageFor(options *opts.Options, filename string) *opts.PackageConfig {
+	name := opts.SlashPath(filename)
+	for _, p := range options.Packages {
+		if ok, _ := path.Match(p.Match, name); ok {
+			return p
+		}
+		if ok, _ := path.Match(p.Match, path.Base(name)); ok {
+			return p
+		}
+	}
+	return nil
+}
+
+// mirroredPackages returns a packages entry for every directory holding
+// query files that no packages entry matches, for mirror_query_directories.
+// Go packages cannot span directories, so each mirrored directory becomes a
+// package named after it. Queries at the top level stay in the main package.
+func mirroredPackages(req *plugin.GenerateRequest, options *opts.Options) []*opts.PackageConfig {
+	dirs := map[string]struct{}{}
+	for _, q := range req.Queries {
+		name := opts.SlashPath(q.Filename)
+		dir := path.Dir(name)
+		if dir == "." || !opts.IsLocalPath(dir) || packageFor(options, name) != nil {
+			continue
+		}
+		dirs[dir] = struct{}{}
+	}
+
+	var packages []*opts.PackageConfig
+	for _, dir := range slices.Sorted(maps.Keys(dirs)) {
+		packages = append(packages, &opts.PackageConfig{
+			Match:   dir + "/*",
+			Package: mirroredPackageName(dir),
+			Out:     dir,
+		})
+	}
+	return packages
+}
+
+// mirroredPackageName returns a Go package name for the directory dir, its
+// base name lowercased and stripped of the characters Go identifiers cannot
+// hold
+func mirroredPackageName(dir string) string {
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, path.Base(dir))
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		name = "q" + name
+	}
+	return name
+}
+
+// generatePackages splits the queries of req between the packages entries
+// and the main package, and generates each of them as if sqlc had been run
+// once per package. Files of package entries are written below their out
+// directory, and every package gets its own models unless a separate models
+// package is configured.
+func generatePackages(req *plugin.GenerateRequest, options *opts.Options) (*plugin.GenerateResponse, error) {
+	queries := map[*opts.PackageConfig][]*plugin.Query{}
+	for _, q := range req.Queries {
+		p := packageFor(options, q.Filename)
+		if p != nil {
+			// The package directory replaces the directory of the SQL file
+			q = &plugin.Query{
+				Text:            q.Text,
+				Name:            q.Name,
+				Cmd:             q.Cmd,
+				Columns:         q.Columns,
+				Params:          q.Params,
+				Comments:        q.Comments,
+				Filename:        path.Base(opts.SlashPath(q.Filename)),
+				InsertIntoTable: q.InsertIntoTable,
+			}
+		}
+		queries[p] = append(queries[p], q)
+	}
+
+	resp := &plugin.GenerateResponse{}
+	for _, p := range append([]*opts.PackageConfig{nil}, options.Packages...) {
+		if p != nil && len(queries[p]) == 0 {
+			continue
+		}
+
+		pkgReq := &plugin.GenerateRequest{
+			Settings:      req.Settings,
+			Catalog:       req.Catalog,
+			Queries:       queries[p],
+			SqlcVersion:   req.SqlcVersion,
+			PluginOptions: req.PluginOptions,
+			GlobalOptions: req.GlobalOptions,
+		}
+		pkgOptions := *options
+		if p != nil {
+			pkgOptions.Package = p.Package
+			if p.SqlPackage != "" {
+				useSQLPackage(&pkgOptions, p.SqlPackage)
+			}
+			if p.SqlDriver != "" {
+				pkgOptions.SqlDriver = p.SqlDriver
+			}
+			// Files skipped by incremental generation are named relative
+			// to the package directory
+			pkgOptions.SkipFiles = map[string]struct{}{}
+			for name := range options.SkipFiles {
+				if rel, ok := strings.CutPrefix(name, p.Out+"/"); ok {
+					pkgOptions.SkipFiles[rel] = struct{}{}
+				}
+			}
+		}
+
+		pkgResp, err := generatePackage(pkgReq, &pkgOptions)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range pkgResp.Files {
+			if p == nil {
+				resp.Files = append(resp.Files, f)
+				continue
+			}
+			if isModelsPackageFile(options, f.Name) {
+				continue
+			}
+			resp.Files = append(resp.Files, &plugin.File{
+				Name:     path.Join(p.Out, f.Name),
+				Contents: f.Contents,
+			})
+		}
+	}
+	return resp, nil
+}
+
+// isModelsPackageFile reports whether the file name holds models moved to
+// output_models_package, which packages generated next to the main one
+// share instead of declaring again
+func isModelsPackageFile(options *opts.Options, name string) bool {
+	if options.OutputModelsPackage == "" {
+		return false
+	}
+	return name == cmp.Or(options.OutputModelsFileName, "models.go") || name == options.OutputEnumsFileName
+}
+
+// useSQLPackage switches the package generated with options to sqlPackage.
+// Options only the previous driver supports are turned off, and the package
+// keeps the types of the previous driver when it shares the models of
+// output_models_package.
+func useSQLPackage(options *opts.Options, sqlPackage string) {
+	if parseDriver(sqlPackage) == parseDriver(options.SqlPackage) {
+		return
+	}
+	if options.OutputModelsPackage != "" && options.TypesSqlPackage == "" {
+		options.TypesSqlPackage = options.SqlPackage
+	}
+	options.SqlPackage = sqlPackage
+	if parseDriver(sqlPackage).IsPGX() {
+		options.PrepareLazily = false
+	} else {
+		options.EmitPoolConstructor = false
+		options.EmitHealthCheck = false
+	}
+}
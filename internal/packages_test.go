@@ -0,0 +1,80 @@
+package golang
+
+import (
+	"testing"
+
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+func TestPackageFor(t *testing.T) {
+	options := &opts.Options{Packages: []*opts.PackageConfig{
+		{Match: "billing/*.sql", Package: "billingdb", Out: "internal/billingdb"},
+		{Match: "audit_*.sql", Package: "auditdb", Out: "internal/auditdb"},
+	}}
+	for filename, want := range map[string]string{
+		"billing/invoices.sql":        "billingdb",
+		"billing\\invoices.sql":       "billingdb",
+		"billing/2024/invoices.sql":   "",
+		"queries/audit_log.sql":       "auditdb",
+		"audit_log.sql":               "auditdb",
+		"query.sql":                   "",
+		"queries/billing/invoice.sql": "",
+	} {
+		got := ""
+		if p := packageFor(options, filename); p != nil {
+			got = p.Package
+		}
+		if got != want {
+			t.Errorf("packageFor(%q) = %q, want %q", filename, got, want)
+		}
+	}
+}
+
+func TestMirroredPackages(t *testing.T) {
+	req := &plugin.GenerateRequest{Queries: []*plugin.Query{
+		{Name: "GetUser", Filename: "queries/users/get.sql"},
+		{Name: "ListUsers", Filename: "queries/users/list.sql"},
+		{Name: "CountUsers", Filename: "queries/admin/count.sql"},
+		{Name: "GetBook", Filename: "queries/books/get.sql"},
+		{Name: "Ping", Filename: "ping.sql"},
+		{Name: "Shared", Filename: "../shared/shared.sql"},
+	}}
+	options := &opts.Options{
+		MirrorQueryDirectories: true,
+		Packages:               []*opts.PackageConfig{{Match: "queries/books/*", Package: "library", Out: "library"}},
+	}
+
+	var got []string
+	for _, p := range mirroredPackages(req, options) {
+		got = append(got, p.Match+" "+p.Package+" "+p.Out)
+	}
+	want := []string{
+		"queries/admin/* admin queries/admin",
+		"queries/users/* users queries/users",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("mirroredPackages() = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("mirroredPackages()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestMirroredPackageName(t *testing.T) {
+	for dir, want := range map[string]string{
+		"queries/users":    "users",
+		"queries/Admin":    "admin",
+		"queries/user-api": "userapi",
+		"queries/v2":       "v2",
+		"queries/2024":     "q2024",
+		"queries/--":       "q",
+	} {
+		if got := mirroredPackageName(dir); got != want {
+			t.Errorf("mirroredPackageName(%q) = %s, want %s", dir, got, want)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package golang
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sqlc-dev/plugin-sdk-go/metadata"
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+	"github.com/sqlc-dev/plugin-sdk-go/sdk"
+)
+
+// paginateOffset is the value of the "-- @paginate offset" annotation,
+// generating a limit/offset variant of a query counting its rows
+const paginateOffset = "offset"
+
+var (
+	keysetAnnotation = regexp.MustCompile(`^keyset\s*\(([^)]*)\)$`)
+	limitClause      = regexp.MustCompile(`(?i)\b(LIMIT|OFFSET)\b`)
+)
+
+// Keyset is the keyset pagination of a :many query annotated with
+// "-- @paginate keyset(created_at, id)", which pages through the rows in the
+// order of the key columns, resuming after the key of the last row read
+type Keyset struct {
+	// SQL of the page query, selecting the rows after a cursor
+	SQL     string
+	Columns []KeysetColumn
+}
+
+type KeysetColumn struct {
+	DBName string
+	// Name of the field of the key in the row, empty when the query returns
+	// the key column only
+	Name string
+	Type string
+}
+
+// Value returns the expression of the key column in the row named row
+func (c KeysetColumn) Value(row string) string {
+	if c.Name == "" {
+		return row
+	}
+	return row + "." + c.Name
+}
+
+// Var returns the name of the variable holding the key column of a cursor
+func (c KeysetColumn) Var() string {
+	if c.Name == "" {
+		return "after"
+	}
+	return "after" + c.Name
+}
+
+// queryKeyset returns the keyset pagination of a query annotated with
+// "-- @paginate keyset(...)". The page query selects from the query as a
+// subquery, so the key columns must be NOT NULL columns of its result and
+// any LIMIT of the query applies before paging.
+func queryKeyset(query *plugin.Query, annotations map[string]string) (*Keyset, error) {
+	value, ok := annotations[annotationPaginate]
+	if !ok || value == paginateOffset {
+		return nil, nil
+	}
+	m := keysetAnnotation.FindStringSubmatch(value)
+	if m == nil {
+		return nil, fmt.Errorf("unknown pagination %q, must be keyset(column, ...) or %s", value, paginateOffset)
+	}
+	if query.Cmd != metadata.CmdMany {
+		return nil, fmt.Errorf("paginate is not supported for %s queries", query.Cmd)
+	}
+
+	var k Keyset
+	var casts []string
+	descending := 0
+	for _, key := range strings.Split(m[1], ",") {
+		fields := strings.Fields(key)
+		if len(fields) == 2 && strings.EqualFold(fields[1], "desc") {
+			descending++
+		} else if len(fields) != 1 {
+			return nil, fmt.Errorf("invalid keyset column %q", strings.TrimSpace(key))
+		}
+		var column *plugin.Column
+		for i, c := range query.Columns {
+			if c.EmbedTable == nil && columnName(c, i) == fields[0] {
+				if column != nil {
+					return nil, fmt.Errorf("keyset column %s is ambiguous", fields[0])
+				}
+				column = c
+			}
+		}
+		if column == nil {
+			return nil, fmt.Errorf("keyset column %s is not a column of the query", fields[0])
+		}
+		if !column.NotNull {
+			return nil, fmt.Errorf("keyset column %s must be NOT NULL", fields[0])
+		}
+		k.Columns = append(k.Columns, KeysetColumn{DBName: fields[0]})
+		casts = append(casts, sdk.DataType(column.Type))
+	}
+	if descending != 0 && descending != len(k.Columns) {
+		return nil, fmt.Errorf("keyset columns must all be ascending or all descending")
+	}
+
+	next := 1
+	for _, p := range query.Params {
+		if int(p.Number) >= next {
+			next = int(p.Number) + 1
+		}
+	}
+	after := make([]string, len(casts))
+	for i, cast := range casts {
+		after[i] = fmt.Sprintf("$%d::%s", next+1+i, cast)
+	}
+	keys := make([]string, len(k.Columns))
+	orderBy := make([]string, len(k.Columns))
+	for i, c := range k.Columns {
+		keys[i] = c.DBName
+		orderBy[i] = c.DBName
+		if descending > 0 {
+			orderBy[i] += " DESC"
+		}
+	}
+	cmp := ">"
+	if descending > 0 {
+		cmp = "<"
+	}
+	k.SQL = fmt.Sprintf("SELECT * FROM (\n%s\n) AS page\nWHERE NOT $%d::boolean OR (%s) %s (%s)\nORDER BY %s\nLIMIT $%d",
+		strings.TrimRight(query.Text, "; \t\n"), next, strings.Join(keys, ", "), cmp, strings.Join(after, ", "),
+		strings.Join(orderBy, ", "), next+1+len(casts))
+	return &k, nil
+}
+
+// bind resolves the key columns to the fields of the rows of the query
+func (k *Keyset) bind(ret QueryValue) error {
+	for i, c := range k.Columns {
+		if ret.Struct == nil {
+			k.Columns[i].Type = ret.Type()
+			continue
+		}
+		found := false
+		for _, f := range ret.Struct.Fields {
+			name := f.DBName
+			if name == "" && f.Column != nil {
+				name = f.Column.Name
+			}
+			if name == c.DBName && len(f.EmbedFields) == 0 {
+				if found {
+					return fmt.Errorf("keyset column %s is ambiguous", c.DBName)
+				}
+				k.Columns[i].Name = f.Name
+				k.Columns[i].Type = f.Type
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("keyset column %s is not a field of %s", c.DBName, ret.Type())
+		}
+	}
+	return nil
+}
+
+// Paged is the limit/offset pagination of a :many query annotated with
+// "-- @paginate offset", whose page query is completed by a query counting
+// all its rows
+type Paged struct {
+	// SQL of the page query, the query with LIMIT and OFFSET parameters
+	SQL string
+	// SQL counting the rows of the query
+	CountSQL string
+}
+
+// queryPaged returns the limit/offset pagination of a query annotated with
+// "-- @paginate offset"
+func queryPaged(query *plugin.Query, annotations map[string]string) (*Paged, error) {
+	if annotations[annotationPaginate] != paginateOffset {
+		return nil, nil
+	}
+	if query.Cmd != metadata.CmdMany {
+		return nil, fmt.Errorf("paginate is not supported for %s queries", query.Cmd)
+	}
+	sql := strings.TrimRight(query.Text, "; \t\n")
+	if limitClause.MatchString(sql) {
+		return nil, fmt.Errorf("@paginate offset requires a query without LIMIT or OFFSET")
+	}
+	next := 1
+	for _, p := range query.Params {
+		if int(p.Number) >= next {
+			next = int(p.Number) + 1
+		}
+	}
+	return &Paged{
+		SQL:      fmt.Sprintf("%s\nLIMIT $%d OFFSET $%d", sql, next, next+1),
+		CountSQL: fmt.Sprintf("SELECT count(*) FROM (\n%s\n) AS page", sql),
+	}, nil
+}
+
+func usesKeyset(queries []Query) bool {
+	for _, q := range queries {
+		if q.Keyset != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func usesPaged(queries []Query) bool {
+	for _, q := range queries {
+		if q.Paged != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package golang
+
+import (
+	"testing"
+
+	"github.com/sqlc-dev/plugin-sdk-go/metadata"
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+)
+
+func TestQueryKeyset(t *testing.T) {
+	column := func(name, typ string, notNull bool) *plugin.Column {
+		return &plugin.Column{Name: name, Type: &plugin.Identifier{Name: typ}, NotNull: notNull}
+	}
+	columns := []*plugin.Column{
+		column("id", "int8", true),
+		column("name", "text", false),
+		column("created_at", "timestamptz", true),
+	}
+	params := []*plugin.Parameter{{Number: 1, Column: column("name", "text", false)}}
+	tests := []struct {
+		annotation string
+		cmd        string
+		want       string
+		wantErr    bool
+	}{
+		{
+			annotation: "keyset(created_at, id)",
+			want: "SELECT * FROM (\nSELECT id, name, created_at FROM authors WHERE name LIKE $1\n) AS page\n" +
+				"WHERE NOT $2::boolean OR (created_at, id) > ($3::timestamptz, $4::int8)\nORDER BY created_at, id\nLIMIT $5",
+		},
+		{
+			annotation: "keyset(created_at DESC, id desc)",
+			want: "SELECT * FROM (\nSELECT id, name, created_at FROM authors WHERE name LIKE $1\n) AS page\n" +
+				"WHERE NOT $2::boolean OR (created_at, id) < ($3::timestamptz, $4::int8)\nORDER BY created_at DESC, id DESC\nLIMIT $5",
+		},
+		{annotation: "keyset(created_at desc, id)", wantErr: true},
+		{annotation: "keyset(name)", wantErr: true},
+		{annotation: "keyset(missing)", wantErr: true},
+		{annotation: "offset(id)", wantErr: true},
+		{annotation: "keyset(id)", cmd: metadata.CmdOne, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.annotation, func(t *testing.T) {
+			query := &plugin.Query{
+				Cmd:     metadata.CmdMany,
+				Text:    "SELECT id, name, created_at FROM authors WHERE name LIKE $1",
+				Columns: columns,
+				Params:  params,
+			}
+			if tc.cmd != "" {
+				query.Cmd = tc.cmd
+			}
+			got, err := queryKeyset(query, map[string]string{annotationPaginate: tc.annotation})
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("queryKeyset() = %q, want an error", got.SQL)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("queryKeyset() failed: %s", err)
+			}
+			if got.SQL != tc.want {
+				t.Errorf("queryKeyset() = %q, want %q", got.SQL, tc.want)
+			}
+		})
+	}
+}
+
+func TestQueryPaged(t *testing.T) {
+	params := []*plugin.Parameter{{Number: 1}, {Number: 2}}
+	query := func(sql string) *plugin.Query {
+		return &plugin.Query{Cmd: metadata.CmdMany, Text: sql, Params: params}
+	}
+
+	got, err := queryPaged(query("SELECT * FROM books WHERE author_id = $1 AND price < $2 ORDER BY title"), map[string]string{annotationPaginate: paginateOffset})
+	if err != nil {
+		t.Fatalf("queryPaged() failed: %s", err)
+	}
+	want := &Paged{
+		SQL:      "SELECT * FROM books WHERE author_id = $1 AND price < $2 ORDER BY title\nLIMIT $3 OFFSET $4",
+		CountSQL: "SELECT count(*) FROM (\nSELECT * FROM books WHERE author_id = $1 AND price < $2 ORDER BY title\n) AS page",
+	}
+	if *got != *want {
+		t.Errorf("queryPaged() = %+v, want %+v", got, want)
+	}
+
+	if _, err := queryPaged(query("SELECT * FROM books LIMIT 10"), map[string]string{annotationPaginate: paginateOffset}); err == nil {
+		t.Errorf("queryPaged() of a query with LIMIT succeeded")
+	}
+	if got, err := queryPaged(query("SELECT * FROM books"), map[string]string{annotationPaginate: "keyset(id)"}); got != nil || err != nil {
+		t.Errorf("queryPaged() of a keyset pagination = %v, %v", got, err)
+	}
+}
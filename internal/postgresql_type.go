@@ -35,9 +35,48 @@ func parseIdentifierString(name string) (*plugin.Identifier, error) {
 }
 
 func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugin.Column) string {
+	typ := postgresBaseType(req, options, col)
+	driver := typesDriver(options)
+	if !driver.IsPGX() || !options.EmitPointersForNullTypes || col.NotNull || col.IsArray {
+		return typ
+	}
+
+	// With emit_pointers_for_null_types postgresBaseType maps the columns of
+	// the pgtype wrappers to Go values, e.g. time.Time and *time.Time, and
+	// every other nullable column is emitted as a pointer to the type of its
+	// NOT NULL variant, instead of a wrapper such as NullStatus. The types
+	// without a Go value, such as pgtype.Interval or the ranges, are left as
+	// they are.
+	if isNilableGoType(typ) {
+		return typ
+	}
+	base := postgresBaseType(req, options, &plugin.Column{Type: col.Type, NotNull: true})
+	if base == typ || isNilableGoType(base) {
+		return typ
+	}
+	return "*" + base
+}
+
+// isNilableGoType reports whether the zero value of typ is nil, in which case
+// there is no need to wrap it in a pointer to represent NULL.
+func isNilableGoType(typ string) bool {
+	switch {
+	case strings.HasPrefix(typ, "*"),
+		strings.HasPrefix(typ, "[]"),
+		strings.HasPrefix(typ, "map["),
+		typ == "interface{}",
+		typ == "any",
+		typ == "json.RawMessage",
+		typ == "net.HardwareAddr":
+		return true
+	}
+	return false
+}
+
+func postgresBaseType(req *plugin.GenerateRequest, options *opts.Options, col *plugin.Column) string {
 	columnType := sdk.DataType(col.Type)
 	notNull := col.NotNull || col.IsArray
-	driver := parseDriver(options.SqlPackage)
+	driver := typesDriver(options)
 	emitPointersForNull := driver.IsPGX() && options.EmitPointersForNullTypes
 
 	switch columnType {
@@ -138,7 +177,10 @@ func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 		return "sql.NullFloat64" // TODO: Change to sql.NullFloat32 after updating the go.mod file
 
 	case "numeric", "pg_catalog.numeric", "money":
-		if driver.IsPGX() {
+		if typ, ok := decimalType(options, columnType, notNull, emitPointersForNull); ok {
+			return typ
+		}
+		if driver == opts.SQLDriverPGXV4 || (driver == opts.SQLDriverPGXV5 && !emitPointersForNull) {
 			return "pgtype.Numeric"
 		}
 		// Since the Go standard library does not have a decimal type, lib/pq
@@ -201,7 +243,7 @@ func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 		return "[]byte"
 
 	case "date":
-		if driver == opts.SQLDriverPGXV5 {
+		if driver == opts.SQLDriverPGXV5 && !emitPointersForNull {
 			return "pgtype.Date"
 		}
 		if notNull {
@@ -213,7 +255,7 @@ func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 		return "sql.NullTime"
 
 	case "pg_catalog.time":
-		if driver == opts.SQLDriverPGXV5 {
+		if driver == opts.SQLDriverPGXV5 && !emitPointersForNull {
 			return "pgtype.Time"
 		}
 		if notNull {
@@ -234,7 +276,7 @@ func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 		return "sql.NullTime"
 
 	case "pg_catalog.timestamp":
-		if driver == opts.SQLDriverPGXV5 {
+		if driver == opts.SQLDriverPGXV5 && !emitPointersForNull {
 			return "pgtype.Timestamp"
 		}
 		if notNull {
@@ -246,7 +288,7 @@ func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 		return "sql.NullTime"
 
 	case "pg_catalog.timestamptz", "timestamptz":
-		if driver == opts.SQLDriverPGXV5 {
+		if driver == opts.SQLDriverPGXV5 && !emitPointersForNull {
 			return "pgtype.Timestamptz"
 		}
 		if notNull {
@@ -270,13 +312,19 @@ func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 		return "sql.NullString"
 
 	case "uuid":
-		if driver == opts.SQLDriverPGXV5 {
-			return "pgtype.UUID"
+		if driver == opts.SQLDriverPGXV5 && options.UuidType != opts.UUIDTypeGoogle {
+			if !emitPointersForNull {
+				return "pgtype.UUID"
+			}
+			if notNull {
+				return "[16]byte"
+			}
+			return "*[16]byte"
 		}
 		if notNull {
 			return "uuid.UUID"
 		}
-		if emitPointersForNull {
+		if emitPointersForNull || driver == opts.SQLDriverPGXV5 {
 			return "*uuid.UUID"
 		}
 		return "uuid.NullUUID"
@@ -341,6 +389,8 @@ func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 		return "sql.NullString"
 
 	case "interval", "pg_catalog.interval":
+		// time.Duration can not hold the months and days of an interval, so
+		// intervals stay pgtype values with emit_pointers_for_null_types
 		if driver == opts.SQLDriverPGXV5 {
 			return "pgtype.Interval"
 		}
@@ -352,7 +402,7 @@ func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 		}
 		return "sql.NullInt64"
 
-	case "daterange":
+	case "daterange", "pg_catalog.daterange":
 		switch driver {
 		case opts.SQLDriverPGXV4:
 			return "pgtype.Daterange"
@@ -362,7 +412,7 @@ func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 			return "interface{}"
 		}
 
-	case "datemultirange":
+	case "datemultirange", "pg_catalog.datemultirange":
 		switch driver {
 		case opts.SQLDriverPGXV5:
 			return "pgtype.Multirange[pgtype.Range[pgtype.Date]]"
@@ -370,7 +420,7 @@ func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 			return "interface{}"
 		}
 
-	case "tsrange":
+	case "tsrange", "pg_catalog.tsrange":
 		switch driver {
 		case opts.SQLDriverPGXV4:
 			return "pgtype.Tsrange"
@@ -380,7 +430,7 @@ func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 			return "interface{}"
 		}
 
-	case "tsmultirange":
+	case "tsmultirange", "pg_catalog.tsmultirange":
 		switch driver {
 		case opts.SQLDriverPGXV5:
 			return "pgtype.Multirange[pgtype.Range[pgtype.Timestamp]]"
@@ -388,7 +438,7 @@ func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 			return "interface{}"
 		}
 
-	case "tstzrange":
+	case "tstzrange", "pg_catalog.tstzrange":
 		switch driver {
 		case opts.SQLDriverPGXV4:
 			return "pgtype.Tstzrange"
@@ -398,7 +448,7 @@ func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 			return "interface{}"
 		}
 
-	case "tstzmultirange":
+	case "tstzmultirange", "pg_catalog.tstzmultirange":
 		switch driver {
 		case opts.SQLDriverPGXV5:
 			return "pgtype.Multirange[pgtype.Range[pgtype.Timestamptz]]"
@@ -406,7 +456,7 @@ func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 			return "interface{}"
 		}
 
-	case "numrange":
+	case "numrange", "pg_catalog.numrange":
 		switch driver {
 		case opts.SQLDriverPGXV4:
 			return "pgtype.Numrange"
@@ -416,7 +466,7 @@ func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 			return "interface{}"
 		}
 
-	case "nummultirange":
+	case "nummultirange", "pg_catalog.nummultirange":
 		switch driver {
 		case opts.SQLDriverPGXV5:
 			return "pgtype.Multirange[pgtype.Range[pgtype.Numeric]]"
@@ -424,7 +474,7 @@ func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 			return "interface{}"
 		}
 
-	case "int4range":
+	case "int4range", "pg_catalog.int4range":
 		switch driver {
 		case opts.SQLDriverPGXV4:
 			return "pgtype.Int4range"
@@ -434,7 +484,7 @@ func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 			return "interface{}"
 		}
 
-	case "int4multirange":
+	case "int4multirange", "pg_catalog.int4multirange":
 		switch driver {
 		case opts.SQLDriverPGXV5:
 			return "pgtype.Multirange[pgtype.Range[pgtype.Int4]]"
@@ -442,7 +492,7 @@ func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 			return "interface{}"
 		}
 
-	case "int8range":
+	case "int8range", "pg_catalog.int8range":
 		switch driver {
 		case opts.SQLDriverPGXV4:
 			return "pgtype.Int8range"
@@ -452,7 +502,7 @@ func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 			return "interface{}"
 		}
 
-	case "int8multirange":
+	case "int8multirange", "pg_catalog.int8multirange":
 		switch driver {
 		case opts.SQLDriverPGXV5:
 			return "pgtype.Multirange[pgtype.Range[pgtype.Int8]]"
@@ -464,7 +514,8 @@ func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 		if driver.IsPGX() {
 			return "pgtype.Hstore"
 		}
-		return "interface{}"
+		// A nil map is NULL, so the same type is used for nullable columns
+		return hstoreType(options)
 
 	case "bit", "varbit", "pg_catalog.bit", "pg_catalog.varbit":
 		if driver == opts.SQLDriverPGXV5 {
@@ -476,7 +527,7 @@ func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 
 	case "cid":
 		if driver == opts.SQLDriverPGXV5 {
-			return "pgtype.Uint32"
+			return uint32Type(notNull, emitPointersForNull)
 		}
 		if driver == opts.SQLDriverPGXV4 {
 			return "pgtype.CID"
@@ -484,7 +535,7 @@ func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 
 	case "oid":
 		if driver == opts.SQLDriverPGXV5 {
-			return "pgtype.Uint32"
+			return uint32Type(notNull, emitPointersForNull)
 		}
 		if driver == opts.SQLDriverPGXV4 {
 			return "pgtype.OID"
@@ -497,7 +548,7 @@ func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 
 	case "xid":
 		if driver == opts.SQLDriverPGXV5 {
-			return "pgtype.Uint32"
+			return uint32Type(notNull, emitPointersForNull)
 		}
 		if driver == opts.SQLDriverPGXV4 {
 			return "pgtype.XID"
@@ -538,13 +589,12 @@ func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 			return "pgtype.Polygon"
 		}
 
-	case "vector":
-		if driver == opts.SQLDriverPGXV5 {
-			if emitPointersForNull {
-				return "*pgvector.Vector"
-			} else {
-				return "pgvector.Vector"
-			}
+	case "vector", "halfvec", "sparsevec":
+		return pgvectorType(driver, columnType, notNull, emitPointersForNull)
+
+	case "geometry", "geography":
+		if typ, ok := postgisType(options, driver); ok {
+			return typ
 		}
 
 	case "void":
@@ -571,6 +621,12 @@ func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 
 			for _, enum := range schema.Enums {
 				if rel.Name == enum.Name && rel.Schema == schema.Name {
+					if pkg := schemaPackage(options, req.Catalog.DefaultSchema, schema.Name); pkg != "" {
+						if notNull {
+							return pkg + "." + StructName(enum.Name, options)
+						}
+						return pkg + ".Null" + StructName(enum.Name, options)
+					}
 					enumName := ""
 					if notNull {
 						if schema.Name == req.Catalog.DefaultSchema {
@@ -596,6 +652,9 @@ func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 
 			for _, ct := range schema.CompositeTypes {
 				if rel.Name == ct.Name && rel.Schema == schema.Name {
+					if driver == opts.SQLDriverPGXV5 && findCompositeType(options, req.Catalog.DefaultSchema, schema.Name, ct.Name) != nil {
+						return compositeGoType(options, req.Catalog.DefaultSchema, schema.Name, ct.Name, notNull)
+					}
 					if notNull {
 						return "string"
 					}
@@ -605,6 +664,39 @@ func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 					return "sql.NullString"
 				}
 			}
+
+			// The row type of a table declared in composite_types
+			if driver == opts.SQLDriverPGXV5 && rel.Schema == schema.Name {
+				for _, table := range schema.Tables {
+					if rel.Name == table.Rel.Name && findCompositeType(options, req.Catalog.DefaultSchema, schema.Name, table.Rel.Name) != nil {
+						return compositeGoType(options, req.Catalog.DefaultSchema, schema.Name, table.Rel.Name, notNull)
+					}
+				}
+			}
+		}
+
+		// sqlc does not pass domain definitions to plugins, so domains are
+		// resolved to the base types listed in the domains option
+		if base, ok := domainBaseType(options, rel); ok {
+			return postgresBaseType(req, options, &plugin.Column{
+				Name:      col.Name,
+				Type:      base,
+				Table:     col.Table,
+				NotNull:   col.NotNull,
+				IsArray:   col.IsArray,
+				ArrayDims: col.ArrayDims,
+			})
+		}
+
+		// Extension types are reported with the schema the extension was
+		// created in, e.g. "public.vector" or "extensions.vector"
+		if _, ok := pgvectorTypes[rel.Name]; ok {
+			return pgvectorType(driver, rel.Name, notNull, emitPointersForNull)
+		}
+		if rel.Name == "geometry" || rel.Name == "geography" {
+			if typ, ok := postgisType(options, driver); ok {
+				return typ
+			}
 		}
 	}
 
@@ -613,3 +705,71 @@ func postgresType(req *plugin.GenerateRequest, options *opts.Options, col *plugi
 	}
 	return "interface{}"
 }
+
+// uint32Type returns the type of the pgx/v5 columns of the uint32 types, such
+// as oid
+func uint32Type(notNull, emitPointersForNull bool) string {
+	if !emitPointersForNull {
+		return "pgtype.Uint32"
+	}
+	if notNull {
+		return "uint32"
+	}
+	return "*uint32"
+}
+
+// pgvectorTypes maps the types provided by the pgvector extension to the
+// pgvector-go types implementing sql.Scanner and driver.Valuer for them
+var pgvectorTypes = map[string]string{
+	"vector":    "pgvector.Vector",
+	"halfvec":   "pgvector.HalfVector",
+	"sparsevec": "pgvector.SparseVector",
+}
+
+// pgvectorType returns the pgvector-go type for a pgvector column. The types
+// cannot hold NULL themselves, so nullable columns are pointers, except on
+// pgx/v5 where they have always been values unless
+// emit_pointers_for_null_types is set.
+func pgvectorType(driver opts.SQLDriver, name string, notNull, emitPointersForNull bool) string {
+	typ := pgvectorTypes[name]
+	if notNull || (driver == opts.SQLDriverPGXV5 && !emitPointersForNull) {
+		return typ
+	}
+	return "*" + typ
+}
+
+// postgisType returns the Go type for PostGIS geometry and geography columns
+// selected by the geometry_type option. Without it they stay interface{}, as
+// geom.T and orb.Geometry can only be scanned once a codec is registered on
+// the pgx connection, github.com/twpayne/pgx-geom for go-geom and the
+// generated RegisterOrbTypes for orb. Both are interfaces, so NULL scans into
+// a nil value. The option is only accepted with pgx/v5.
+func postgisType(options *opts.Options, driver opts.SQLDriver) (string, bool) {
+	if driver != opts.SQLDriverPGXV5 {
+		return "", false
+	}
+	switch options.GeometryType {
+	case opts.GeometryTypeGoGeom:
+		return "geom.T", true
+	case opts.GeometryTypeOrb:
+		return "orb.Geometry", true
+	}
+	return "", false
+}
+
+// domainBaseType returns the base type configured in the domains option for
+// the domain rel. Domains can be listed with or without their schema.
+func domainBaseType(options *opts.Options, rel *plugin.Identifier) (*plugin.Identifier, bool) {
+	base, ok := options.Domains[rel.Schema+"."+rel.Name]
+	if !ok {
+		base, ok = options.Domains[rel.Name]
+	}
+	if !ok {
+		return nil, false
+	}
+	typ, err := parseIdentifierString(base)
+	if err != nil {
+		return nil, false
+	}
+	return typ, true
+}
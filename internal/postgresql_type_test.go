@@ -0,0 +1,204 @@
+package golang
+
+import (
+	"testing"
+
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+func TestPointersForNullTypes(t *testing.T) {
+	req := &plugin.GenerateRequest{Catalog: &plugin.Catalog{
+		DefaultSchema: "public",
+		Schemas: []*plugin.Schema{{
+			Name:  "public",
+			Enums: []*plugin.Enum{{Name: "status", Vals: []string{"open", "closed"}}},
+		}},
+	}}
+	for _, tc := range []struct {
+		options  opts.Options
+		dbType   string
+		notNull  string
+		nullable string
+	}{
+		{dbType: "text", notNull: "string", nullable: "*string"},
+		{dbType: "int4", notNull: "int32", nullable: "*int32"},
+		{dbType: "int8", notNull: "int64", nullable: "*int64"},
+		{dbType: "float8", notNull: "float64", nullable: "*float64"},
+		{dbType: "bool", notNull: "bool", nullable: "*bool"},
+		{dbType: "date", notNull: "time.Time", nullable: "*time.Time"},
+		{dbType: "pg_catalog.time", notNull: "time.Time", nullable: "*time.Time"},
+		{dbType: "pg_catalog.timestamp", notNull: "time.Time", nullable: "*time.Time"},
+		{dbType: "timestamptz", notNull: "time.Time", nullable: "*time.Time"},
+		{dbType: "uuid", notNull: "[16]byte", nullable: "*[16]byte"},
+		{options: opts.Options{UuidType: opts.UUIDTypeGoogle}, dbType: "uuid", notNull: "uuid.UUID", nullable: "*uuid.UUID"},
+		{dbType: "numeric", notNull: "string", nullable: "*string"},
+		{options: opts.Options{NumericType: opts.NumericTypeShopspring}, dbType: "numeric", notNull: "decimal.Decimal", nullable: "*decimal.Decimal"},
+		{dbType: "oid", notNull: "uint32", nullable: "*uint32"},
+		{dbType: "inet", notNull: "netip.Addr", nullable: "*netip.Addr"},
+		{dbType: "status", notNull: "Status", nullable: "*Status"},
+		{dbType: "jsonb", notNull: "[]byte", nullable: "[]byte"},
+		{dbType: "interval", notNull: "pgtype.Interval", nullable: "pgtype.Interval"},
+		{dbType: "tstzrange", notNull: "pgtype.Range[pgtype.Timestamptz]", nullable: "pgtype.Range[pgtype.Timestamptz]"},
+	} {
+		name := tc.dbType
+		if tc.options.UuidType != "" || tc.options.NumericType != "" {
+			name += "/" + tc.options.UuidType + tc.options.NumericType
+		}
+		t.Run(name, func(t *testing.T) {
+			options := tc.options
+			options.SqlPackage = "pgx/v5"
+			options.EmitPointersForNullTypes = true
+			for _, col := range []struct {
+				notNull bool
+				want    string
+			}{
+				{true, tc.notNull},
+				{false, tc.nullable},
+			} {
+				got := postgresType(req, &options, &plugin.Column{Type: &plugin.Identifier{Name: tc.dbType}, NotNull: col.notNull})
+				if got != col.want {
+					t.Errorf("postgresType() of a column with NotNull %t = %s, want %s", col.notNull, got, col.want)
+				}
+			}
+		})
+	}
+
+	// Without the option the pgx/v5 wrappers are kept
+	options := &opts.Options{SqlPackage: "pgx/v5"}
+	for dbType, want := range map[string]string{
+		"timestamptz": "pgtype.Timestamptz",
+		"uuid":        "pgtype.UUID",
+		"numeric":     "pgtype.Numeric",
+		"oid":         "pgtype.Uint32",
+	} {
+		for _, notNull := range []bool{true, false} {
+			if got := postgresType(req, options, &plugin.Column{Type: &plugin.Identifier{Name: dbType}, NotNull: notNull}); got != want {
+				t.Errorf("postgresType() of %s with NotNull %t = %s, want %s", dbType, notNull, got, want)
+			}
+		}
+	}
+}
+
+func TestGeometryType(t *testing.T) {
+	req := &plugin.GenerateRequest{Catalog: &plugin.Catalog{DefaultSchema: "public"}}
+	for _, tc := range []struct {
+		sqlPackage   string
+		geometryType string
+		want         string
+	}{
+		{"pgx/v5", "", "interface{}"},
+		{"pgx/v5", opts.GeometryTypeGoGeom, "geom.T"},
+		{"pgx/v5", opts.GeometryTypeOrb, "orb.Geometry"},
+		{"pgx/v4", "", "interface{}"},
+		{"database/sql", "", "interface{}"},
+	} {
+		options := &opts.Options{SqlPackage: tc.sqlPackage, GeometryType: tc.geometryType}
+		for _, typ := range []*plugin.Identifier{
+			{Name: "geometry"},
+			{Name: "geography"},
+			{Schema: "public", Name: "geometry"},
+		} {
+			for _, notNull := range []bool{true, false} {
+				got := postgresType(req, options, &plugin.Column{Type: typ, NotNull: notNull})
+				if got != tc.want {
+					t.Errorf("postgresType() of %s.%s with sql_package %s and geometry_type %q = %s, want %s", typ.Schema, typ.Name, tc.sqlPackage, tc.geometryType, got, tc.want)
+				}
+			}
+		}
+	}
+
+	for _, sqlPackage := range []string{"pgx/v4", "database/sql"} {
+		req := &plugin.GenerateRequest{PluginOptions: []byte(`{"package": "db", "sql_package": "` + sqlPackage + `", "geometry_type": "go-geom"}`)}
+		options, err := opts.Parse(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := opts.ValidateOpts(options); err == nil {
+			t.Errorf("geometry_type is accepted with sql_package %s", sqlPackage)
+		}
+	}
+}
+
+func TestUUIDType(t *testing.T) {
+	req := &plugin.GenerateRequest{Catalog: &plugin.Catalog{DefaultSchema: "public"}}
+	for _, tc := range []struct {
+		sqlPackage string
+		uuidType   string
+		notNull    string
+		nullable   string
+	}{
+		{"pgx/v5", "", "pgtype.UUID", "pgtype.UUID"},
+		{"pgx/v5", opts.UUIDTypeGoogle, "uuid.UUID", "*uuid.UUID"},
+		{"pgx/v4", "", "uuid.UUID", "uuid.NullUUID"},
+		{"database/sql", "", "uuid.UUID", "uuid.NullUUID"},
+	} {
+		options := &opts.Options{SqlPackage: tc.sqlPackage, UuidType: tc.uuidType}
+		for _, col := range []struct {
+			notNull bool
+			want    string
+		}{
+			{true, tc.notNull},
+			{false, tc.nullable},
+		} {
+			got := postgresType(req, options, &plugin.Column{Type: &plugin.Identifier{Name: "uuid"}, NotNull: col.notNull})
+			if got != col.want {
+				t.Errorf("postgresType() of a uuid column with NotNull %t, sql_package %s and uuid_type %q = %s, want %s", col.notNull, tc.sqlPackage, tc.uuidType, got, col.want)
+			}
+		}
+	}
+
+	for _, sqlPackage := range []string{"pgx/v4", "database/sql"} {
+		req := &plugin.GenerateRequest{PluginOptions: []byte(`{"package": "db", "sql_package": "` + sqlPackage + `", "uuid_type": "google"}`)}
+		options, err := opts.Parse(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := opts.ValidateOpts(options); err == nil {
+			t.Errorf("uuid_type is accepted with sql_package %s", sqlPackage)
+		}
+	}
+}
+
+func TestPgvectorTypes(t *testing.T) {
+	req := &plugin.GenerateRequest{Catalog: &plugin.Catalog{DefaultSchema: "public"}}
+	for _, tc := range []struct {
+		sqlPackage          string
+		emitPointersForNull bool
+		nullable            string
+	}{
+		// pgx/v5 has always scanned NULL vectors into values
+		{"pgx/v5", false, "pgvector.Vector"},
+		{"pgx/v5", true, "*pgvector.Vector"},
+		{"pgx/v4", false, "*pgvector.Vector"},
+		{"database/sql", false, "*pgvector.Vector"},
+	} {
+		options := &opts.Options{SqlPackage: tc.sqlPackage, EmitPointersForNullTypes: tc.emitPointersForNull}
+		for _, typ := range []*plugin.Identifier{
+			{Name: "vector"},
+			{Schema: "public", Name: "vector"},
+			{Schema: "extensions", Name: "vector"},
+		} {
+			for _, col := range []struct {
+				notNull bool
+				want    string
+			}{
+				{true, "pgvector.Vector"},
+				{false, tc.nullable},
+			} {
+				got := postgresType(req, options, &plugin.Column{Type: typ, NotNull: col.notNull})
+				if got != col.want {
+					t.Errorf("postgresType() of %s.%s with NotNull %t, sql_package %s and emit_pointers_for_null_types %t = %s, want %s", typ.Schema, typ.Name, col.notNull, tc.sqlPackage, tc.emitPointersForNull, got, col.want)
+				}
+			}
+		}
+	}
+
+	options := &opts.Options{SqlPackage: "database/sql"}
+	for name, want := range map[string]string{"halfvec": "pgvector.HalfVector", "sparsevec": "pgvector.SparseVector"} {
+		if got := postgresType(req, options, &plugin.Column{Type: &plugin.Identifier{Name: name}, NotNull: true}); got != want {
+			t.Errorf("postgresType() of %s = %s, want %s", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package golang
+
+import (
+	"bytes"
+	"path"
+	"runtime/pprof"
+
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+)
+
+// profiler records the CPU and heap profiles of a generation for emit_pprof.
+// The profiles are returned as generated files, as the plugin can not write
+// files of its own when it runs as WASM.
+type profiler struct {
+	cpu     bytes.Buffer
+	running bool
+}
+
+func startProfiler() *profiler {
+	p := &profiler{}
+	// CPU profiling is not available on every platform, e.g. wasip1, only
+	// the heap is profiled there
+	p.running = pprof.StartCPUProfile(&p.cpu) == nil
+	return p
+}
+
+// stopCPU stops the CPU profile, it may be called several times
+func (p *profiler) stopCPU() {
+	if p.running {
+		pprof.StopCPUProfile()
+		p.running = false
+	}
+}
+
+// files stops the CPU profile and returns the profiles as files of dir
+func (p *profiler) files(dir string) ([]*plugin.File, error) {
+	var files []*plugin.File
+	if p.running {
+		p.stopCPU()
+		files = append(files, &plugin.File{Name: path.Join(dir, "cpu.pprof"), Contents: p.cpu.Bytes()})
+	}
+	var heap bytes.Buffer
+	if err := pprof.WriteHeapProfile(&heap); err != nil {
+		return nil, err
+	}
+	files = append(files, &plugin.File{Name: path.Join(dir, "heap.pprof"), Contents: heap.Bytes()})
+	return files, nil
+}
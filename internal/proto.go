@@ -0,0 +1,351 @@
+package golang
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+// ProtoFile describes the protobuf messages emit_proto generates for the
+// models, the result structs and the nested composites
+type ProtoFile struct {
+	Package   string // The protobuf package
+	GoPackage string // Import path of the Go code protoc generates for it
+	Imports   []string
+	Messages  []ProtoMessage
+}
+
+// ProtoMessage is the message of a struct, with the Go statements converting
+// between the struct m and the message p field by field
+type ProtoMessage struct {
+	Name    string // The message and struct name, e.g. "Author"
+	Type    string // The Go type of the struct, e.g. "models.Author"
+	Comment string
+	Fields  []ProtoField
+}
+
+type ProtoField struct {
+	Name   string // e.g. "author_id"
+	Type   string // e.g. "optional string" or "repeated Book"
+	Number int
+	To     string // Sets the field of p from m
+	From   string // Sets the field of m from p
+}
+
+// UsesUUID reports whether the conversions need the helper copying bytes
+// into UUIDs
+func (f *ProtoFile) UsesUUID() bool {
+	return f.uses("uuidFromProto(")
+}
+
+func (f *ProtoFile) uses(s string) bool {
+	for _, m := range f.Messages {
+		for _, field := range m.Fields {
+			if strings.Contains(field.To, s) || strings.Contains(field.From, s) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// usesPackage reports whether a message type or a conversion refers to pkg
+func (f *ProtoFile) usesPackage(pkg string) bool {
+	re := regexp.MustCompile(`(^|[^\w.])` + regexp.QuoteMeta(pkg) + `\.`)
+	for _, m := range f.Messages {
+		if re.MatchString(m.Type) {
+			return true
+		}
+		for _, field := range m.Fields {
+			if re.MatchString(field.To) || re.MatchString(field.From) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// protoValue converts a Go value to a protobuf field value and back, To and
+// From format the Go expression of the value
+type protoValue struct {
+	Type string
+	To   string
+	From string
+}
+
+// scalar reports whether fields of the value have a pointer type in Go when
+// they are optional, and which helper of the proto package takes its address
+func (v protoValue) scalar() (string, bool) {
+	ptr, ok := protoScalarPtrs[v.Type]
+	return ptr, ok
+}
+
+var protoScalarPtrs = map[string]string{
+	"string": "proto.String",
+	"bool":   "proto.Bool",
+	"int32":  "proto.Int32",
+	"int64":  "proto.Int64",
+	"uint32": "proto.Uint32",
+	"uint64": "proto.Uint64",
+	"float":  "proto.Float32",
+	"double": "proto.Float64",
+}
+
+const protoTimestamp = "google.protobuf.Timestamp"
+
+// protoValues are the Go types with a protobuf representation of their own
+var protoValues = map[string]protoValue{
+	"string":    {"string", "%s", "%s"},
+	"bool":      {"bool", "%s", "%s"},
+	"int":       {"int64", "int64(%s)", "int(%s)"},
+	"int8":      {"int32", "int32(%s)", "int8(%s)"},
+	"int16":     {"int32", "int32(%s)", "int16(%s)"},
+	"int32":     {"int32", "%s", "%s"},
+	"int64":     {"int64", "%s", "%s"},
+	"uint":      {"uint64", "uint64(%s)", "uint(%s)"},
+	"uint8":     {"uint32", "uint32(%s)", "uint8(%s)"},
+	"uint16":    {"uint32", "uint32(%s)", "uint16(%s)"},
+	"uint32":    {"uint32", "%s", "%s"},
+	"uint64":    {"uint64", "%s", "%s"},
+	"float32":   {"float", "%s", "%s"},
+	"float64":   {"double", "%s", "%s"},
+	"[]byte":    {"bytes", "%s", "%s"},
+	"[16]byte":  {"bytes", "%s[:]", "uuidFromProto(%s)"},
+	"uuid.UUID": {"bytes", "%s[:]", "uuid.UUID(uuidFromProto(%s))"},
+	"time.Time": {protoTimestamp, "timestamppb.New(%s)", "%s.AsTime()"},
+}
+
+type protoBuilder struct {
+	structs  map[string]struct{}
+	enums    map[string]struct{}
+	mappings map[string]*opts.ProtoTypeMapping
+	imports  map[string]struct{}
+}
+
+func newProtoBuilder(options *opts.Options, enums []Enum) *protoBuilder {
+	b := &protoBuilder{
+		structs:  map[string]struct{}{},
+		enums:    map[string]struct{}{},
+		mappings: map[string]*opts.ProtoTypeMapping{},
+		imports:  map[string]struct{}{},
+	}
+	for _, e := range enums {
+		b.enums[e.Name] = struct{}{}
+	}
+	for _, m := range options.ProtoTypeMappings {
+		b.mappings[m.GoType] = m
+	}
+	return b
+}
+
+// value returns the conversion of the Go type typ
+func (b *protoBuilder) value(typ string) (protoValue, bool) {
+	if m, ok := b.mappings[typ]; ok {
+		if m.ProtoImport != "" {
+			b.imports[m.ProtoImport] = struct{}{}
+		}
+		return protoValue{m.ProtoType, m.ToProto + "(%s)", m.FromProto + "(%s)"}, true
+	}
+	if v, ok := protoValues[typ]; ok {
+		if v.Type == protoTimestamp {
+			b.imports["google/protobuf/timestamp.proto"] = struct{}{}
+		}
+		return v, true
+	}
+	name := typ[strings.LastIndex(typ, ".")+1:]
+	if _, ok := b.structs[name]; ok {
+		return protoValue{name, name + "ToProto(%s)", name + "FromProto(%s)"}, true
+	}
+	if _, ok := b.enums[name]; ok {
+		return protoValue{"string", "string(%s)", typ + "(%s)"}, true
+	}
+	return protoValue{}, false
+}
+
+// field returns the protobuf field of the Go field goName of type typ
+func (b *protoBuilder) field(goName, typ string, number int) (ProtoField, bool) {
+	name := toSnakeCase(goName)
+	m, p := "m."+goName, "p."+protoGoName(name)
+	f := ProtoField{Name: name, Number: number}
+
+	if v, ok := b.value(typ); ok {
+		f.Type = v.Type
+		f.To = fmt.Sprintf("%s = %s", p, protoApply(v.To, m))
+		f.From = fmt.Sprintf("%s = %s", m, protoApply(v.From, p))
+		if _, scalar := v.scalar(); !scalar && v.Type != "bytes" {
+			f.From = fmt.Sprintf("if %s != nil {\n%s\n}", p, f.From)
+		}
+		return f, true
+	}
+
+	if n, ok := findNullWrapper(b.enums, typ); ok && !strings.HasPrefix(typ, "*") {
+		v, ok := b.value(n.ValueType)
+		if !ok {
+			return f, false
+		}
+		f.Type = v.Type
+		value, src := m+"."+n.ValueField, p
+		to := protoApply(v.To, value)
+		if ptr, scalar := v.scalar(); scalar {
+			f.Type = "optional " + v.Type
+			to = ptr + "(" + to + ")"
+			src = "*" + p
+		}
+		f.To = fmt.Sprintf("if %s.Valid {\n%s = %s\n}", m, p, to)
+		f.From = fmt.Sprintf("if %s != nil {\n%s = %s{%s: %s, Valid: true}\n}", p, m, typ, n.ValueField, protoApply(v.From, src))
+		return f, true
+	}
+
+	if elem, ok := strings.CutPrefix(typ, "*"); ok {
+		v, ok := b.value(elem)
+		if !ok {
+			return f, false
+		}
+		f.Type = v.Type
+		to, src := protoApply(v.To, "*"+m), p
+		if ptr, scalar := v.scalar(); scalar {
+			f.Type = "optional " + v.Type
+			to = ptr + "(" + to + ")"
+			src = "*" + p
+		}
+		f.To = fmt.Sprintf("if %s != nil {\n%s = %s\n}", m, p, to)
+		f.From = fmt.Sprintf("if %s != nil {\nv := %s\n%s = &v\n}", p, protoApply(v.From, src), m)
+		return f, true
+	}
+
+	if elem, ok := strings.CutPrefix(typ, "[]"); ok {
+		elem, pointer := strings.CutPrefix(elem, "*")
+		v, ok := b.value(elem)
+		if !ok || (pointer && v.Type != elem[strings.LastIndex(elem, ".")+1:]) {
+			return f, false
+		}
+		f.Type = "repeated " + v.Type
+		if pointer {
+			f.To = fmt.Sprintf("for _, v := range %s {\n%s = append(%s, %s)\n}", m, p, p, protoApply(v.To, "*v"))
+			f.From = fmt.Sprintf("for _, v := range %s {\ne := %s\n%s = append(%s, &e)\n}", p, protoApply(v.From, "v"), m, m)
+		} else if v.To == "%s" && v.From == "%s" {
+			f.To = fmt.Sprintf("%s = append(%s, %s...)", p, p, m)
+			f.From = fmt.Sprintf("%s = append(%s, %s...)", m, m, p)
+		} else {
+			f.To = fmt.Sprintf("for _, v := range %s {\n%s = append(%s, %s)\n}", m, p, p, protoApply(v.To, "v"))
+			f.From = fmt.Sprintf("for _, v := range %s {\n%s = append(%s, %s)\n}", p, m, m, protoApply(v.From, "v"))
+		}
+		return f, true
+	}
+
+	return f, false
+}
+
+// protoApply formats the conversion of expr, parenthesizing dereferences
+// that are indexed
+func protoApply(format, expr string) string {
+	if strings.HasPrefix(expr, "*") && strings.Contains(format, "%s[") {
+		expr = "(" + expr + ")"
+	}
+	return fmt.Sprintf(format, expr)
+}
+
+// protoGoName returns the name protoc-gen-go gives to the Go field of the
+// protobuf field name
+func protoGoName(name string) string {
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c == '_' && i+1 < len(name) && isASCIILower(name[i+1]):
+			// The underscore is dropped and the next letter capitalized
+		case isASCIILower(c) && (i == 0 || name[i-1] == '_' || isASCIIDigit(name[i-1])):
+			b.WriteByte(c - 'a' + 'A')
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+func isASCIILower(c byte) bool {
+	return 'a' <= c && c <= 'z'
+}
+
+func isASCIIDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// buildProtoFile describes the messages of the models, the result structs
+// and the nested composites. Fields of a Go type without a protobuf type are
+// reported, so that they can be mapped with proto_type_mappings.
+func buildProtoFile(options *opts.Options, enums []Enum, structs []Struct, queries []Query, nested []Nested) (*ProtoFile, error) {
+	b := newProtoBuilder(options, enums)
+	walkOutputStructs(structs, queries, nested, func(s outputStruct) {
+		b.structs[s.Name] = struct{}{}
+	})
+
+	file := &ProtoFile{Package: options.ProtoPackage, GoPackage: options.ProtoGoPackage}
+	if file.Package == "" {
+		file.Package = options.Package
+	}
+	seen := map[string]struct{}{}
+	var err error
+	walkOutputStructs(structs, queries, nested, func(s outputStruct) {
+		if _, ok := seen[s.Name]; ok || err != nil {
+			return
+		}
+		seen[s.Name] = struct{}{}
+		msg := ProtoMessage{Name: s.Name, Type: s.Type, Comment: s.Comment}
+		add := func(goName, typ string) {
+			f, ok := b.field(goName, typ, len(msg.Fields)+1)
+			if !ok {
+				err = fmt.Errorf("emit_proto: %s.%s has Go type %s without a protobuf type, map it with proto_type_mappings", s.Name, goName, typ)
+				return
+			}
+			msg.Fields = append(msg.Fields, f)
+		}
+		for _, f := range s.Fields {
+			add(f.Name, f.Type)
+		}
+		for _, n := range s.Nested {
+			add(n.FieldName, n.FieldType)
+		}
+		file.Messages = append(file.Messages, msg)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for imp := range b.imports {
+		file.Imports = append(file.Imports, imp)
+	}
+	sort.Strings(file.Imports)
+	return file, nil
+}
+
+// Contents renders the .proto file
+func (f *ProtoFile) Contents() []byte {
+	var b strings.Builder
+	b.WriteString("// Code generated by sqlc. DO NOT EDIT.\n\n")
+	b.WriteString("syntax = \"proto3\";\n\n")
+	fmt.Fprintf(&b, "package %s;\n\n", f.Package)
+	for _, imp := range f.Imports {
+		fmt.Fprintf(&b, "import %q;\n", imp)
+	}
+	if len(f.Imports) > 0 {
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "option go_package = %q;\n", f.GoPackage)
+	for _, m := range f.Messages {
+		b.WriteString("\n")
+		for _, line := range strings.Split(strings.TrimSpace(m.Comment), "\n") {
+			if line != "" {
+				fmt.Fprintf(&b, "// %s\n", strings.TrimSpace(line))
+			}
+		}
+		fmt.Fprintf(&b, "message %s {\n", m.Name)
+		for _, field := range m.Fields {
+			fmt.Fprintf(&b, "  %s %s = %d;\n", field.Type, field.Name, field.Number)
+		}
+		b.WriteString("}\n")
+	}
+	return []byte(b.String())
+}
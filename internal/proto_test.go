@@ -0,0 +1,70 @@
+package golang
+
+import (
+	"testing"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+func TestProtoField(t *testing.T) {
+	b := newProtoBuilder(&opts.Options{ProtoTypeMappings: []*opts.ProtoTypeMapping{
+		{GoType: "pgtype.Numeric", ProtoType: "string", ToProto: "numericToProto", FromProto: "numericFromProto"},
+	}}, []Enum{{Name: "Status"}})
+	b.structs["Book"] = struct{}{}
+
+	for _, tc := range []struct {
+		goName string
+		typ    string
+		want   ProtoField
+	}{
+		{"Name", "string", ProtoField{Name: "name", Type: "string", To: "p.Name = m.Name", From: "m.Name = p.Name"}},
+		{"AuthorID", "int16", ProtoField{Name: "author_id", Type: "int32", To: "p.AuthorId = int32(m.AuthorID)", From: "m.AuthorID = int16(p.AuthorId)"}},
+		{"Bio", "pgtype.Text", ProtoField{
+			Name: "bio", Type: "optional string",
+			To:   "if m.Bio.Valid {\np.Bio = proto.String(m.Bio.String)\n}",
+			From: "if p.Bio != nil {\nm.Bio = pgtype.Text{String: *p.Bio, Valid: true}\n}",
+		}},
+		{"Status", "NullStatus", ProtoField{
+			Name: "status", Type: "optional string",
+			To:   "if m.Status.Valid {\np.Status = proto.String(string(m.Status.Status))\n}",
+			From: "if p.Status != nil {\nm.Status = NullStatus{Status: Status(*p.Status), Valid: true}\n}",
+		}},
+		{"Price", "*pgtype.Numeric", ProtoField{
+			Name: "price", Type: "optional string",
+			To:   "if m.Price != nil {\np.Price = proto.String(numericToProto(*m.Price))\n}",
+			From: "if p.Price != nil {\nv := numericFromProto(*p.Price)\nm.Price = &v\n}",
+		}},
+		{"Tags", "[]string", ProtoField{Name: "tags", Type: "repeated string", To: "p.Tags = append(p.Tags, m.Tags...)", From: "m.Tags = append(m.Tags, p.Tags...)"}},
+		{"Books", "[]*Book", ProtoField{
+			Name: "books", Type: "repeated Book",
+			To:   "for _, v := range m.Books {\np.Books = append(p.Books, BookToProto(*v))\n}",
+			From: "for _, v := range p.Books {\ne := BookFromProto(v)\nm.Books = append(m.Books, &e)\n}",
+		}},
+	} {
+		t.Run(tc.goName, func(t *testing.T) {
+			got, ok := b.field(tc.goName, tc.typ, 0)
+			if !ok {
+				t.Fatalf("field(%q, %q) has no protobuf type", tc.goName, tc.typ)
+			}
+			if got != tc.want {
+				t.Errorf("field(%q, %q) = %#v, want %#v", tc.goName, tc.typ, got, tc.want)
+			}
+		})
+	}
+
+	if _, ok := b.field("Attrs", "pgtype.Hstore", 0); ok {
+		t.Errorf("field of unmapped type pgtype.Hstore has a protobuf type")
+	}
+}
+
+func TestProtoGoName(t *testing.T) {
+	for name, want := range map[string]string{
+		"id":        "Id",
+		"author_id": "AuthorId",
+		"name_2":    "Name_2",
+	} {
+		if got := protoGoName(name); got != want {
+			t.Errorf("protoGoName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
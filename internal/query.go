@@ -76,6 +76,16 @@ func (v QueryValue) Pairs() []Argument {
 	}
 }
 
+// Names returns the argument names of Pairs, for calls forwarding the
+// arguments of a query method
+func (v QueryValue) Names() string {
+	var out []string
+	for _, arg := range v.Pairs() {
+		out = append(out, arg.Name)
+	}
+	return strings.Join(out, ", ")
+}
+
 func (v QueryValue) SlicePair() string {
 	if v.isEmpty() {
 		return ""
@@ -93,7 +103,7 @@ func (v QueryValue) Type() string {
 	panic("no type for QueryValue: " + v.Name)
 }
 
-func (v *QueryValue) DefineType() string {
+func (v QueryValue) DefineType() string {
 	t := v.Type()
 	if v.IsPointer() {
 		return "*" + t
@@ -101,13 +111,25 @@ func (v *QueryValue) DefineType() string {
 	return t
 }
 
-func (v *QueryValue) ReturnName() string {
+func (v QueryValue) ReturnName() string {
 	if v.IsPointer() {
 		return "&" + escape(v.Name)
 	}
 	return escape(v.Name)
 }
 
+// EmbeddedFields returns the fields of the entities embedded with
+// sqlc.embed()
+func (v QueryValue) EmbeddedFields() []Field {
+	var fields []Field
+	for _, f := range v.Struct.Fields {
+		if len(f.EmbedFields) > 0 {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
 func (v QueryValue) UniqueFields() []Field {
 	seen := map[string]struct{}{}
 	fields := make([]Field, 0, len(v.Struct.Fields))
@@ -267,6 +289,43 @@ type Query struct {
 	Arg          QueryValue
 	// Used for :copyfrom
 	Table *plugin.Identifier
+	// Go type of the id returned by an :execlastid query, set by
+	// emit_typed_last_insert_id
+	LastInsertIDType string
+	// Whether an :execlastid query reads the rowid from its RETURNING clause,
+	// set by sqlite_min_version
+	ReturningRowID bool
+	// Handle the query runs on when reads and writes are split, reader or writer
+	Route string
+	// Go expression of the duration the query is bounded to, if any
+	Timeout string
+	// Number of retries after transient errors, set by a retry annotation
+	Retries int
+	// Go expression of the time the results are cached for, set by a cache
+	// annotation
+	CacheTTL string
+	// Whether the query opts out of emit_prepared_queries, set by a prepare
+	// annotation
+	Unprepared bool
+	// SQL of the variant inserting rows from arrays, set by a bulk annotation
+	BulkSQL string
+	// SQL of the variant reporting the action of an upsert, set by an upsert
+	// annotation
+	UpsertSQL string
+	// Keyset pagination of the query, set by a paginate annotation
+	Keyset *Keyset
+	// Limit/offset pagination of the query, set by a paginate annotation
+	Paged *Paged
+	// Methods derived from the query, set by a derive annotation
+	Derived *Derived
+	// Dynamic filter of the query, set by filter and order_by annotations
+	Filter *Filter
+	// Models the emitted result struct has all the columns of, set by
+	// emit_model_from_row
+	RowModels []Struct
+	// Method name of the query this query is a variant of, whose argument and
+	// result structs it uses instead of declaring its own
+	VariantOf string
 	// Used for nested grouping
 	HasNestedConfig          bool   // Whether this query has nested configuration
 	GroupFunctionName        string // Name of the group function to call (e.g., "GroupGetAuthors")
@@ -302,6 +361,28 @@ func (q Query) TableIdentifierForMySQL() string {
 	return strings.Join(escapedNames, ".")
 }
 
+// SQLiteInsert returns the Go string literal of the INSERT statement of a
+// SQLite :copyfrom query, up to VALUES
+func (q Query) SQLiteInsert() string {
+	names := make([]string, 0, 2)
+	for _, p := range []string{q.Table.Schema, q.Table.Name} {
+		if p != "" {
+			names = append(names, `"`+p+`"`)
+		}
+	}
+	columns := q.Arg.ColumnNames()
+	for i, c := range columns {
+		columns[i] = `"` + c + `"`
+	}
+	return "`INSERT INTO " + strings.Join(names, ".") + " (" + strings.Join(columns, ", ") + ") VALUES `"
+}
+
+// SQLiteValues returns the Go string literal of the placeholders of one row
+// of a SQLite :copyfrom query
+func (q Query) SQLiteValues() string {
+	return `"(` + strings.Repeat(", ?", len(q.Arg.ColumnNames()))[2:] + `)"`
+}
+
 // Helper methods for nested grouping
 func (q Query) ShouldCallGroupFunction() bool {
 	return q.HasNestedConfig && (q.Cmd == metadata.CmdMany || q.Cmd == metadata.CmdOne)
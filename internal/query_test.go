@@ -0,0 +1,60 @@
+package golang
+
+import (
+	"testing"
+
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+)
+
+func TestEmbeddedFields(t *testing.T) {
+	v := QueryValue{Struct: &Struct{Name: "ListAuthorsWithBooksRow", Fields: []Field{
+		{Name: "ID", Type: "int64"},
+		{Name: "Author", Type: "Author", EmbedFields: []Field{{Name: "ID"}}},
+		{Name: "Book", Type: "Book", EmbedFields: []Field{{Name: "Title"}}},
+	}}}
+	fields := v.EmbeddedFields()
+	if len(fields) != 2 || fields[0].Name != "Author" || fields[1].Name != "Book" {
+		t.Errorf("EmbeddedFields() = %v, want the Author and Book fields", fields)
+	}
+
+	ctx := &tmplCtx{EmitRowEntityMethods: true}
+	if !ctx.EmitRowEntities(v) {
+		t.Errorf("EmitRowEntities() = false for a row with embedded entities")
+	}
+	flat := QueryValue{Struct: &Struct{Fields: []Field{{Name: "ID", Type: "int64"}}}}
+	if ctx.EmitRowEntities(flat) {
+		t.Errorf("EmitRowEntities() = true for a row without embedded entities")
+	}
+}
+
+func TestSQLiteCopyFrom(t *testing.T) {
+	q := Query{
+		Table: &plugin.Identifier{Schema: "main", Name: "books"},
+		Arg: QueryValue{Struct: &Struct{Fields: []Field{
+			{Name: "Title", DBName: "title"},
+			{Name: "AuthorID", DBName: "author_id"},
+		}}},
+	}
+	if got, want := q.SQLiteInsert(), "`INSERT INTO \"main\".\"books\" (\"title\", \"author_id\") VALUES `"; got != want {
+		t.Errorf("SQLiteInsert() = %s, want %s", got, want)
+	}
+	if got, want := q.SQLiteValues(), `"(?, ?)"`; got != want {
+		t.Errorf("SQLiteValues() = %s, want %s", got, want)
+	}
+	single := Query{Table: &plugin.Identifier{Name: "books"}, Arg: QueryValue{DBName: "title"}}
+	if got, want := single.SQLiteInsert(), "`INSERT INTO \"books\" (\"title\") VALUES `"; got != want {
+		t.Errorf("SQLiteInsert() = %s, want %s", got, want)
+	}
+
+	ctx := &tmplCtx{}
+	if got := ctx.SQLiteCopyFromChunkSize(q); got != 499 {
+		t.Errorf("SQLiteCopyFromChunkSize() = %d, want the 499 rows fitting in 999 parameters", got)
+	}
+	if got := ctx.SQLiteCopyFromChunkSize(single); got != 999 {
+		t.Errorf("SQLiteCopyFromChunkSize() = %d, want 999", got)
+	}
+	ctx.CopyFromChunkSize = 100
+	if got := ctx.SQLiteCopyFromChunkSize(q); got != 100 {
+		t.Errorf("SQLiteCopyFromChunkSize() with copyfrom_chunk_size 100 = %d", got)
+	}
+}
@@ -0,0 +1,49 @@
+package golang
+
+// RangeHelper describes the Lower, Upper and Contains helpers generated for a
+// pgx v5 range type, e.g. Int4RangeLower for pgtype.Range[pgtype.Int4]
+type RangeHelper struct {
+	Name       string // Helper name stem, e.g. "Int4Range"
+	Type       string // The range type, e.g. "pgtype.Range[pgtype.Int4]"
+	GoType     string // The Go type of a bound, e.g. "int32"
+	ValueField string // The bound field holding the value, e.g. "Int32"
+}
+
+// IsTime reports whether bounds are compared with time.Time methods rather
+// than with operators
+func (r RangeHelper) IsTime() bool {
+	return r.GoType == "time.Time"
+}
+
+// pgtypeRangeHelpers lists the pgx v5 range types whose bounds can be
+// compared, in the order their helpers are emitted
+var pgtypeRangeHelpers = []RangeHelper{
+	{Name: "Int4Range", Type: "pgtype.Range[pgtype.Int4]", GoType: "int32", ValueField: "Int32"},
+	{Name: "Int8Range", Type: "pgtype.Range[pgtype.Int8]", GoType: "int64", ValueField: "Int64"},
+	{Name: "DateRange", Type: "pgtype.Range[pgtype.Date]", GoType: "time.Time", ValueField: "Time"},
+	{Name: "TsRange", Type: "pgtype.Range[pgtype.Timestamp]", GoType: "time.Time", ValueField: "Time"},
+	{Name: "TstzRange", Type: "pgtype.Range[pgtype.Timestamptz]", GoType: "time.Time", ValueField: "Time"},
+}
+
+// buildRangeHelpers returns the helpers for the range types that are actually
+// used by the generated models and queries
+func buildRangeHelpers(structs []Struct, queries []Query) []RangeHelper {
+	used := usedTypes(structs, queries)
+	var helpers []RangeHelper
+	for _, h := range pgtypeRangeHelpers {
+		if _, ok := used[h.Type]; ok {
+			helpers = append(helpers, h)
+		}
+	}
+	return helpers
+}
+
+// usesTimeRange reports whether any of the helpers has time.Time bounds
+func usesTimeRange(helpers []RangeHelper) bool {
+	for _, h := range helpers {
+		if h.IsTime() {
+			return true
+		}
+	}
+	return false
+}
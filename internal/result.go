@@ -3,6 +3,7 @@ package golang
 import (
 	"bufio"
 	"fmt"
+	"path"
 	"sort"
 	"strings"
 
@@ -13,15 +14,16 @@ import (
 	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
 )
 
-func buildEnums(req *plugin.GenerateRequest, options *opts.Options) []Enum {
+func buildEnums(req *plugin.GenerateRequest, options *opts.Options) ([]Enum, error) {
 	var enums []Enum
 	for _, schema := range req.Catalog.Schemas {
 		if schema.Name == "pg_catalog" || schema.Name == "information_schema" {
 			continue
 		}
 		for _, enum := range schema.Enums {
+			pkg := schemaPackage(options, req.Catalog.DefaultSchema, schema.Name)
 			var enumName string
-			if schema.Name == req.Catalog.DefaultSchema {
+			if schema.Name == req.Catalog.DefaultSchema || pkg != "" {
 				enumName = enum.Name
 			} else {
 				enumName = schema.Name + "_" + enum.Name
@@ -29,23 +31,46 @@ func buildEnums(req *plugin.GenerateRequest, options *opts.Options) []Enum {
 
 			e := Enum{
 				Name:      StructName(enumName, options),
+				DBName:    schema.Name + "." + enum.Name,
 				Comment:   enum.Comment,
 				NameTags:  map[string]string{},
 				ValidTags: map[string]string{},
+				Package:   pkg,
 			}
 			if options.EmitJsonTags {
 				e.NameTags["json"] = JSONTagName(enumName, options)
 				e.ValidTags["json"] = JSONTagName("valid", options)
 			}
 
+			cfg := findEnumConfig(options, req.Catalog.DefaultSchema, schema.Name, enum.Name)
+			// The constants renamed by the enum config are reserved first, a
+			// generated constant cannot fall back to their names
+			renamed := map[string]string{}
+			for _, v := range enum.Vals {
+				if name, ok := cfg.Rename[v]; ok {
+					renamed[name] = v
+				}
+			}
 			seen := make(map[string]struct{}, len(enum.Vals))
 			for i, v := range enum.Vals {
-				value := EnumReplace(v)
+				if name, ok := cfg.Rename[v]; ok {
+					e.Constants = append(e.Constants, Constant{
+						Name:  name,
+						Value: v,
+						Type:  e.Name,
+					})
+					continue
+				}
+				value := EnumReplace(strings.TrimPrefix(v, cfg.TrimPrefix))
 				if _, found := seen[value]; found || value == "" {
 					value = fmt.Sprintf("value_%d", i)
 				}
+				name := StructName(enumName+"_"+value, options)
+				if other, ok := renamed[name]; ok {
+					return nil, fmt.Errorf("enum %s: value %q is renamed to %s, the name of the constant of value %q", e.DBName, other, name, v)
+				}
 				e.Constants = append(e.Constants, Constant{
-					Name:  StructName(enumName+"_"+value, options),
+					Name:  name,
 					Value: v,
 					Type:  e.Name,
 				})
@@ -57,7 +82,7 @@ func buildEnums(req *plugin.GenerateRequest, options *opts.Options) []Enum {
 	if len(enums) > 0 {
 		sort.Slice(enums, func(i, j int) bool { return enums[i].Name < enums[j].Name })
 	}
-	return enums
+	return enums, nil
 }
 
 func buildStructs(req *plugin.GenerateRequest, options *opts.Options) []Struct {
@@ -66,9 +91,13 @@ func buildStructs(req *plugin.GenerateRequest, options *opts.Options) []Struct {
 		if schema.Name == "pg_catalog" || schema.Name == "information_schema" {
 			continue
 		}
+		pkg := schemaPackage(options, req.Catalog.DefaultSchema, schema.Name)
+		if pkg == "" {
+			pkg = options.OutputModelsPackage
+		}
 		for _, table := range schema.Tables {
 			var tableName string
-			if schema.Name == req.Catalog.DefaultSchema {
+			if schema.Name == req.Catalog.DefaultSchema || options.SchemaPackages {
 				tableName = table.Rel.Name
 			} else {
 				tableName = schema.Name + "_" + table.Rel.Name
@@ -83,10 +112,14 @@ func buildStructs(req *plugin.GenerateRequest, options *opts.Options) []Struct {
 			s := Struct{
 				Table:   &plugin.Identifier{Schema: schema.Name, Name: table.Rel.Name},
 				Name:    StructName(structName, options),
-				Package: options.OutputModelsPackage,
+				Package: pkg,
 				Comment: table.Comment,
 			}
+			omitted := omittedColumns(options, req.Catalog.DefaultSchema, s.Table)
 			for _, column := range table.Columns {
+				if _, ok := omitted[column.Name]; ok {
+					continue
+				}
 				tags := map[string]string{}
 				if options.EmitDbTags {
 					tags["db"] = column.Name
@@ -95,16 +128,23 @@ func buildStructs(req *plugin.GenerateRequest, options *opts.Options) []Struct {
 					tags["json"] = JSONTagName(column.Name, options)
 				}
 				addExtraGoStructTags(tags, req, options, column)
+				sensitive := columnSensitive(req, options, column, column.Comment)
+				if sensitive {
+					addSensitiveStructTags(tags, options)
+				}
 				s.Fields = append(s.Fields, Field{
-					Name:    StructName(column.Name, options),
-					Type:    goType(req, options, column),
-					Tags:    tags,
-					Comment: column.Comment,
+					Name:      StructName(column.Name, options),
+					Type:      goType(req, options, column),
+					Tags:      tags,
+					Comment:   deprecatedComment(column.Comment),
+					Column:    column,
+					Sensitive: sensitive,
 				})
 			}
 			structs = append(structs, s)
 		}
 	}
+	structs = append(structs, buildCompositeStructs(req, options)...)
 	if len(structs) > 0 {
 		sort.Slice(structs, func(i, j int) bool { return structs[i].Name < structs[j].Name })
 	}
@@ -121,6 +161,8 @@ type goEmbed struct {
 	modelType string
 	modelName string
 	fields    []Field
+	nullable  bool
+	validFlag bool
 }
 
 // look through all the structs and attempt to find a matching one to embed
@@ -184,12 +226,53 @@ func argName(name string) string {
 	return out
 }
 
+// querySourceName returns the slash separated path, relative to the query
+// output directory, of the SQL file a query was read from. Directories are
+// only kept with mirror_query_directories, and paths leaving the output
+// directory, such as Windows paths with a drive letter, are flattened.
+func querySourceName(options *opts.Options, filename string) string {
+	clean := opts.SlashPath(filename)
+	if !options.MirrorQueryDirectories || !opts.IsLocalPath(clean) {
+		return path.Base(clean)
+	}
+	return clean
+}
+
+// QueryName returns the Go method name of the query named name, applying the
+// rename map. Unlike struct names, renamed query names are used verbatim.
+func QueryName(name string, options *opts.Options) string {
+	if rename := options.Rename[name]; rename != "" {
+		return rename
+	}
+	for _, p := range options.RenamePatterns {
+		if p.Pattern.MatchString(name) {
+			return p.Pattern.ReplaceAllString(name, p.Replacement)
+		}
+	}
+	return name
+}
+
+// queryConstantName returns the name of the constant holding the SQL of the
+// query, set by query_constant_names or derived from its method name and
+// query_constant_suffix
+func queryConstantName(name, methodName string, options *opts.Options) string {
+	if constant := options.QueryConstantNames[name]; constant != "" {
+		return constant
+	}
+	if options.EmitExportedQueries {
+		return sdk.Title(methodName) + options.QueryConstantSuffix
+	}
+	return sdk.LowerTitle(methodName) + options.QueryConstantSuffix
+}
+
 func buildQueries(req *plugin.GenerateRequest, options *opts.Options, structs []Struct) ([]Query, error) {
 	qs := make([]Query, 0, len(req.Queries))
 
 	// Track struct_root usage across all queries to detect reuse opportunities
 	structRootUsage := make(map[string]string) // maps struct_root -> first query that uses it
+	var nestedQueries []*opts.NestedQueryConfig
 	if options.Nested != nil {
+		nestedQueries = options.Nested.Queries
 		for _, nestedConfig := range options.Nested.Queries {
 			structRoot := nestedConfig.StructRoot
 			if structRoot == "" {
@@ -201,6 +284,11 @@ func buildQueries(req *plugin.GenerateRequest, options *opts.Options, structs []
 		}
 	}
 
+	var locator *sourceLocator
+	if options.EmitSqlSourceLocation {
+		locator = newSourceLocator(req)
+	}
+
 	for _, query := range req.Queries {
 		if query.Name == "" {
 			continue
@@ -208,20 +296,64 @@ func buildQueries(req *plugin.GenerateRequest, options *opts.Options, structs []
 		if query.Cmd == "" {
 			continue
 		}
+		methodName := QueryName(query.Name, options)
 
-		var constantName string
-		if options.EmitExportedQueries {
-			constantName = sdk.Title(query.Name)
-		} else {
-			constantName = sdk.LowerTitle(query.Name)
-		}
+		constantName := queryConstantName(query.Name, methodName, options)
 
-		comments := query.Comments
+		comments, annotations := splitAnnotations(query.Comments)
+		route, err := queryRoute(query.Cmd, annotations)
+		if err != nil {
+			return nil, fmt.Errorf("query %s: %w", query.Name, err)
+		}
+		timeout, err := queryTimeout(query.Cmd, annotations)
+		if err != nil {
+			return nil, fmt.Errorf("query %s: %w", query.Name, err)
+		}
+		retries, err := queryRetries(query.Cmd, annotations)
+		if err != nil {
+			return nil, fmt.Errorf("query %s: %w", query.Name, err)
+		}
+		cacheTTL, err := queryCacheTTL(query.Cmd, annotations)
+		if err != nil {
+			return nil, fmt.Errorf("query %s: %w", query.Name, err)
+		}
+		querySQL, returningRowID, err := sqliteReturningSQL(req, options, query)
+		if err != nil {
+			return nil, fmt.Errorf("query %s: %w", query.Name, err)
+		}
+		unprepared, err := queryUnprepared(annotations)
+		if err != nil {
+			return nil, fmt.Errorf("query %s: %w", query.Name, err)
+		}
+		bulkSQL, err := queryBulkSQL(query, annotations)
+		if err != nil {
+			return nil, fmt.Errorf("query %s: %w", query.Name, err)
+		}
+		upsertSQL, err := queryUpsertSQL(query, annotations)
+		if err != nil {
+			return nil, fmt.Errorf("query %s: %w", query.Name, err)
+		}
+		keyset, err := queryKeyset(query, annotations)
+		if err != nil {
+			return nil, fmt.Errorf("query %s: %w", query.Name, err)
+		}
+		paged, err := queryPaged(query, annotations)
+		if err != nil {
+			return nil, fmt.Errorf("query %s: %w", query.Name, err)
+		}
+		derived, err := queryDerived(query, annotations)
+		if err != nil {
+			return nil, fmt.Errorf("query %s: %w", query.Name, err)
+		}
+		comments = deprecatedQueryComments(comments)
 		if options.EmitSqlAsComment {
 			if len(comments) == 0 {
-				comments = append(comments, query.Name)
+				comments = append(comments, methodName)
 			}
 			comments = append(comments, " ")
+			if locator != nil {
+				comments = append(comments, " Source: "+locator.location(query), " ")
+			}
 			scanner := bufio.NewScanner(strings.NewReader(query.Text))
 			for scanner.Scan() {
 				line := scanner.Text()
@@ -233,14 +365,26 @@ func buildQueries(req *plugin.GenerateRequest, options *opts.Options, structs []
 		}
 
 		gq := Query{
-			Cmd:          query.Cmd,
-			ConstantName: constantName,
-			FieldName:    sdk.LowerTitle(query.Name) + "Stmt",
-			MethodName:   query.Name,
-			SourceName:   query.Filename,
-			SQL:          query.Text,
-			Comments:     comments,
-			Table:        query.InsertIntoTable,
+			Cmd:              query.Cmd,
+			ConstantName:     constantName,
+			FieldName:        sdk.LowerTitle(methodName) + "Stmt",
+			MethodName:       methodName,
+			SourceName:       querySourceName(options, query.Filename),
+			SQL:              querySQL,
+			Comments:         comments,
+			Table:            query.InsertIntoTable,
+			LastInsertIDType: lastInsertIDType(req, options, query),
+			ReturningRowID:   returningRowID,
+			Route:            route,
+			Timeout:          timeout,
+			Retries:          retries,
+			CacheTTL:         cacheTTL,
+			Unprepared:       unprepared,
+			BulkSQL:          bulkSQL,
+			UpsertSQL:        upsertSQL,
+			Keyset:           keyset,
+			Paged:            paged,
+			Derived:          derived,
 		}
 		sqlpkg := parseDriver(options.SqlPackage)
 
@@ -251,7 +395,7 @@ func buildQueries(req *plugin.GenerateRequest, options *opts.Options, structs []
 			gq.Arg = QueryValue{
 				Name:      escape(paramName(p)),
 				DBName:    p.Column.GetName(),
-				Typ:       goType(req, options, p.Column),
+				Typ:       paramType(req, options, p.Column),
 				SQLDriver: sqlpkg,
 				Column:    p.Column,
 			}
@@ -267,6 +411,9 @@ func buildQueries(req *plugin.GenerateRequest, options *opts.Options, structs []
 			if err != nil {
 				return nil, err
 			}
+			for i, f := range s.Fields {
+				s.Fields[i].Type = paramType(req, options, f.Column)
+			}
 			gq.Arg = QueryValue{
 				Emit:        true,
 				Name:        "arg",
@@ -276,8 +423,9 @@ func buildQueries(req *plugin.GenerateRequest, options *opts.Options, structs []
 			}
 
 			// if query params is 2, and query params limit is 4 AND this is a copyfrom, we still want to emit the query's model
-			// otherwise we end up with a copyfrom using a struct without the struct definition
-			if len(query.Params) <= qpl && query.Cmd != ":copyfrom" {
+			// otherwise we end up with a copyfrom using a struct without the struct definition.
+			// The same goes for the bulk variant of a query.
+			if len(query.Params) <= qpl && query.Cmd != ":copyfrom" && bulkSQL == "" {
 				gq.Arg.Emit = false
 			}
 		}
@@ -319,10 +467,18 @@ func buildQueries(req *plugin.GenerateRequest, options *opts.Options, structs []
 			if gs == nil {
 				var columns []goColumn
 				for i, c := range query.Columns {
+					if c.EmbedTable != nil && len(omittedColumns(options, req.Catalog.DefaultSchema, c.EmbedTable)) > 0 {
+						return nil, fmt.Errorf("query %s: sqlc.embed(%s) selects columns listed in omit_columns", query.Name, c.EmbedTable.Name)
+					}
+					embed := newGoEmbed(c.EmbedTable, structs, req.Catalog.DefaultSchema)
+					if embed != nil {
+						embed.nullable = nullableEmbed(options, sqlpkg, query, c.EmbedTable)
+						embed.validFlag = validFlagEmbed(options, sqlpkg, query, c.EmbedTable)
+					}
 					columns = append(columns, goColumn{
 						id:     i,
 						Column: c,
-						embed:  newGoEmbed(c.EmbedTable, structs, req.Catalog.DefaultSchema),
+						embed:  embed,
 					})
 				}
 				var err error
@@ -340,10 +496,23 @@ func buildQueries(req *plugin.GenerateRequest, options *opts.Options, structs []
 				SQLDriver:   sqlpkg,
 				EmitPointer: options.EmitResultStructPointers,
 			}
+			if emit && options.EmitModelFromRow {
+				gq.RowModels = rowModels(structs, gs)
+			}
+		}
+
+		if keyset != nil {
+			if err := keyset.bind(gq.Ret); err != nil {
+				return nil, fmt.Errorf("query %s: %w", query.Name, err)
+			}
+		}
+		gq.Filter, err = queryFilter(req, options, query, gq.Ret, annotations)
+		if err != nil {
+			return nil, fmt.Errorf("query %s: %w", query.Name, err)
 		}
 
 		// Check if this query has nested configuration
-		for _, nestedConfig := range options.Nested.Queries {
+		for _, nestedConfig := range nestedQueries {
 			if nestedConfig.Query == gq.MethodName {
 				gq.HasNestedConfig = true
 				gq.GroupFunctionName = "Group" + gq.MethodName
@@ -365,7 +534,9 @@ func buildQueries(req *plugin.GenerateRequest, options *opts.Options, structs []
 			}
 		}
 
+		variants := softDeleteVariants(&gq, options)
 		qs = append(qs, gq)
+		qs = append(qs, variants...)
 	}
 	sort.Slice(qs, func(i, j int) bool { return qs[i].MethodName < qs[j].MethodName })
 	return qs, nil
@@ -383,6 +554,39 @@ func putOutColumns(query *plugin.Query) bool {
 	return found
 }
 
+// columnComment returns the comment of col, falling back to the comment of
+// the table column it was selected from, since sqlc only fills in comments on
+// catalog columns
+func columnComment(req *plugin.GenerateRequest, col *plugin.Column) string {
+	if col.Comment != "" || col.Table == nil {
+		return col.Comment
+	}
+	name := col.Name
+	if col.OriginalName != "" {
+		name = col.OriginalName
+	}
+	schemaName := col.Table.Schema
+	if schemaName == "" {
+		schemaName = req.Catalog.DefaultSchema
+	}
+	for _, schema := range req.Catalog.Schemas {
+		if schema.Name != schemaName {
+			continue
+		}
+		for _, table := range schema.Tables {
+			if table.Rel.Name != col.Table.Name {
+				continue
+			}
+			for _, c := range table.Columns {
+				if c.Name == name {
+					return c.Comment
+				}
+			}
+		}
+	}
+	return ""
+}
+
 // It's possible that this method will generate duplicate JSON tag values
 //
 //	Columns: count, count,   count_2
@@ -431,16 +635,26 @@ func columnsToStruct(req *plugin.GenerateRequest, options *opts.Options, name st
 		}
 		addExtraGoStructTags(tags, req, options, c.Column)
 		f := Field{
-			Name:   fieldName,
-			DBName: colName,
-			Tags:   tags,
-			Column: c.Column,
+			Name:    fieldName,
+			DBName:  colName,
+			Tags:    tags,
+			Comment: deprecatedComment(columnComment(req, c.Column)),
+			Column:  c.Column,
 		}
 		if c.embed == nil {
 			f.Type = goType(req, options, c.Column)
+			f.Sensitive = columnSensitive(req, options, c.Column, columnComment(req, c.Column))
+			if f.Sensitive {
+				addSensitiveStructTags(tags, options)
+			}
 		} else {
 			f.Type = c.embed.modelType
 			f.EmbedFields = c.embed.fields
+			if c.embed.nullable {
+				f.Type = "*" + f.Type
+				f.EmbedNullable = true
+			}
+			f.EmbedValid = c.embed.validFlag
 		}
 
 		gs.Fields = append(gs.Fields, f)
@@ -1,10 +1,14 @@
 package golang
 
 import (
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/sqlc-dev/plugin-sdk-go/metadata"
 	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
 )
 
 func TestPutOutColumns_ForZeroColumns(t *testing.T) {
@@ -76,3 +80,147 @@ func TestPutOutColumns_AlwaysTrueWhenQueryHasColumns(t *testing.T) {
 		t.Error("should be true when we have columns")
 	}
 }
+
+func TestBuildEnumsConfig(t *testing.T) {
+	req := &plugin.GenerateRequest{Catalog: &plugin.Catalog{
+		DefaultSchema: "public",
+		Schemas: []*plugin.Schema{{
+			Name:  "public",
+			Enums: []*plugin.Enum{{Name: "status", Vals: []string{"status_active", "status_pending", "status_"}}},
+		}},
+	}}
+	for _, tc := range []struct {
+		name   string
+		config *opts.EnumConfig
+		want   []string
+		err    string
+	}{
+		{"default", nil, []string{"StatusStatusActive", "StatusStatusPending", "StatusStatus"}, ""},
+		{"trim prefix", &opts.EnumConfig{Name: "status", TrimPrefix: "status_"}, []string{"StatusActive", "StatusPending", "StatusValue2"}, ""},
+		{"qualified", &opts.EnumConfig{Name: "public.status", TrimPrefix: "status_"}, []string{"StatusActive", "StatusPending", "StatusValue2"}, ""},
+		{"rename", &opts.EnumConfig{Name: "status", TrimPrefix: "status_", Rename: map[string]string{"status_": "StatusUnknown"}}, []string{"StatusActive", "StatusPending", "StatusUnknown"}, ""},
+		{"rename clash", &opts.EnumConfig{Name: "status", TrimPrefix: "status_", Rename: map[string]string{"status_pending": "StatusActive"}}, nil,
+			`value "status_pending" is renamed to StatusActive, the name of the constant of value "status_active"`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			options := &opts.Options{}
+			if tc.config != nil {
+				options.Enums = []*opts.EnumConfig{tc.config}
+			}
+			enums, err := buildEnums(req, options)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("buildEnums() error = %v, want %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			for _, c := range enums[0].Constants {
+				names = append(names, c.Name)
+			}
+			if strings.Join(names, " ") != strings.Join(tc.want, " ") {
+				t.Errorf("constants = %v, want %v", names, tc.want)
+			}
+		})
+	}
+}
+
+func TestColumnComment(t *testing.T) {
+	req := &plugin.GenerateRequest{Catalog: &plugin.Catalog{
+		DefaultSchema: "public",
+		Schemas: []*plugin.Schema{
+			{Name: "public", Tables: []*plugin.Table{{
+				Rel: &plugin.Identifier{Name: "users"},
+				Columns: []*plugin.Column{
+					{Name: "id"},
+					{Name: "email", Comment: "Login address"},
+				},
+			}}},
+			{Name: "audit", Tables: []*plugin.Table{{
+				Rel:     &plugin.Identifier{Schema: "audit", Name: "users"},
+				Columns: []*plugin.Column{{Name: "email", Comment: "Archived address"}},
+			}}},
+		},
+	}}
+	users := &plugin.Identifier{Name: "users"}
+	for _, tc := range []struct {
+		name string
+		col  *plugin.Column
+		want string
+	}{
+		{"own comment", &plugin.Column{Name: "email", Comment: "Query comment", Table: users}, "Query comment"},
+		{"table column", &plugin.Column{Name: "email", Table: users}, "Login address"},
+		{"aliased", &plugin.Column{Name: "contact", OriginalName: "email", Table: users}, "Login address"},
+		{"other schema", &plugin.Column{Name: "email", Table: &plugin.Identifier{Schema: "audit", Name: "users"}}, "Archived address"},
+		{"uncommented column", &plugin.Column{Name: "id", Table: users}, ""},
+		{"unknown column", &plugin.Column{Name: "name", Table: users}, ""},
+		{"expression", &plugin.Column{Name: "email"}, ""},
+	} {
+		if got := columnComment(req, tc.col); got != tc.want {
+			t.Errorf("%s: columnComment() = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestQueryName(t *testing.T) {
+	req := &plugin.GenerateRequest{PluginOptions: []byte(`{
+		"package": "db",
+		"rename": {"GetUserByEmail": "FindUserByEmail", "regex:^List(.*)$": "All$1", "user_id": "UserID"}
+	}`)}
+	options, err := opts.Parse(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]string{
+		"GetUserByEmail": "FindUserByEmail",
+		"ListUsers":      "AllUsers",
+		"CreateUser":     "CreateUser",
+	} {
+		if got := QueryName(name, options); got != want {
+			t.Errorf("QueryName(%q) = %s, want %s", name, got, want)
+		}
+	}
+}
+
+func TestQueryConstantName(t *testing.T) {
+	names := map[string]string{"ListUsers": "ListUsersSQL"}
+	for _, tc := range []struct {
+		options *opts.Options
+		name    string
+		want    string
+	}{
+		{&opts.Options{}, "GetUser", "getUser"},
+		{&opts.Options{QueryConstantSuffix: "Query"}, "GetUser", "getUserQuery"},
+		{&opts.Options{EmitExportedQueries: true}, "GetUser", "GetUser"},
+		{&opts.Options{EmitExportedQueries: true, QueryConstantSuffix: "Query"}, "GetUser", "GetUserQuery"},
+		{&opts.Options{QueryConstantSuffix: "Query", QueryConstantNames: names}, "ListUsers", "ListUsersSQL"},
+		{&opts.Options{QueryConstantNames: names}, "GetUser", "getUser"},
+	} {
+		if got := queryConstantName(tc.name, tc.name, tc.options); got != tc.want {
+			t.Errorf("queryConstantName(%s) with suffix %q and exported %t = %s, want %s", tc.name, tc.options.QueryConstantSuffix, tc.options.EmitExportedQueries, got, tc.want)
+		}
+	}
+}
+
+func TestQuerySourceName(t *testing.T) {
+	for _, tc := range []struct {
+		mirror   bool
+		filename string
+		want     string
+	}{
+		{false, "queries/users/get.sql", "get.sql"},
+		{true, "queries/users/get.sql", "queries/users/get.sql"},
+		{true, "get.sql", "get.sql"},
+		{true, "queries/../get.sql", "get.sql"},
+		{true, "../shared/get.sql", "get.sql"},
+		{true, "/abs/queries/get.sql", "get.sql"},
+	} {
+		options := &opts.Options{MirrorQueryDirectories: tc.mirror}
+		if got, want := querySourceName(options, tc.filename), filepath.FromSlash(tc.want); got != want {
+			t.Errorf("querySourceName(%q) with mirror_query_directories %t = %s, want %s", tc.filename, tc.mirror, got, want)
+		}
+	}
+}
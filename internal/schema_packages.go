@@ -0,0 +1,179 @@
+package golang
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+// schemaPackage returns the package the models of schema are generated into
+// by schema_packages, or an empty string when they belong to the models
+// package
+func schemaPackage(options *opts.Options, defaultSchema, schema string) string {
+	if !options.SchemaPackages || schema == defaultSchema {
+		return ""
+	}
+	name := strings.ToLower(EnumReplace(schema))
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "schema_" + name
+	}
+	return name
+}
+
+// schemaPackageImportPath returns the import path of a schema package, which
+// is generated into the directory of the same name below out
+func schemaPackageImportPath(options *opts.Options, name string) string {
+	return path.Join(options.SchemaPackagesImportPath, name)
+}
+
+// schemaModelsFileName returns the file the models of a schema package are
+// written to
+func schemaModelsFileName(name string) string {
+	return path.Join(name, "models.go")
+}
+
+// schemaModels holds the enums and structs generated into a schema package
+type schemaModels struct {
+	Package string
+	Enums   []Enum
+	Structs []Struct
+}
+
+// splitSchemaPackages separates the enums and structs of schema packages from
+// those of the models package. Schema packages are returned in the order
+// they are first seen.
+func splitSchemaPackages(options *opts.Options, enums []Enum, structs []Struct) ([]Enum, []Struct, []*schemaModels) {
+	var mainEnums []Enum
+	var mainStructs []Struct
+	var pkgs []*schemaModels
+	byName := map[string]*schemaModels{}
+	get := func(name string) *schemaModels {
+		if m, ok := byName[name]; ok {
+			return m
+		}
+		m := &schemaModels{Package: name}
+		byName[name] = m
+		pkgs = append(pkgs, m)
+		return m
+	}
+
+	for _, e := range enums {
+		if e.Package == "" {
+			mainEnums = append(mainEnums, e)
+			continue
+		}
+		m := get(e.Package)
+		m.Enums = append(m.Enums, e)
+	}
+	for _, s := range structs {
+		if s.Package == options.OutputModelsPackage {
+			mainStructs = append(mainStructs, s)
+			continue
+		}
+		m := get(s.Package)
+		m.Structs = append(m.Structs, s)
+	}
+	return mainEnums, mainStructs, pkgs
+}
+
+// validateSchemaPackages reports import cycles between the models package
+// and schema packages, which appear when models refer to each other across
+// schemas. Without a separate models package, the models of the default
+// schema live in the package of the queries, which imports every schema
+// package, so schema packages cannot refer to them at all.
+func validateSchemaPackages(options *opts.Options, enums []Enum, structs []Struct, pkgs []*schemaModels) error {
+	if len(pkgs) == 0 {
+		return nil
+	}
+
+	// The default schema is identified by the empty package name
+	mainNames := map[string]struct{}{}
+	for _, e := range enums {
+		mainNames[e.Name] = struct{}{}
+		mainNames["Null"+e.Name] = struct{}{}
+	}
+	for _, s := range structs {
+		mainNames[s.Name] = struct{}{}
+	}
+	refersTo := func(typ string) string {
+		typ = trimSliceAndPointerPrefix(typ)
+		if options.ModelsPackageImportPath != "" && strings.HasPrefix(typ, options.OutputModelsPackage+".") {
+			return ""
+		}
+		if _, ok := mainNames[typ]; ok && options.ModelsPackageImportPath == "" {
+			return ""
+		}
+		for _, m := range pkgs {
+			if strings.HasPrefix(typ, m.Package+".") {
+				return m.Package
+			}
+		}
+		return "-"
+	}
+
+	deps := map[string]map[string]bool{"": {}}
+	for _, m := range pkgs {
+		deps[m.Package] = map[string]bool{}
+		if options.ModelsPackageImportPath == "" {
+			deps[""][m.Package] = true
+		}
+		for _, s := range m.Structs {
+			for _, f := range s.Fields {
+				if to := refersTo(f.Type); to != "-" && to != m.Package {
+					deps[m.Package][to] = true
+				}
+			}
+		}
+	}
+	for _, s := range structs {
+		for _, f := range s.Fields {
+			if to := refersTo(f.Type); to != "-" && to != "" {
+				deps[""][to] = true
+			}
+		}
+	}
+
+	name := func(pkg string) string {
+		if pkg == "" {
+			if options.OutputModelsPackage != "" {
+				return options.OutputModelsPackage
+			}
+			return options.Package
+		}
+		return pkg
+	}
+	// Look for a cycle from every package, in a stable order
+	order := []string{""}
+	for _, m := range pkgs {
+		order = append(order, m.Package)
+	}
+	var visit func(pkg string, path []string) []string
+	visit = func(pkg string, path []string) []string {
+		for i, p := range path {
+			if p == pkg {
+				return append(path[i:], pkg)
+			}
+		}
+		path = append(path, pkg)
+		for _, to := range order {
+			if deps[pkg][to] {
+				if cycle := visit(to, path); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		return nil
+	}
+	for _, pkg := range order {
+		if cycle := visit(pkg, nil); cycle != nil {
+			names := make([]string, len(cycle))
+			for i, p := range cycle {
+				names[i] = name(p)
+			}
+			return fmt.Errorf("schema_packages: models would form an import cycle: %s", strings.Join(names, " -> "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package golang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+func TestSchemaPackage(t *testing.T) {
+	options := &opts.Options{SchemaPackages: true}
+	for schema, want := range map[string]string{
+		"public":   "",
+		"billing":  "billing",
+		"Billing":  "billing",
+		"audit-v2": "audit_v2",
+		"2024":     "schema_2024",
+	} {
+		if got := schemaPackage(options, "public", schema); got != want {
+			t.Errorf("schemaPackage(%q) = %q, want %q", schema, got, want)
+		}
+	}
+	if got := schemaPackage(&opts.Options{}, "public", "billing"); got != "" {
+		t.Errorf("schemaPackage without schema_packages = %q, want the models package", got)
+	}
+}
+
+func TestValidateSchemaPackages(t *testing.T) {
+	billing := func(fieldType string) []*schemaModels {
+		return []*schemaModels{{Package: "billing", Structs: []Struct{
+			{Name: "Invoice", Package: "billing", Fields: []Field{{Name: "Owner", Type: fieldType}}},
+		}}}
+	}
+	user := []Struct{{Name: "User", Fields: []Field{{Name: "Invoices", Type: "[]billing.Invoice"}}}}
+	models := &opts.Options{Package: "db", OutputModelsPackage: "models", ModelsPackageImportPath: "example.com/models"}
+
+	for _, tc := range []struct {
+		name    string
+		options *opts.Options
+		structs []Struct
+		pkgs    []*schemaModels
+		err     string
+	}{
+		{"independent", &opts.Options{Package: "db"}, user, billing("int64"), ""},
+		{"models package", models, nil, billing("*models.User"), ""},
+		{"queries package", &opts.Options{Package: "db"}, user, billing("*User"), "db -> billing -> db"},
+		{"models cycle", models, user, billing("*models.User"), "models -> billing -> models"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateSchemaPackages(tc.options, nil, tc.structs, tc.pkgs)
+			if tc.err == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("validateSchemaPackages() error = %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
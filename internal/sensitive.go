@@ -0,0 +1,108 @@
+package golang
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+	"github.com/sqlc-dev/plugin-sdk-go/sdk"
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+// sensitiveMarker is the column comment line flagging a column as sensitive,
+// optionally followed by a reason, e.g. "sensitive: hashed password"
+const sensitiveMarker = "sensitive"
+
+// slogGoVersion is the minor version of the first Go release with log/slog,
+// which the LogValue methods of structs with sensitive fields use
+const slogGoVersion = 21
+
+// sensitiveComment reports whether a line of a column comment is the
+// sensitive marker
+func sensitiveComment(comment string) bool {
+	for _, line := range strings.Split(comment, "\n") {
+		key, _, _ := strings.Cut(strings.TrimSpace(line), ":")
+		if strings.EqualFold(strings.TrimSpace(key), sensitiveMarker) {
+			return true
+		}
+	}
+	return false
+}
+
+// columnSensitive reports whether the values of col are redacted by the
+// String and LogValue methods of the structs holding them, because an
+// override or the comment of the column flags it as sensitive
+func columnSensitive(req *plugin.GenerateRequest, options *opts.Options, col *plugin.Column, comment string) bool {
+	if sensitiveComment(comment) {
+		return true
+	}
+	cname := col.Name
+	if col.OriginalName != "" {
+		cname = col.OriginalName
+	}
+	for _, override := range options.Overrides {
+		if override.Sensitive && override.IsPattern() && overridePatternMatches(&override, col, req.Catalog.DefaultSchema) {
+			return true
+		}
+		if !override.Sensitive || override.ShimOverride.Column == "" {
+			continue
+		}
+		if override.Matches(col.Table, req.Catalog.DefaultSchema) && sdk.MatchString(override.ShimOverride.ColumnName, cname) {
+			return true
+		}
+	}
+	return false
+}
+
+// addSensitiveStructTags adds the sensitive_struct_tag tags, e.g. log:"-", to
+// the tags of a sensitive field. They replace the tags of the same keys.
+func addSensitiveStructTags(tags map[string]string, options *opts.Options) {
+	for k, v := range options.SensitiveStructTags {
+		tags[k] = v
+	}
+}
+
+// HasSensitiveFields reports whether String and LogValue methods redacting
+// the sensitive fields are generated for the struct
+func (s Struct) HasSensitiveFields() bool {
+	return slices.ContainsFunc(s.Fields, func(f Field) bool { return f.Sensitive })
+}
+
+// usesRedaction reports whether any of the structs, or of the row and params
+// structs declared for the queries, has redacting methods
+func usesRedaction(structs []Struct, queries []Query) bool {
+	for _, s := range structs {
+		if s.HasSensitiveFields() {
+			return true
+		}
+	}
+	for _, q := range queries {
+		if q.VariantOf != "" {
+			continue
+		}
+		if q.Ret.EmitStruct() && q.Ret.Struct.HasSensitiveFields() {
+			return true
+		}
+		if q.Arg.EmitStruct() && q.Arg.Struct.HasSensitiveFields() {
+			return true
+		}
+	}
+	return false
+}
+
+// validateRedaction rejects structs with sensitive fields whose fields would
+// collide with the redacting methods
+func validateRedaction(structs []Struct) error {
+	for _, s := range structs {
+		if !s.HasSensitiveFields() {
+			continue
+		}
+		for _, f := range s.Fields {
+			if f.Name == "String" || f.Name == "LogValue" {
+				return fmt.Errorf("struct %s has sensitive fields and a field named %s, which conflicts with its redacting method", s.Name, f.Name)
+			}
+		}
+	}
+	return nil
+}
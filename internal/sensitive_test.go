@@ -0,0 +1,35 @@
+package golang
+
+import "testing"
+
+func TestSensitiveComment(t *testing.T) {
+	for comment, want := range map[string]bool{
+		"sensitive":                      true,
+		"sensitive: hashed password":     true,
+		"The hash\n  Sensitive : bcrypt": true,
+		"":                               false,
+		"not sensitive":                  false,
+		"sensitivity: low":               false,
+	} {
+		if got := sensitiveComment(comment); got != want {
+			t.Errorf("sensitiveComment(%q) = %t, want %t", comment, got, want)
+		}
+	}
+}
+
+func TestValidateRedaction(t *testing.T) {
+	for _, tc := range []struct {
+		fields []Field
+		err    bool
+	}{
+		{[]Field{{Name: "Email", Sensitive: true}, {Name: "Name"}}, false},
+		{[]Field{{Name: "Email", Sensitive: true}, {Name: "String"}}, true},
+		{[]Field{{Name: "LogValue", Sensitive: true}}, true},
+		{[]Field{{Name: "String"}, {Name: "LogValue"}}, false},
+	} {
+		err := validateRedaction([]Struct{{Name: "User", Fields: tc.fields}})
+		if (err != nil) != tc.err {
+			t.Errorf("validateRedaction(%+v) error = %v, want an error %t", tc.fields, err, tc.err)
+		}
+	}
+}
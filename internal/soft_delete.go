@@ -0,0 +1,61 @@
+package golang
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sqlc-dev/plugin-sdk-go/metadata"
+	"github.com/sqlc-dev/plugin-sdk-go/sdk"
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+// softDeleteFilter matches the "deleted_at IS NULL" conditions of the
+// soft_delete_column, optionally qualified by a table name
+func softDeleteFilter(column string) *regexp.Regexp {
+	return regexp.MustCompile(`(?i)\b((?:\w+\.)?` + regexp.QuoteMeta(column) + `)\s+IS\s+NULL\b`)
+}
+
+// softDeleteVariants documents that a query filters out the soft-deleted rows
+// and, with emit_soft_delete_variants, returns its WithDeleted variant, in
+// which the filter is always true, and its OnlyDeleted variant, in which it is
+// inverted
+func softDeleteVariants(gq *Query, options *opts.Options) []Query {
+	if options.SoftDeleteColumn == "" || gq.Cmd == metadata.CmdCopyFrom || strings.HasPrefix(gq.Cmd, ":batch") {
+		return nil
+	}
+	filter := softDeleteFilter(options.SoftDeleteColumn)
+	if !filter.MatchString(gq.SQL) {
+		return nil
+	}
+	gq.Comments = append(gq.Comments, fmt.Sprintf(" %s filters out the rows with %s set.", gq.MethodName, options.SoftDeleteColumn))
+	if !options.EmitSoftDeleteVariants {
+		return nil
+	}
+	return []Query{
+		gq.variant("WithDeleted", filter.ReplaceAllString(gq.SQL, "TRUE"),
+			fmt.Sprintf(" %sWithDeleted runs %s including the rows with %s set.", gq.MethodName, gq.MethodName, options.SoftDeleteColumn)),
+		gq.variant("OnlyDeleted", filter.ReplaceAllString(gq.SQL, "$1 IS NOT NULL"),
+			fmt.Sprintf(" %sOnlyDeleted runs %s for the rows with %s set only.", gq.MethodName, gq.MethodName, options.SoftDeleteColumn)),
+	}
+}
+
+// variant returns a copy of the query running sql, named after the query with
+// suffix. The variant declares no struct, it shares those of the query.
+func (q Query) variant(suffix, sql, comment string) Query {
+	v := q
+	v.MethodName += suffix
+	v.ConstantName += suffix
+	v.FieldName = sdk.LowerTitle(v.MethodName) + "Stmt"
+	v.SQL = sql
+	v.Comments = []string{comment}
+	v.VariantOf = q.MethodName
+	v.BulkSQL = ""
+	v.UpsertSQL = ""
+	v.Keyset = nil
+	v.Paged = nil
+	v.Derived = nil
+	v.Filter = nil
+	v.HasNestedConfig = false
+	return v
+}
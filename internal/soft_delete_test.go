@@ -0,0 +1,50 @@
+package golang
+
+import (
+	"testing"
+
+	"github.com/sqlc-dev/plugin-sdk-go/metadata"
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+func TestSoftDeleteVariants(t *testing.T) {
+	newQuery := func() Query {
+		return Query{
+			Cmd:          metadata.CmdMany,
+			MethodName:   "ListBooks",
+			ConstantName: "listBooks",
+			FieldName:    "listBooksStmt",
+			SQL:          "SELECT * FROM books b WHERE b.author_id = $1 AND b.deleted_at is null",
+		}
+	}
+	options := &opts.Options{SoftDeleteColumn: "deleted_at", EmitSoftDeleteVariants: true}
+
+	q := newQuery()
+	variants := softDeleteVariants(&q, options)
+	if len(q.Comments) != 1 || q.Comments[0] != " ListBooks filters out the rows with deleted_at set." {
+		t.Errorf("softDeleteVariants() comments = %q", q.Comments)
+	}
+	want := []struct{ name, field, sql string }{
+		{"ListBooksWithDeleted", "listBooksWithDeletedStmt", "SELECT * FROM books b WHERE b.author_id = $1 AND TRUE"},
+		{"ListBooksOnlyDeleted", "listBooksOnlyDeletedStmt", "SELECT * FROM books b WHERE b.author_id = $1 AND b.deleted_at IS NOT NULL"},
+	}
+	if len(variants) != len(want) {
+		t.Fatalf("softDeleteVariants() = %d variants, want %d", len(variants), len(want))
+	}
+	for i, w := range want {
+		v := variants[i]
+		if v.MethodName != w.name || v.FieldName != w.field || v.SQL != w.sql || v.VariantOf != "ListBooks" {
+			t.Errorf("variant %d = %s %s %q of %s, want %s %s %q of ListBooks", i, v.MethodName, v.FieldName, v.SQL, v.VariantOf, w.name, w.field, w.sql)
+		}
+	}
+
+	q = newQuery()
+	if variants := softDeleteVariants(&q, &opts.Options{SoftDeleteColumn: "deleted_at"}); len(variants) != 0 || len(q.Comments) != 1 {
+		t.Errorf("softDeleteVariants() without emit_soft_delete_variants = %d variants, comments %q", len(variants), q.Comments)
+	}
+	q = newQuery()
+	q.SQL = "SELECT * FROM books WHERE deleted_at IS NOT NULL"
+	if variants := softDeleteVariants(&q, options); len(variants) != 0 || len(q.Comments) != 0 {
+		t.Errorf("softDeleteVariants() of an unfiltered query = %d variants, comments %q", len(variants), q.Comments)
+	}
+}
@@ -0,0 +1,70 @@
+package golang
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+// queryNamePattern matches the comment naming a query, e.g.
+// "-- name: GetAuthor :one"
+var queryNamePattern = regexp.MustCompile(`^\s*(?:--|/\*|#)\s*name:\s*(\S+)`)
+
+// sourceLocator finds the SQL files and lines defining the queries, for the
+// source comments of emit_sql_source_location
+type sourceLocator struct {
+	queries []string            // queries paths of the sqlc config
+	files   map[string][]string // lines of the files read so far, nil if unreadable
+}
+
+func newSourceLocator(req *plugin.GenerateRequest) *sourceLocator {
+	l := &sourceLocator{files: map[string][]string{}}
+	if req.Settings != nil {
+		for _, p := range req.Settings.Queries {
+			l.queries = append(l.queries, opts.SlashPath(p))
+		}
+	}
+	return l
+}
+
+// location returns the path of the SQL file defining the query, relative to
+// the sqlc config, followed by the line of its name comment. The line is left
+// out when the file cannot be read, as when the plugin runs as WASM, and only
+// the file name is known when the config has several queries paths.
+func (l *sourceLocator) location(query *plugin.Query) string {
+	var candidates []string
+	for _, p := range l.queries {
+		if path.Base(p) == query.Filename {
+			candidates = append(candidates, p)
+		} else {
+			candidates = append(candidates, path.Join(p, query.Filename))
+		}
+	}
+	for _, candidate := range candidates {
+		for n, line := range l.lines(candidate) {
+			if m := queryNamePattern.FindStringSubmatch(line); m != nil && m[1] == query.Name {
+				return fmt.Sprintf("%s:%d", candidate, n+1)
+			}
+		}
+	}
+	if len(candidates) == 1 {
+		return candidates[0]
+	}
+	return query.Filename
+}
+
+func (l *sourceLocator) lines(name string) []string {
+	lines, ok := l.files[name]
+	if !ok {
+		if contents, err := os.ReadFile(name); err == nil {
+			lines = strings.Split(string(contents), "\n")
+		}
+		l.files[name] = lines
+	}
+	return lines
+}
@@ -0,0 +1,39 @@
+package golang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+func TestSourceLocation(t *testing.T) {
+	dir := t.TempDir()
+	sql := "-- Authors\n\n-- name: GetAuthor :one\nSELECT * FROM authors WHERE id = $1;\n\n/* name: ListAuthors :many */\nSELECT * FROM authors;\n"
+	if err := os.WriteFile(filepath.Join(dir, "authors.sql"), []byte(sql), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	file := opts.SlashPath(filepath.Join(dir, "authors.sql"))
+
+	for _, tc := range []struct {
+		name    string
+		queries []string
+		query   *plugin.Query
+		want    string
+	}{
+		{"directory", []string{dir}, &plugin.Query{Name: "GetAuthor", Filename: "authors.sql"}, file + ":3"},
+		{"file", []string{file}, &plugin.Query{Name: "ListAuthors", Filename: "authors.sql"}, file + ":6"},
+		{"missing query", []string{dir}, &plugin.Query{Name: "DeleteAuthor", Filename: "authors.sql"}, file},
+		{"unreadable", []string{"queries"}, &plugin.Query{Name: "GetBook", Filename: "books.sql"}, "queries/books.sql"},
+		{"several paths", []string{"queries", "more"}, &plugin.Query{Name: "GetBook", Filename: "books.sql"}, "books.sql"},
+		{"several paths found", []string{"queries", dir}, &plugin.Query{Name: "GetAuthor", Filename: "authors.sql"}, file + ":3"},
+	} {
+		l := newSourceLocator(&plugin.GenerateRequest{Settings: &plugin.Settings{Queries: tc.queries}})
+		if got := l.location(tc.query); got != tc.want {
+			t.Errorf("%s: location() = %s, want %s", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package golang
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sqlc-dev/plugin-sdk-go/metadata"
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+// sqliteReturningMinor is the minor version of the first SQLite 3 release
+// with RETURNING clauses
+const sqliteReturningMinor = 35
+
+// returningPattern matches the RETURNING clause of a statement
+var returningPattern = regexp.MustCompile(`(?i)\bRETURNING\b`)
+
+// sqliteReturning reports whether the SQLite release named by
+// sqlite_min_version supports RETURNING clauses
+func sqliteReturning(options *opts.Options) bool {
+	parts := strings.Split(options.SqliteMinVersion, ".")
+	v, err := strconv.Atoi(parts[1])
+	return err == nil && v >= sqliteReturningMinor
+}
+
+// sqliteReturningSQL checks the query against the SQLite release named by
+// sqlite_min_version. Queries using RETURNING are rejected before 3.35, and
+// from 3.35 on the :execlastid inserts return the rowid of the inserted row
+// with a RETURNING clause, which is read with the statement instead of
+// through LastInsertId. It returns the SQL of the query and whether the
+// clause was added.
+func sqliteReturningSQL(req *plugin.GenerateRequest, options *opts.Options, query *plugin.Query) (string, bool, error) {
+	if options.SqliteMinVersion == "" || req.Settings.GetEngine() != "sqlite" {
+		return query.Text, false, nil
+	}
+	returning := returningPattern.MatchString(query.Text)
+	if !sqliteReturning(options) {
+		if returning {
+			return "", false, fmt.Errorf("RETURNING requires SQLite 3.%d, but sqlite_min_version is %s", sqliteReturningMinor, options.SqliteMinVersion)
+		}
+		return query.Text, false, nil
+	}
+	if query.Cmd != metadata.CmdExecLastId || query.InsertIntoTable == nil || returning {
+		return query.Text, false, nil
+	}
+	return strings.TrimRight(query.Text, "; \t\r\n") + " RETURNING rowid", true, nil
+}
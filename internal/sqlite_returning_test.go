@@ -0,0 +1,44 @@
+package golang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+func TestSqliteReturningSQL(t *testing.T) {
+	authors := &plugin.Identifier{Name: "authors"}
+	insert := "INSERT INTO authors (name) VALUES (?);"
+	for _, tc := range []struct {
+		name     string
+		engine   string
+		version  string
+		query    *plugin.Query
+		want     string
+		appended bool
+		err      string
+	}{
+		{"lastid", "sqlite", "3.35.0", &plugin.Query{Cmd: ":execlastid", Text: insert, InsertIntoTable: authors}, "INSERT INTO authors (name) VALUES (?) RETURNING rowid", true, ""},
+		{"lastid before 3.35", "sqlite", "3.34", &plugin.Query{Cmd: ":execlastid", Text: insert, InsertIntoTable: authors}, insert, false, ""},
+		{"no version", "sqlite", "", &plugin.Query{Cmd: ":execlastid", Text: insert, InsertIntoTable: authors}, insert, false, ""},
+		{"mysql", "mysql", "3.35", &plugin.Query{Cmd: ":execlastid", Text: insert, InsertIntoTable: authors}, insert, false, ""},
+		{"exec", "sqlite", "3.35", &plugin.Query{Cmd: ":exec", Text: insert, InsertIntoTable: authors}, insert, false, ""},
+		{"returning", "sqlite", "3.35", &plugin.Query{Cmd: ":one", Text: "INSERT INTO authors (name) VALUES (?) RETURNING id", InsertIntoTable: authors}, "INSERT INTO authors (name) VALUES (?) RETURNING id", false, ""},
+		{"returning before 3.35", "sqlite", "3.31", &plugin.Query{Cmd: ":one", Text: "DELETE FROM authors returning id"}, "", false, "RETURNING requires SQLite 3.35"},
+	} {
+		req := &plugin.GenerateRequest{Settings: &plugin.Settings{Engine: tc.engine}}
+		sql, appended, err := sqliteReturningSQL(req, &opts.Options{SqliteMinVersion: tc.version}, tc.query)
+		if tc.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("%s: sqliteReturningSQL() error = %v, want %q", tc.name, err, tc.err)
+			}
+			continue
+		}
+		if err != nil || sql != tc.want || appended != tc.appended {
+			t.Errorf("%s: sqliteReturningSQL() = %q, %t, %v, want %q, %t", tc.name, sql, appended, err, tc.want, tc.appended)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package golang
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/sqlc-dev/plugin-sdk-go/metadata"
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+// pgxOnlyCommands are the commands of the queries left out of the package of
+// stdlib_package, database/sql has no way to run them against PostgreSQL
+var pgxOnlyCommands = map[string]struct{}{
+	metadata.CmdCopyFrom:  {},
+	metadata.CmdBatchExec: {},
+	metadata.CmdBatchMany: {},
+	metadata.CmdBatchOne:  {},
+}
+
+// generateStdlibPackage generates the database/sql variant of the pgx/v5
+// package for stdlib_package, as if sqlc had been run a second time with
+// another sql_package. The variant keeps the pgx/v5 types, which database/sql
+// sends and scans through their Value and Scan methods, so that both packages
+// share the models of output_models_package. Its files are written below the
+// out directory of stdlib_package.
+func generateStdlibPackage(req *plugin.GenerateRequest, options *opts.Options) ([]*plugin.File, error) {
+	p := options.StdlibPackage
+	var queries []*plugin.Query
+	for _, q := range req.Queries {
+		if _, ok := pgxOnlyCommands[q.Cmd]; !ok {
+			queries = append(queries, q)
+		}
+	}
+	pkgReq := &plugin.GenerateRequest{
+		Settings:      req.Settings,
+		Catalog:       req.Catalog,
+		Queries:       queries,
+		SqlcVersion:   req.SqlcVersion,
+		PluginOptions: req.PluginOptions,
+		GlobalOptions: req.GlobalOptions,
+	}
+
+	pkgOptions := *options
+	pkgOptions.Package = p.Package
+	useSQLPackage(&pkgOptions, opts.SQLPackageStandard)
+	pkgOptions.SkipFiles = map[string]struct{}{}
+	for name := range options.SkipFiles {
+		if rel, ok := strings.CutPrefix(name, p.Out+"/"); ok {
+			pkgOptions.SkipFiles[rel] = struct{}{}
+		}
+	}
+
+	pkgResp, err := generatePackage(pkgReq, &pkgOptions)
+	if err != nil {
+		return nil, fmt.Errorf("stdlib_package: %w", err)
+	}
+	var files []*plugin.File
+	for _, f := range pkgResp.Files {
+		if isModelsPackageFile(options, f.Name) {
+			continue
+		}
+		files = append(files, &plugin.File{
+			Name:     path.Join(p.Out, f.Name),
+			Contents: f.Contents,
+		})
+	}
+	return files, nil
+}
@@ -15,6 +15,9 @@ type Struct struct {
 	Package string
 	Fields  []Field
 	Comment string
+	// Positional is set when values are matched to fields by position, as
+	// for composite types, so fields must keep their database order
+	Positional bool
 }
 
 func (s Struct) Type() string {
@@ -28,6 +31,15 @@ func StructName(name string, options *opts.Options) string {
 	if rename := options.Rename[name]; rename != "" {
 		return rename
 	}
+	for _, p := range options.RenamePatterns {
+		if p.Pattern.MatchString(name) {
+			name = p.Pattern.ReplaceAllString(name, p.Replacement)
+			if rename := options.Rename[name]; rename != "" {
+				return rename
+			}
+			break
+		}
+	}
 	out := ""
 	name = strings.Map(func(r rune) rune {
 		if unicode.IsLetter(r) {
@@ -0,0 +1,44 @@
+package golang
+
+import (
+	"testing"
+
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+func TestStructNameRenamePatterns(t *testing.T) {
+	req := &plugin.GenerateRequest{PluginOptions: []byte(`{
+		"package": "db",
+		"rename": {
+			"regex:^tbl_(.*)$": "$1",
+			"regex:_ts$": "_at",
+			"user": "Account",
+			"api_key": "APIKey"
+		}
+	}`)}
+	options, err := opts.Parse(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]string{
+		"tbl_order_item": "OrderItem",
+		"tbl_user":       "Account",
+		"created_ts":     "CreatedAt",
+		"api_key":        "APIKey",
+		"user":           "Account",
+		"order_ts_total": "OrderTsTotal",
+	} {
+		if got := StructName(name, options); got != want {
+			t.Errorf("StructName(%q) = %s, want %s", name, got, want)
+		}
+	}
+}
+
+func TestRenamePatternsInvalid(t *testing.T) {
+	req := &plugin.GenerateRequest{PluginOptions: []byte(`{"package": "db", "rename": {"regex:(": "x"}}`)}
+	if _, err := opts.Parse(req); err == nil {
+		t.Error("opts.Parse() accepted an invalid rename pattern")
+	}
+}
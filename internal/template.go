@@ -2,8 +2,65 @@ package golang
 
 import (
 	"embed"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
 )
 
 //go:embed templates/*
 //go:embed templates/*/*
-var templates embed.FS
\ No newline at end of file
+var templates embed.FS
+
+// templateFeatures are the optional files of a generation whose templates
+// are only parsed when the files are generated
+type templateFeatures struct {
+	Interface  bool
+	CopyFrom   bool
+	Batch      bool
+	ReadWrite  bool
+	Background bool
+	Explain    bool
+	Retry      bool
+	Nested     bool
+}
+
+// templateFiles lists the template files a generation needs. Templates of
+// other drivers and of unused features are not parsed, which matters for the
+// cold start of the WASM plugin paying for parsing on every run.
+func templateFiles(driver opts.SQLDriver, features templateFeatures) []string {
+	dir := "templates/stdlib/"
+	if driver.IsPGX() {
+		dir = "templates/pgx/"
+	}
+	files := []string{
+		"templates/template.tmpl",
+		"templates/nested/nestedQuery.tmpl",
+		dir + "dbCode.tmpl",
+		dir + "queryCode.tmpl",
+	}
+	for _, f := range []struct {
+		used bool
+		name string
+	}{
+		{features.Interface, "interfaceCode.tmpl"},
+		{features.Batch, "batchCode.tmpl"},
+		{features.ReadWrite, "readWriteCode.tmpl"},
+		{features.Background, "backgroundCode.tmpl"},
+		{features.Explain, "explainCode.tmpl"},
+		{features.Retry, "retryCode.tmpl"},
+	} {
+		if f.used {
+			files = append(files, dir+f.name)
+		}
+	}
+	if features.CopyFrom {
+		if driver.IsGoSQLDriverMySQL() {
+			files = append(files, "templates/go-sql-driver-mysql/copyfromCopy.tmpl")
+		} else {
+			files = append(files, dir+"copyfromCopy.tmpl")
+		}
+	}
+	if features.Nested {
+		files = append(files, "templates/nested/nestedCore.tmpl", "templates/nested/nestedUtils.tmpl", "templates/nested/nestedGrouper.tmpl")
+	}
+	return files
+}
@@ -33,6 +33,13 @@ func upperTitle(s string) string {
 	return strings.ToUpper(s[:1]) + s[1:]
 }
 
+// trimPackage removes the pkg qualifier from typ, keeping any leading slice
+// or pointer markers (e.g. "*models.Status" -> "*Status")
+func trimPackage(typ, pkg string) string {
+	prefix := typ[:len(typ)-len(strings.TrimLeft(typ, "[]*"))]
+	return prefix + strings.TrimPrefix(typ[len(prefix):], pkg+".")
+}
+
 func add(a, b int) int {
 	return a + b
 }
@@ -101,6 +108,60 @@ func getNullableType(fieldType string, modelsPackage string) string {
 	return fieldType
 }
 
+// getEmbedScanType returns the type of the variable an embed field is scanned
+// into. NOT NULL columns whose Go type has no nullable wrapper (time.Time,
+// uuid.UUID, ... when emitting pointers for null types) are scanned through a
+// pointer, since the whole embed can still be NULL in a LEFT JOIN
+func getEmbedScanType(field Field, modelsPackage string) string {
+	if scansThroughPointer(field, modelsPackage) {
+		return "*" + field.Type
+	}
+	return getNullableType(field.Type, modelsPackage)
+}
+
+// getEmbedValidCheck returns the expression suffix used to check whether a
+// variable declared with getEmbedScanType holds a non-NULL value
+func getEmbedValidCheck(field Field, modelsPackage string) string {
+	if scansThroughPointer(field, modelsPackage) {
+		return " != nil"
+	}
+	return ".Valid"
+}
+
+// getEmbedValue returns the expression that extracts the field value from a
+// variable declared with getEmbedScanType
+func getEmbedValue(varName string, field Field, modelsPackage string) string {
+	if scansThroughPointer(field, modelsPackage) {
+		return "*" + varName
+	}
+	if valueField := getNullableValueField(field.Type, modelsPackage); valueField != "" {
+		return varName + "." + valueField
+	}
+	return varName
+}
+
+func scansThroughPointer(field Field, modelsPackage string) bool {
+	if field.Column == nil || !field.Column.NotNull {
+		return false
+	}
+	if getNullableType(field.Type, modelsPackage) != field.Type {
+		return false
+	}
+	return !isNullWrapperType(field.Type) && !isNilableGoType(field.Type)
+}
+
+// isNullWrapperType reports whether fieldType is a struct wrapper with a Valid
+// flag, such as pgtype.UUID or sql.NullString
+func isNullWrapperType(fieldType string) bool {
+	if strings.HasPrefix(fieldType, "pgtype.") {
+		return true
+	}
+	if i := strings.LastIndex(fieldType, "."); i >= 0 {
+		fieldType = fieldType[i+1:]
+	}
+	return strings.HasPrefix(fieldType, "Null")
+}
+
 // getNullableValueField returns the field name to extract the actual value from a nullable wrapper
 // For example: pgtype.Bool -> "Bool", pgtype.Int4 -> "Int32", entity.NullImageType -> "ImageType"
 // Returns empty string if the value can be used directly (already nullable types)
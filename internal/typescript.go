@@ -0,0 +1,229 @@
+package golang
+
+import (
+	"fmt"
+	"strings"
+)
+
+// typescriptScalars maps Go types to the TypeScript types of their JSON
+// encoding. The pgtype and uuid wrappers encode NULL as null.
+var typescriptScalars = map[string]string{
+	"bool":    "boolean",
+	"string":  "string",
+	"int":     "number",
+	"int8":    "number",
+	"int16":   "number",
+	"int32":   "number",
+	"int64":   "number",
+	"uint":    "number",
+	"uint8":   "number",
+	"uint16":  "number",
+	"uint32":  "number",
+	"uint64":  "number",
+	"float32": "number",
+	"float64": "number",
+	"[]byte":  "string",
+
+	"time.Time":       "string",
+	"uuid.UUID":       "string",
+	"uuid.NullUUID":   "string | null",
+	"json.RawMessage": "unknown",
+	"interface{}":     "unknown",
+	"any":             "unknown",
+
+	"pgtype.Bool":        "boolean | null",
+	"pgtype.Text":        "string | null",
+	"pgtype.Int2":        "number | null",
+	"pgtype.Int4":        "number | null",
+	"pgtype.Int8":        "number | null",
+	"pgtype.Float4":      "number | null",
+	"pgtype.Float8":      "number | null",
+	"pgtype.Numeric":     "number | null",
+	"pgtype.UUID":        "string | null",
+	"pgtype.Date":        "string | null",
+	"pgtype.Timestamp":   "string | null",
+	"pgtype.Timestamptz": "string | null",
+}
+
+// TypeScriptFile declares the types of the nested composites, the structs
+// they refer to and the enums
+type TypeScriptFile struct {
+	Enums      []Enum
+	Interfaces []TypeScriptInterface
+}
+
+type TypeScriptInterface struct {
+	Name    string
+	Comment string
+	Fields  []TypeScriptField
+}
+
+type TypeScriptField struct {
+	Name     string
+	Optional bool
+	Type     string
+}
+
+type typescriptBuilder struct {
+	structs    map[string]outputStruct
+	enums      map[string]struct{}
+	seen       map[string]struct{}
+	interfaces []TypeScriptInterface
+}
+
+// typ returns the TypeScript type of the JSON encoding of the Go type typ,
+// adding the interfaces of the structs it refers to
+func (b *typescriptBuilder) typ(typ string) string {
+	if t, ok := typescriptScalars[typ]; ok {
+		return t
+	}
+	if valueType, ok := jsonSchemaSQLNulls[typ]; ok {
+		return fmt.Sprintf("{ %s: %s; Valid: boolean }", strings.TrimPrefix(typ, "sql.Null"), b.typ(valueType))
+	}
+	switch {
+	case strings.HasPrefix(typ, "*"):
+		return b.typ(typ[1:]) + " | null"
+	case strings.HasPrefix(typ, "[]"):
+		// nil slices are encoded as null
+		elem := b.typ(typ[2:])
+		if strings.Contains(elem, " ") {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[] | null"
+	case strings.HasPrefix(typ, "map[string]"):
+		return "Record<string, " + b.typ(strings.TrimPrefix(typ, "map[string]")) + ">"
+	}
+
+	name := typ[strings.LastIndex(typ, ".")+1:]
+	if _, ok := b.enums[name]; ok {
+		return name
+	}
+	if s, ok := b.structs[name]; ok {
+		b.add(s)
+		return name
+	}
+	if enum := strings.TrimPrefix(name, "Null"); enum != name {
+		if _, ok := b.enums[enum]; ok {
+			return fmt.Sprintf("{ %s: %s; Valid: boolean }", enum, enum)
+		}
+	}
+	// Unknown types, such as the ones of overrides, accept any value
+	return "unknown"
+}
+
+// add declares the interface of a struct and the ones it refers to
+func (b *typescriptBuilder) add(s outputStruct) {
+	if _, ok := b.seen[s.Name]; ok {
+		return
+	}
+	b.seen[s.Name] = struct{}{}
+	i := TypeScriptInterface{Name: s.Name, Comment: s.Comment}
+	field := func(goName, typ string, tags map[string]string) {
+		name, omitEmpty, ok := jsonProperty(goName, tags)
+		if !ok {
+			return
+		}
+		i.Fields = append(i.Fields, TypeScriptField{Name: name, Optional: omitEmpty, Type: b.typ(typ)})
+	}
+	for _, f := range s.Fields {
+		field(f.Name, f.Type, f.Tags)
+	}
+	for _, n := range s.Nested {
+		field(n.FieldName, n.FieldType, n.FieldTags)
+	}
+	b.interfaces = append(b.interfaces, i)
+}
+
+// buildTypeScriptFile returns the declarations of the enums and of the nested
+// composites, along with the models and result structs they refer to
+func buildTypeScriptFile(enums []Enum, structs []Struct, queries []Query, nested []Nested) *TypeScriptFile {
+	b := &typescriptBuilder{
+		structs: map[string]outputStruct{},
+		enums:   map[string]struct{}{},
+		seen:    map[string]struct{}{},
+	}
+	for _, e := range enums {
+		b.enums[e.Name] = struct{}{}
+	}
+	walkOutputStructs(structs, queries, nested, func(s outputStruct) {
+		if _, ok := b.structs[s.Name]; !ok {
+			b.structs[s.Name] = s
+		}
+	})
+	var walk func(data *NestedStructData)
+	walk = func(data *NestedStructData) {
+		if !data.IsEntityStruct {
+			b.add(b.structs[data.StructOut])
+		}
+		for _, child := range data.NestedStructs {
+			walk(child)
+		}
+	}
+	for _, n := range nested {
+		for _, item := range n.NestedDataItems {
+			if item.RootStructData != nil {
+				walk(item.RootStructData)
+			}
+		}
+	}
+	return &TypeScriptFile{Enums: enums, Interfaces: b.interfaces}
+}
+
+// Contents renders the declarations
+func (f *TypeScriptFile) Contents() []byte {
+	var b strings.Builder
+	b.WriteString("// Code generated by sqlc. DO NOT EDIT.\n")
+	for _, e := range f.Enums {
+		b.WriteString("\n")
+		writeTypeScriptComment(&b, e.Comment)
+		values := make([]string, 0, len(e.Constants))
+		for _, c := range e.Constants {
+			values = append(values, fmt.Sprintf("%q", c.Value))
+		}
+		if len(values) == 0 {
+			values = append(values, "never")
+		}
+		fmt.Fprintf(&b, "export type %s = %s;\n", e.Name, strings.Join(values, " | "))
+	}
+	for _, i := range f.Interfaces {
+		b.WriteString("\n")
+		writeTypeScriptComment(&b, i.Comment)
+		fmt.Fprintf(&b, "export interface %s {\n", i.Name)
+		for _, field := range i.Fields {
+			name := field.Name
+			if !isTypeScriptIdentifier(name) {
+				name = fmt.Sprintf("%q", name)
+			}
+			if field.Optional {
+				name += "?"
+			}
+			fmt.Fprintf(&b, "  %s: %s;\n", name, field.Type)
+		}
+		b.WriteString("}\n")
+	}
+	return []byte(b.String())
+}
+
+// writeTypeScriptComment writes a comment as a TSDoc block
+func writeTypeScriptComment(b *strings.Builder, comment string) {
+	comment = strings.TrimSpace(comment)
+	if comment == "" {
+		return
+	}
+	b.WriteString("/**\n")
+	for _, line := range strings.Split(comment, "\n") {
+		fmt.Fprintf(b, " * %s\n", strings.TrimSpace(line))
+	}
+	b.WriteString(" */\n")
+}
+
+// isTypeScriptIdentifier reports whether a property name needs no quotes
+func isTypeScriptIdentifier(name string) bool {
+	for i, r := range name {
+		if r == '_' || r == '$' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || i > 0 && r >= '0' && r <= '9' {
+			continue
+		}
+		return false
+	}
+	return name != ""
+}
@@ -0,0 +1,65 @@
+package golang
+
+import (
+	"testing"
+)
+
+func TestTypeScriptType(t *testing.T) {
+	b := &typescriptBuilder{
+		structs: map[string]outputStruct{"Book": {Name: "Book", Fields: []Field{{Name: "Title", Type: "string", Tags: map[string]string{"json": "title"}}}}},
+		enums:   map[string]struct{}{"Status": {}},
+		seen:    map[string]struct{}{},
+	}
+
+	for _, tc := range []struct {
+		typ  string
+		want string
+	}{
+		{"string", "string"},
+		{"int64", "number"},
+		{"*int32", "number | null"},
+		{"pgtype.Text", "string | null"},
+		{"sql.NullInt64", "{ Int64: number; Valid: boolean }"},
+		{"Status", "Status"},
+		{"NullStatus", "{ Status: Status; Valid: boolean }"},
+		{"[]string", "string[] | null"},
+		{"[]*Book", "(Book | null)[] | null"},
+		{"map[string]int32", "Record<string, number>"},
+		{"pgtype.Hstore", "unknown"},
+	} {
+		if got := b.typ(tc.typ); got != tc.want {
+			t.Errorf("typ(%q) = %q, want %q", tc.typ, got, tc.want)
+		}
+	}
+}
+
+func TestTypeScriptFileContents(t *testing.T) {
+	f := &TypeScriptFile{
+		Enums: []Enum{{Name: "Status", Constants: []Constant{{Value: "open"}, {Value: "closed"}}}},
+		Interfaces: []TypeScriptInterface{{
+			Name:    "AuthorGroup",
+			Comment: "Authors with their books",
+			Fields: []TypeScriptField{
+				{Name: "name", Type: "string"},
+				{Name: "books", Optional: true, Type: "Book[] | null"},
+				{Name: "created-at", Type: "string"},
+			},
+		}},
+	}
+	want := `// Code generated by sqlc. DO NOT EDIT.
+
+export type Status = "open" | "closed";
+
+/**
+ * Authors with their books
+ */
+export interface AuthorGroup {
+  name: string;
+  books?: Book[] | null;
+  "created-at": string;
+}
+`
+	if got := string(f.Contents()); got != want {
+		t.Errorf("Contents() =\n%s\nwant\n%s", got, want)
+	}
+}
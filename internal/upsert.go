@@ -0,0 +1,54 @@
+package golang
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sqlc-dev/plugin-sdk-go/metadata"
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+)
+
+// upsertAction is the value of the "-- @upsert action" annotation, generating
+// a variant of an INSERT ... ON CONFLICT reporting what happened to the row
+const upsertAction = "action"
+
+var (
+	onConflictClause = regexp.MustCompile(`(?i)\bON\s+CONFLICT\b`)
+	returningClause  = regexp.MustCompile(`(?i)\bRETURNING\b`)
+)
+
+// queryUpsertSQL returns the SQL of the variant of a query annotated with
+// "-- @upsert action", which returns (xmax = 0) to tell an inserted row from
+// an updated one. A conflict skipping the row returns no row at all.
+func queryUpsertSQL(query *plugin.Query, annotations map[string]string) (string, error) {
+	value, ok := annotations[annotationUpsert]
+	if !ok {
+		return "", nil
+	}
+	if value != upsertAction {
+		return "", fmt.Errorf("unknown upsert mode %q, must be %s", value, upsertAction)
+	}
+	switch query.Cmd {
+	case metadata.CmdExec, metadata.CmdExecRows, metadata.CmdExecResult:
+	default:
+		return "", fmt.Errorf("upsert is not supported for %s queries", query.Cmd)
+	}
+	sql := strings.TrimRight(query.Text, "; \t\n")
+	if !onConflictClause.MatchString(sql) {
+		return "", fmt.Errorf("upsert requires an INSERT ... ON CONFLICT query")
+	}
+	if returningClause.MatchString(sql) {
+		return "", fmt.Errorf("upsert requires a query without RETURNING")
+	}
+	return sql + " RETURNING (xmax = 0) AS inserted", nil
+}
+
+func usesUpsert(queries []Query) bool {
+	for _, q := range queries {
+		if q.UpsertSQL != "" {
+			return true
+		}
+	}
+	return false
+}
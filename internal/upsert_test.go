@@ -0,0 +1,67 @@
+package golang
+
+import (
+	"testing"
+
+	"github.com/sqlc-dev/plugin-sdk-go/metadata"
+	"github.com/sqlc-dev/plugin-sdk-go/plugin"
+)
+
+func TestQueryUpsertSQL(t *testing.T) {
+	tests := []struct {
+		name    string
+		sql     string
+		cmd     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "do update",
+			sql:  "INSERT INTO authors (id, name) VALUES ($1, $2) ON CONFLICT (id) DO UPDATE SET name = excluded.name",
+			cmd:  metadata.CmdExec,
+			want: "INSERT INTO authors (id, name) VALUES ($1, $2) ON CONFLICT (id) DO UPDATE SET name = excluded.name RETURNING (xmax = 0) AS inserted",
+		},
+		{
+			name: "do nothing",
+			sql:  "INSERT INTO authors (id) VALUES ($1) on conflict do nothing;\n",
+			cmd:  metadata.CmdExecRows,
+			want: "INSERT INTO authors (id) VALUES ($1) on conflict do nothing RETURNING (xmax = 0) AS inserted",
+		},
+		{
+			name:    "no conflict clause",
+			sql:     "INSERT INTO authors (id) VALUES ($1)",
+			cmd:     metadata.CmdExec,
+			wantErr: true,
+		},
+		{
+			name:    "returning",
+			sql:     "INSERT INTO authors (id) VALUES ($1) ON CONFLICT DO NOTHING RETURNING id",
+			cmd:     metadata.CmdExec,
+			wantErr: true,
+		},
+		{
+			name:    "returning rows",
+			sql:     "INSERT INTO authors (id) VALUES ($1) ON CONFLICT DO NOTHING",
+			cmd:     metadata.CmdOne,
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			query := &plugin.Query{Text: tc.sql, Cmd: tc.cmd}
+			got, err := queryUpsertSQL(query, map[string]string{annotationUpsert: upsertAction})
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("queryUpsertSQL() = %q, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("queryUpsertSQL() failed: %s", err)
+			}
+			if got != tc.want {
+				t.Errorf("queryUpsertSQL() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package golang
+
+import (
+	"runtime/debug"
+	"time"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/opts"
+)
+
+// modulePath is the path of the module of the plugin, which is looked up in
+// the build info of the binary it is built into
+const modulePath = "github.com/sqlc-dev/sqlc-gen-go"
+
+// Version and Commit identify the build of the plugin in the version file.
+// They can be set with
+// -ldflags "-X github.com/sqlc-dev/sqlc-gen-go/internal.Version=v1.2.3", and
+// otherwise come from the module version and VCS revision of the build info.
+var (
+	Version string
+	Commit  string
+)
+
+// VersionInfo holds the constants of the version file
+type VersionInfo struct {
+	SqlcVersion string
+	Version     string
+	Commit      string
+	GeneratedAt string
+}
+
+func buildVersionInfo(options *opts.Options, sqlcVersion string) *VersionInfo {
+	v := &VersionInfo{
+		SqlcVersion: sqlcVersion,
+		Version:     Version,
+		Commit:      Commit,
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		module := &info.Main
+		for _, dep := range info.Deps {
+			if dep.Path == modulePath {
+				module = dep
+			}
+		}
+		if v.Version == "" && module.Path == modulePath {
+			v.Version = module.Version
+		}
+		// The VCS revision is only recorded for the main module
+		if v.Commit == "" && module == &info.Main {
+			for _, s := range info.Settings {
+				if s.Key == "vcs.revision" {
+					v.Commit = s.Value
+				}
+			}
+		}
+	}
+	if options.EmitGenerationTimestamp {
+		v.GeneratedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+	return v
+}
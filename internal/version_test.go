@@ -0,0 +1,74 @@
+package golang
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/sqlc-dev/sqlc-gen-go/internal/golden"
+)
+
+// versionConstants generates the authors endtoend case, sent by sqlc v1.23.0,
+// with options and returns the constants declared by its version.go
+func versionConstants(t *testing.T, options string) map[string]string {
+	t.Helper()
+	req, err := golden.ReadRequest("endtoend/testdata/authors")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.PluginOptions = []byte(options)
+	resp, err := Generate(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range resp.Files {
+		if f.Name != "version.go" {
+			continue
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), f.Name, f.Contents, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		constants := map[string]string{}
+		ast.Inspect(file, func(n ast.Node) bool {
+			if spec, ok := n.(*ast.ValueSpec); ok {
+				value, err := strconv.Unquote(spec.Values[0].(*ast.BasicLit).Value)
+				if err != nil {
+					t.Fatal(err)
+				}
+				constants[spec.Names[0].Name] = value
+			}
+			return true
+		})
+		return constants
+	}
+	t.Fatal("version.go was not generated")
+	return nil
+}
+
+func TestVersionFile(t *testing.T) {
+	defer func(version, commit string) { Version, Commit = version, commit }(Version, Commit)
+	Version, Commit = "v1.2.3", "abc123"
+
+	constants := versionConstants(t, `{"package": "db", "sql_package": "pgx/v5", "emit_version_file": true}`)
+	want := map[string]string{"SqlcVersion": "v1.23.0", "GeneratorVersion": "v1.2.3", "GeneratorCommit": "abc123"}
+	if len(constants) != len(want) {
+		t.Errorf("version.go declares %v, want %v", constants, want)
+	}
+	for name, value := range want {
+		if constants[name] != value {
+			t.Errorf("%s = %q, want %q", name, constants[name], value)
+		}
+	}
+
+	before := time.Now().UTC().Truncate(time.Second)
+	constants = versionConstants(t, `{"package": "db", "sql_package": "pgx/v5", "emit_version_file": true, "emit_generation_timestamp": true}`)
+	generated, err := time.Parse(time.RFC3339, constants["GeneratedAt"])
+	if err != nil || generated.Before(before) || generated.After(time.Now()) {
+		t.Errorf("GeneratedAt = %q, want the time of the generation", constants["GeneratedAt"])
+	}
+}